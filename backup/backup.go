@@ -0,0 +1,270 @@
+// Package backup reads and writes portable backup archives: zip files holding one NDJSON file
+// per entity and a manifest recording the format version and each file's count and checksum.
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+// Format identifies backup archives in their manifest
+const Format = "gofr-blog-backup"
+
+// Version is the archive layout written by this package; archives of later versions are rejected
+const Version = 1
+
+// Entries of an archive
+const (
+	ManifestName = "manifest.json"
+	PostsName    = EntityPosts + ".ndjson"
+)
+
+// Entities an archive holds, each in a file named after it. Records of other tables refer to
+// posts and users by their archived id.
+const (
+	EntityPosts        = "posts"
+	EntityTags         = "post_tags"
+	EntityTranslations = "post_translations"
+	EntityUsers        = "users"
+	EntityAPIKeys      = "api_keys"
+	EntityWebhooks     = "webhook_subscriptions"
+	EntityAudit        = "audit_log"
+)
+
+// maxLineSize caps the NDJSON lines read from an archive
+const maxLineSize = 16 << 20
+
+var (
+	ErrInvalidArchive     = errors.New("invalid backup archive")
+	ErrUnsupportedVersion = errors.New("unsupported backup archive version")
+	ErrChecksumMismatch   = errors.New("backup archive checksum mismatch")
+
+	errEntityWritten = errors.New("records of an entity must be written together")
+)
+
+// Manifest describes the contents of an archive
+type Manifest struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     []File    `json:"files"`
+}
+
+// File is an NDJSON file of an archive, holding Count records of Entity
+type File struct {
+	Name   string `json:"name"`
+	Entity string `json:"entity"`
+	Count  int    `json:"count"`
+	SHA256 string `json:"sha256"`
+}
+
+// Contents holds the records of an archive by entity. Entities the archive has no file for,
+// such as those of archives written before they were added, are empty.
+type Contents struct {
+	Posts        []models.Post
+	Tags         []models.PostTags
+	Translations []models.PostTranslation
+	Users        []models.User
+	APIKeys      []models.APIKeyRecord
+	Webhooks     []models.WebhookSubscription
+	Audit        []models.AuditEntry
+}
+
+// Writer writes an archive entity by entity, starting with posts. Records are written as they
+// are added, and the manifest follows them once their checksums are known.
+type Writer struct {
+	zip   *zip.Writer
+	files []File
+	file  io.Writer
+	hash  hash.Hash
+}
+
+// NewWriter starts an archive written to w
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := &Writer{zip: zip.NewWriter(w)}
+	if err := bw.Begin(EntityPosts); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+// Begin ends the current file and starts the file of entity, which the manifest lists even
+// when no records are added to it. An entity can only be begun once.
+func (bw *Writer) Begin(entity string) error {
+	for _, file := range bw.files {
+		if file.Entity == entity {
+			return errors.Join(errEntityWritten, errors.New(entity))
+		}
+	}
+
+	bw.endFile()
+	name := entity + ".ndjson"
+	file, err := bw.zip.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	bw.hash = sha256.New()
+	bw.file = io.MultiWriter(file, bw.hash)
+	bw.files = append(bw.files, File{Name: name, Entity: entity})
+	return nil
+}
+
+// AddPost writes post as the next line of the posts file, which must be the current file
+func (bw *Writer) AddPost(post *models.Post) error {
+	if current := bw.files[len(bw.files)-1].Entity; current != EntityPosts {
+		return errors.Join(errEntityWritten, errors.New(EntityPosts+" after "+current))
+	}
+	return bw.Add(post)
+}
+
+// Add writes record as the next line of the current file
+func (bw *Writer) Add(record any) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err = bw.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	bw.files[len(bw.files)-1].Count++
+	return nil
+}
+
+// endFile records the checksum of the current file
+func (bw *Writer) endFile() {
+	if len(bw.files) > 0 {
+		bw.files[len(bw.files)-1].SHA256 = hex.EncodeToString(bw.hash.Sum(nil))
+	}
+}
+
+// Close writes the manifest and finishes the archive; it does not close the underlying writer
+func (bw *Writer) Close() error {
+	bw.endFile()
+	manifest := Manifest{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Files:     bw.files,
+	}
+
+	file, err := bw.zip.Create(ManifestName)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(manifest); err != nil {
+		return err
+	}
+	return bw.zip.Close()
+}
+
+// Read verifies an archive against its manifest and returns the manifest and the records in it
+func Read(data []byte) (*Manifest, *Contents, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, errors.Join(ErrInvalidArchive, err)
+	}
+
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents := &Contents{}
+	for _, file := range manifest.Files {
+		switch file.Entity {
+		case EntityPosts:
+			contents.Posts, err = readRecords[models.Post](archive, file)
+		case EntityTags:
+			contents.Tags, err = readRecords[models.PostTags](archive, file)
+		case EntityTranslations:
+			contents.Translations, err = readRecords[models.PostTranslation](archive, file)
+		case EntityUsers:
+			contents.Users, err = readRecords[models.User](archive, file)
+		case EntityAPIKeys:
+			contents.APIKeys, err = readRecords[models.APIKeyRecord](archive, file)
+		case EntityWebhooks:
+			contents.Webhooks, err = readRecords[models.WebhookSubscription](archive, file)
+		case EntityAudit:
+			contents.Audit, err = readRecords[models.AuditEntry](archive, file)
+		default:
+			// Entities of later minor additions are not restored by this version
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return manifest, contents, nil
+}
+
+// readManifest reads and checks the manifest of an archive
+func readManifest(archive *zip.Reader) (*Manifest, error) {
+	file, err := archive.Open(ManifestName)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidArchive, errors.New("missing "+ManifestName))
+	}
+	defer file.Close()
+
+	var manifest Manifest
+	if err = json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, errors.Join(ErrInvalidArchive, err)
+	}
+	switch {
+	case manifest.Format != Format:
+		return nil, errors.Join(ErrInvalidArchive, errors.New("unknown format: "+manifest.Format))
+	case manifest.Version < 1 || manifest.Version > Version:
+		return nil, errors.Join(ErrUnsupportedVersion, errors.New("version "+strconv.Itoa(manifest.Version)))
+	}
+	return &manifest, nil
+}
+
+// readRecords reads a file of records of an archive, checking its checksum and count
+func readRecords[T any](archive *zip.Reader, entry File) ([]T, error) {
+	file, err := archive.Open(entry.Name)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidArchive, errors.New("missing "+entry.Name))
+	}
+	defer file.Close()
+
+	digest := sha256.New()
+	scanner := bufio.NewScanner(io.TeeReader(file, digest))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var records []T
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record T
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, errors.Join(ErrInvalidArchive, errors.New(entry.Name+" line "+strconv.Itoa(line)), err)
+		}
+		records = append(records, record)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Join(ErrInvalidArchive, err)
+	}
+
+	if sum := hex.EncodeToString(digest.Sum(nil)); sum != entry.SHA256 {
+		return nil, errors.Join(ErrChecksumMismatch, errors.New(entry.Name))
+	}
+	if len(records) != entry.Count {
+		return nil, errors.Join(ErrInvalidArchive, errors.New(entry.Name+" holds "+strconv.Itoa(len(records))+
+			" records, the manifest lists "+strconv.Itoa(entry.Count)))
+	}
+	return records, nil
+}
@@ -0,0 +1,133 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+
+	"gofr-blog-service/models"
+)
+
+// writeArchive writes posts to an archive and returns its bytes
+func writeArchive(t *testing.T, posts []models.Post) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	for i := range posts {
+		if err = writer.AddPost(&posts[i]); err != nil {
+			t.Fatalf("AddPost: %v", err)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestRoundTrip tests that written posts are read back with a matching manifest
+func TestRoundTrip(t *testing.T) {
+	posts := []models.Post{
+		{ID: 3, Title: "Third", Slug: "third", Content: "Line one\nline two", Status: "published"},
+		{ID: 1, Title: "First", Slug: "first", Status: "draft"},
+	}
+
+	manifest, read, err := Read(writeArchive(t, posts))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if manifest.Version != Version || len(manifest.Files) != 1 || manifest.Files[0].Count != 2 {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+	if len(read.Posts) != 2 || read.Posts[0].Slug != "third" || read.Posts[0].Content != posts[0].Content ||
+		read.Posts[1].ID != 1 {
+		t.Errorf("unexpected posts %+v", read.Posts)
+	}
+}
+
+// TestRoundTrip_Entities tests that the records of other tables are read back by entity and that
+// an entity is written only once
+func TestRoundTrip_Entities(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err = writer.AddPost(&models.Post{ID: 1, Slug: "first"}); err != nil {
+		t.Fatalf("AddPost: %v", err)
+	}
+	if err = writer.Begin(EntityTags); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err = writer.Add(models.PostTags{PostID: 1, Tags: []string{"go"}}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err = writer.Begin(EntityUsers); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err = writer.AddPost(&models.Post{ID: 2}); err == nil {
+		t.Error("expected an error adding a post after the posts file")
+	}
+	if err = writer.Begin(EntityTags); err == nil {
+		t.Error("expected an error beginning an entity twice")
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	manifest, read, err := Read(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(manifest.Files) != 3 || manifest.Files[2].Entity != EntityUsers || manifest.Files[2].Count != 0 {
+		t.Errorf("unexpected manifest files %+v", manifest.Files)
+	}
+	if len(read.Posts) != 1 || len(read.Tags) != 1 || read.Tags[0].Tags[0] != "go" || len(read.Users) != 0 {
+		t.Errorf("unexpected contents %+v", read)
+	}
+}
+
+// TestRead_Rejects tests that tampered, foreign and newer archives are rejected
+func TestRead_Rejects(t *testing.T) {
+	// rebuild copies an archive, changing one entry
+	rebuild := func(data []byte, name string, content []byte) []byte {
+		reader, _ := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		var buf bytes.Buffer
+		writer := zip.NewWriter(&buf)
+		for _, file := range reader.File {
+			entry, _ := writer.Create(file.Name)
+			if file.Name == name {
+				_, _ = entry.Write(content)
+				continue
+			}
+			rc, _ := file.Open()
+			var original bytes.Buffer
+			_, _ = original.ReadFrom(rc)
+			_, _ = entry.Write(original.Bytes())
+		}
+		_ = writer.Close()
+		return buf.Bytes()
+	}
+
+	data := writeArchive(t, []models.Post{{ID: 1, Slug: "first"}})
+	tests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"not a zip", []byte("posts"), ErrInvalidArchive},
+		{"tampered posts", rebuild(data, PostsName, []byte(`{"id":1,"slug":"other"}`+"\n")), ErrChecksumMismatch},
+		{"foreign format", rebuild(data, ManifestName, []byte(`{"format":"other","version":1}`)), ErrInvalidArchive},
+		{"newer version", rebuild(data, ManifestName, []byte(`{"format":"`+Format+`","version":99}`)), ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		if _, _, err := Read(tt.data); !errors.Is(err, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Package cache provides a read-through cache backed by GoFr's Redis datasource,
+// with an in-process fallback when Redis is not configured.
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ErrMiss is returned by Get when a key is absent or expired
+var ErrMiss = errors.New("cache miss")
+
+// Cache stores byte values with a time to live
+type Cache interface {
+	// Get returns the value of key or ErrMiss
+	Get(ctx *gofr.Context, key string) ([]byte, error)
+	// Set stores value under key for ttl
+	Set(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes keys
+	Delete(ctx *gofr.Context, keys ...string) error
+	// Incr increments the integer counter at key and returns its new value
+	Incr(ctx *gofr.Context, key string) (int64, error)
+	// SetNX stores value under key for ttl only when key is absent and reports whether it did
+	SetNX(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// TestMemory_Expiry tests that values expire after their TTL while counters persist
+func TestMemory_Expiry(t *testing.T) {
+	m := NewMemory(0)
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	_ = m.Set(nil, "post", []byte("v1"), time.Minute)
+	if value, err := m.Get(nil, "post"); err != nil || string(value) != "v1" {
+		t.Fatalf("Expected v1, got %q (%v)", value, err)
+	}
+
+	if n, _ := m.Incr(nil, "generation"); n != 1 {
+		t.Errorf("Expected counter 1, got %d", n)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := m.Get(nil, "post"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Expected ErrMiss after expiry, got %v", err)
+	}
+	if n, _ := m.Incr(nil, "generation"); n != 2 {
+		t.Errorf("Expected counter 2, got %d", n)
+	}
+}
+
+// TestMemory_SetNXAndEviction tests SetNX ownership and the entry limit
+func TestMemory_SetNXAndEviction(t *testing.T) {
+	m := NewMemory(2)
+
+	if ok, _ := m.SetNX(nil, "lock", []byte("1"), time.Minute); !ok {
+		t.Error("Expected the first SetNX to succeed")
+	}
+	if ok, _ := m.SetNX(nil, "lock", []byte("1"), time.Minute); ok {
+		t.Error("Expected the second SetNX to fail")
+	}
+
+	_ = m.Set(nil, "a", []byte("a"), time.Minute)
+	_ = m.Set(nil, "b", []byte("b"), time.Minute)
+	if len(m.entries) > 2 {
+		t.Errorf("Expected at most 2 entries, got %d", len(m.entries))
+	}
+}
+
+// TestMemory_EvictionKeepsCounters tests that a full cache evicts cached values, never counters
+func TestMemory_EvictionKeepsCounters(t *testing.T) {
+	m := NewMemory(2)
+
+	_, _ = m.Incr(nil, "generation")
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_ = m.Set(nil, key, []byte(key), time.Minute)
+	}
+
+	if value, err := m.Get(nil, "generation"); err != nil || string(value) != "1" {
+		t.Errorf("Expected the counter to survive eviction, got %q, %v", value, err)
+	}
+	if len(m.entries) > 2 {
+		t.Errorf("Expected at most 2 entries, got %d", len(m.entries))
+	}
+}
+
+// TestLoader_Load tests that concurrent misses share one load and that errors are not cached
+func TestLoader_Load(t *testing.T) {
+	ctx := &gofr.Context{}
+	loader := NewLoader(NewMemory(0), time.Minute)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() ([]byte, error) {
+		loads.Add(1)
+		<-release
+		return []byte("post"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := loader.Load(ctx, "posts:id:1", load); err != nil || string(value) != "post" {
+				t.Errorf("Expected post, got %q (%v)", value, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Errorf("Expected a single load, got %d", loads.Load())
+	}
+
+	errNotFound := errors.New("not found")
+	for i := 0; i < 2; i++ {
+		_, err := loader.Load(ctx, "posts:id:2", func() ([]byte, error) {
+			loads.Add(1)
+			return nil, errNotFound
+		})
+		if !errors.Is(err, errNotFound) {
+			t.Errorf("Expected the load error, got %v", err)
+		}
+	}
+	if loads.Load() != 3 {
+		t.Errorf("Expected failed loads not to be cached, got %d loads", loads.Load())
+	}
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Stampede protection settings
+const (
+	// lockTTL bounds how long a loader holds the fill lock of a key
+	lockTTL = 5 * time.Second
+	// lockWait is how long callers that lost the fill lock poll the cache before loading themselves
+	lockWait = 250 * time.Millisecond
+	// lockPoll is the interval between those polls
+	lockPoll = 25 * time.Millisecond
+	// ttlJitter spreads expiries of keys filled together by up to this fraction of the TTL
+	ttlJitter = 0.1
+)
+
+// call is an in-flight load shared by concurrent callers of one key
+type call struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+// Loader reads values through a cache. Concurrent misses of a key in one process share a
+// single load, and a short-lived lock in the cache lets one instance fill a key at a time.
+type Loader struct {
+	cache Cache
+	ttl   time.Duration
+
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// NewLoader creates a loader that caches loaded values for about ttl
+func NewLoader(cache Cache, ttl time.Duration) *Loader {
+	return &Loader{
+		cache: cache,
+		ttl:   ttl,
+		calls: make(map[string]*call),
+	}
+}
+
+// Load returns the cached value of key, calling load and caching its result on a miss.
+// Cache failures are logged and never fail the read; errors from load are returned unchanged
+// and are not cached.
+func (l *Loader) Load(ctx *gofr.Context, key string, load func() ([]byte, error)) ([]byte, error) {
+	value, err := l.cache.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, ErrMiss) {
+		ctx.Logger.Errorf("Cache read of %s failed: %v", key, err)
+	}
+
+	l.mu.Lock()
+	if c, ok := l.calls[key]; ok {
+		l.mu.Unlock()
+		<-c.done
+		return c.value, c.err
+	}
+	c := &call{done: make(chan struct{})}
+	l.calls[key] = c
+	l.mu.Unlock()
+
+	c.value, c.err = l.fill(ctx, key, load)
+
+	l.mu.Lock()
+	delete(l.calls, key)
+	l.mu.Unlock()
+	close(c.done)
+
+	return c.value, c.err
+}
+
+// fill loads key once across instances when possible and stores the result
+func (l *Loader) fill(ctx *gofr.Context, key string, load func() ([]byte, error)) ([]byte, error) {
+	lockKey := "lock:" + key
+	locked, err := l.cache.SetNX(ctx, lockKey, []byte("1"), lockTTL)
+	if err != nil {
+		ctx.Logger.Errorf("Cache lock of %s failed: %v", key, err)
+	}
+
+	if err == nil && !locked {
+		// Another instance is filling the key; give it a moment before loading ourselves
+		for waited := time.Duration(0); waited < lockWait; waited += lockPoll {
+			time.Sleep(lockPoll)
+			if value, getErr := l.cache.Get(ctx, key); getErr == nil {
+				return value, nil
+			}
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		if locked {
+			l.release(ctx, lockKey)
+		}
+		return nil, err
+	}
+
+	if setErr := l.cache.Set(ctx, key, value, l.jitteredTTL()); setErr != nil {
+		ctx.Logger.Errorf("Cache write of %s failed: %v", key, setErr)
+	}
+	if locked {
+		l.release(ctx, lockKey)
+	}
+	return value, nil
+}
+
+// release drops a fill lock
+func (l *Loader) release(ctx *gofr.Context, lockKey string) {
+	if err := l.cache.Delete(ctx, lockKey); err != nil {
+		ctx.Logger.Errorf("Cache unlock of %s failed: %v", lockKey, err)
+	}
+}
+
+// jitteredTTL returns the TTL shortened by a random amount so keys filled together expire apart
+func (l *Loader) jitteredTTL() time.Duration {
+	jitter := time.Duration(rand.Float64() * ttlJitter * float64(l.ttl))
+	return l.ttl - jitter
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// DefaultMemoryEntries is the default capacity of a memory cache
+const DefaultMemoryEntries = 10000
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// expired reports whether the entry has expired at now; a zero expiry never expires
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// Memory is an in-process cache for single instances, tests and local runs. It holds at most
+// its capacity of entries with a time to live; counters are kept beyond it.
+type Memory struct {
+	mu         sync.Mutex
+	entries    map[string]memoryEntry
+	maxEntries int
+	now        func() time.Time
+}
+
+// NewMemory creates a memory cache holding at most maxEntries values
+func NewMemory(maxEntries int) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryEntries
+	}
+	return &Memory{
+		entries:    make(map[string]memoryEntry),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// Get returns the value of key or ErrMiss
+func (m *Memory) Get(_ *gofr.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok || entry.expired(m.now()) {
+		delete(m.entries, key)
+		return nil, ErrMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores value under key for ttl
+func (m *Memory) Set(_ *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(key, value, ttl)
+	return nil
+}
+
+// Delete removes keys
+func (m *Memory) Delete(_ *gofr.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+// Incr increments the integer counter at key and returns its new value
+func (m *Memory) Incr(_ *gofr.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var current int64
+	if entry, ok := m.entries[key]; ok && !entry.expired(m.now()) {
+		current, _ = strconv.ParseInt(string(entry.value), 10, 64)
+	}
+	current++
+	m.set(key, []byte(strconv.FormatInt(current, 10)), 0)
+	return current, nil
+}
+
+// SetNX stores value under key for ttl only when key is absent and reports whether it did
+func (m *Memory) SetNX(_ *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry, ok := m.entries[key]; ok && !entry.expired(m.now()) {
+		return false, nil
+	}
+	m.set(key, value, ttl)
+	return true, nil
+}
+
+// set stores an entry, making room first when the cache is full. The caller holds the lock.
+func (m *Memory) set(key string, value []byte, ttl time.Duration) {
+	if _, exists := m.entries[key]; !exists && len(m.entries) >= m.maxEntries {
+		m.evict()
+	}
+
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = m.now().Add(ttl)
+	}
+	m.entries[key] = entry
+}
+
+// evict drops expired entries, or arbitrary entries with a time to live when none have expired.
+// Entries without one, such as counters, are state rather than copies of it and are never
+// evicted: losing a generation counter would resurrect entries cached under an older generation.
+func (m *Memory) evict() {
+	now := m.now()
+	for key, entry := range m.entries {
+		if entry.expired(now) {
+			delete(m.entries, key)
+		}
+	}
+
+	for key, entry := range m.entries {
+		if len(m.entries) < m.maxEntries {
+			return
+		}
+		if !entry.expiresAt.IsZero() {
+			delete(m.entries, key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gofr.dev/pkg/gofr"
+)
+
+// Redis caches values in GoFr's Redis datasource, using a memory cache instead
+// when no Redis connection is configured
+type Redis struct {
+	fallback *Memory
+}
+
+// NewRedis creates a Redis cache that falls back to fallback without Redis
+func NewRedis(fallback *Memory) *Redis {
+	return &Redis{fallback: fallback}
+}
+
+// Get returns the value of key or ErrMiss
+func (r *Redis) Get(ctx *gofr.Context, key string) ([]byte, error) {
+	if ctx.Redis == nil {
+		return r.fallback.Get(ctx, key)
+	}
+
+	value, err := ctx.Redis.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+// Set stores value under key for ttl
+func (r *Redis) Set(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	if ctx.Redis == nil {
+		return r.fallback.Set(ctx, key, value, ttl)
+	}
+	return ctx.Redis.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete removes keys
+func (r *Redis) Delete(ctx *gofr.Context, keys ...string) error {
+	if ctx.Redis == nil {
+		return r.fallback.Delete(ctx, keys...)
+	}
+	return ctx.Redis.Del(ctx, keys...).Err()
+}
+
+// Incr increments the integer counter at key and returns its new value
+func (r *Redis) Incr(ctx *gofr.Context, key string) (int64, error) {
+	if ctx.Redis == nil {
+		return r.fallback.Incr(ctx, key)
+	}
+	return ctx.Redis.Incr(ctx, key).Result()
+}
+
+// SetNX stores value under key for ttl only when key is absent and reports whether it did
+func (r *Redis) SetNX(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if ctx.Redis == nil {
+		return r.fallback.SetNX(ctx, key, value, ttl)
+	}
+	return ctx.Redis.SetNX(ctx, key, value, ttl).Result()
+}
@@ -0,0 +1,171 @@
+// Command blogctl runs admin tasks against the blog database with the service's own rules:
+// mutations are validated, audited, cached and evented exactly as through the API.
+//
+//	blogctl posts list -status=draft -output=json
+//	blogctl posts create -file=post.md -author_id=3
+//	blogctl regenerate slugs -dry-run
+//	blogctl import wordpress -file=export.xml -dry-run
+//	blogctl export site -format=jekyll -status=published
+//	blogctl backup restore -file=backup.zip -strategy=rename
+//	blogctl users create -name=Ada -email=ada@example.com -role=admin
+//	blogctl keys create -user_id=1 -name=deploy
+//
+// It reads the same configs/.env as the server.
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"gofr-blog-service/cache"
+	"gofr-blog-service/handlers"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+	"gofr-blog-service/store"
+)
+
+// Help on the flags shared by subcommands
+const (
+	outputHelp = "\n-output=table|json selects the output format (default table)."
+	actorHelp  = "\n-actor= names the caller in the audit log (default blogctl:$USER)."
+)
+
+func main() {
+	app := gofr.NewCMD()
+
+	// Domain events go to the same topics as the server's, and reach consumers through its outbox relay
+	topics := services.DefaultEventTopics()
+	for eventType, topic := range map[string]string{
+		models.PostCreated:   app.Config.Get("POST_CREATED_TOPIC"),
+		models.PostUpdated:   app.Config.Get("POST_UPDATED_TOPIC"),
+		models.PostPublished: app.Config.Get("POST_PUBLISHED_TOPIC"),
+		models.PostDeleted:   app.Config.Get("POST_DELETED_TOPIC"),
+	} {
+		if topic != "" {
+			topics[eventType] = topic
+		}
+	}
+
+	postService := services.NewPostService(store.NewPostStore(), store.NewOutboxStore(), store.NewWebhookStore(),
+		store.NewAuditStore(), services.NewPostEvents(topics))
+
+	// Mutations drop the posts they change from the server's cache; without REDIS_HOST there is no
+	// shared cache to keep consistent
+	postService.SetCache(services.NewPostCache(cache.NewRedis(cache.NewMemory(cache.DefaultMemoryEntries)),
+		time.Duration(configInt(app, "CACHE_TTL_SECONDS", 300))*time.Second))
+
+	postHandler := handlers.NewPostHandler(postService, nil, nil)
+	tagService := services.NewTagService(store.NewTagStore(), postService)
+	importService := services.NewImportService(store.NewImportStore(), postService, postHandler.CreateValidator(),
+		services.ImportConfig{BatchSize: configInt(app, "IMPORT_BATCH_SIZE", services.DefaultImportBatchSize)})
+	importService.SetTags(tagService)
+
+	exportService := services.NewExportService(store.NewPostStore(), services.SiteConfig{
+		BaseURL: app.Config.GetOrDefault("SITE_URL", "http://localhost:8000"),
+	})
+	exportService.SetTags(tagService)
+
+	// Restored webhook subscriptions get the URL check of new ones
+	allowPrivateWebhooks, _ := strconv.ParseBool(app.Config.Get("WEBHOOK_ALLOW_PRIVATE_URLS"))
+	backupService := services.NewBackupService(postService, postHandler.CreateValidator())
+	backupService.SetWebhookSender(services.NewWebhookSender(
+		time.Duration(configInt(app, "WEBHOOK_TIMEOUT_SECONDS", 10))*time.Second, allowPrivateWebhooks))
+
+	// Users and API keys; the admin key is not needed, since blogctl runs with database access
+	userService := services.NewUserService(store.NewUserStore(), "")
+
+	cli := handlers.NewAdminCLI(postHandler, importService, exportService, backupService, userService)
+
+	// Posts
+	app.SubCommand("posts list", cli.ListPosts,
+		gofr.AddDescription("List posts, newest first"),
+		gofr.AddHelp("-status=, -author_id= filter the posts; -limit= (default 20, max 100) and -after=cursor page them."+outputHelp))
+	app.SubCommand("posts get", cli.GetPost,
+		gofr.AddDescription("Show a post"),
+		gofr.AddHelp("-id= or -slug= selects the post."+outputHelp))
+	app.SubCommand("posts create", cli.CreatePost,
+		gofr.AddDescription("Create a post from a Markdown file"),
+		gofr.AddHelp("-file= is a Markdown file whose front matter sets title, slug, author_id, status, meta_title,\n"+
+			"meta_description, canonical_url, og_image and noindex. -author_id= and -status= override it;\n"+
+			"the slug defaults to one derived from the title and the status to draft."+outputHelp+actorHelp))
+	app.SubCommand("posts publish", cli.PublishPost,
+		gofr.AddDescription("Publish a post"),
+		gofr.AddHelp("-id= selects the post."+outputHelp+actorHelp))
+	app.SubCommand("posts archive", cli.ArchivePost,
+		gofr.AddDescription("Archive a post"),
+		gofr.AddHelp("-id= selects the post."+outputHelp+actorHelp))
+	app.SubCommand("posts delete", cli.DeletePost,
+		gofr.AddDescription("Delete a post"),
+		gofr.AddHelp("-id= selects the post; -yes confirms the deletion."+outputHelp+actorHelp))
+
+	// Derived data
+	app.SubCommand("regenerate slugs", cli.RegenerateSlugs,
+		gofr.AddDescription("Derive post slugs from titles"),
+		gofr.AddHelp("-id=1,2 limits the run to some posts; -dry-run only reports the changes."+outputHelp+actorHelp))
+	app.SubCommand("regenerate cache", cli.RefreshCache,
+		gofr.AddDescription("Drop every cached post and list page"),
+		gofr.AddHelp(strings.TrimPrefix(outputHelp, "\n")))
+
+	// Imports
+	app.SubCommand("import wordpress", cli.ImportWordPress,
+		gofr.AddDescription("Import posts from a WordPress WXR export"),
+		gofr.AddHelp("-file= is the export; -dry-run only reports the conflicts. -default_author_id= sets the author of\n"+
+			"posts by authors missing from the export; -author_map=login:id,... maps exported authors to author ids.\n"+
+			"Slugs and dates are kept; an interrupted import resumes when the same export is imported again."+
+			outputHelp+actorHelp))
+
+	// Exports
+	app.SubCommand("export site", cli.ExportSite,
+		gofr.AddDescription("Export posts as a Hugo or Jekyll site"),
+		gofr.AddHelp("-format=hugo|jekyll lays the zip out for the generator (default hugo); -file= names it\n"+
+			"(default <format>-export.zip). -status= and -from=, -to= (RFC 3339 creation times) filter the posts."+
+			outputHelp))
+
+	// Backups
+	app.SubCommand("backup create", cli.CreateBackup,
+		gofr.AddDescription("Write a backup archive of the posts and the tables that go with them"),
+		gofr.AddHelp("-file= names the archive (default backup-<time>.zip)."+outputHelp))
+	app.SubCommand("backup restore", cli.RestoreBackup,
+		gofr.AddDescription("Restore a backup archive in one transaction"),
+		gofr.AddHelp("-file= is the archive. -strategy= settles posts whose slug is taken: skip (default),\n"+
+			"overwrite the post holding it, or rename to the first free -2, -3 suffix."+outputHelp+actorHelp))
+
+	// Users and API keys
+	app.SubCommand("users create", cli.CreateUser,
+		gofr.AddDescription("Create a user"),
+		gofr.AddHelp("-name= and -email= (unique) describe the user; -role=admin|editor (default editor) sets\n"+
+			"what the user's API keys may do: admins may use the admin endpoints."+outputHelp))
+	app.SubCommand("users list", cli.ListUsers,
+		gofr.AddDescription("List users"),
+		gofr.AddHelp(strings.TrimPrefix(outputHelp, "\n")))
+	app.SubCommand("users disable", cli.DisableUser,
+		gofr.AddDescription("Disable a user, suspending their API keys"),
+		gofr.AddHelp("-id= selects the user."+outputHelp))
+	app.SubCommand("users enable", cli.EnableUser,
+		gofr.AddDescription("Re-enable a disabled user"),
+		gofr.AddHelp("-id= selects the user."+outputHelp))
+	app.SubCommand("keys create", cli.CreateAPIKey,
+		gofr.AddDescription("Create an API key for a user"),
+		gofr.AddHelp("-user_id= selects the user and -name= labels the key. The key is printed once; only its\n"+
+			"hash is stored."+outputHelp))
+	app.SubCommand("keys list", cli.ListAPIKeys,
+		gofr.AddDescription("List API keys by their prefix"),
+		gofr.AddHelp("-user_id= lists the keys of one user."+outputHelp))
+	app.SubCommand("keys revoke", cli.RevokeAPIKey,
+		gofr.AddDescription("Revoke an API key"),
+		gofr.AddHelp("-id= selects the key; -yes confirms the revocation."+outputHelp))
+
+	app.Run()
+}
+
+// configInt reads an integer setting, falling back to def when it is missing or invalid
+func configInt(app *gofr.App, key string, def int) int {
+	value, err := strconv.Atoi(app.Config.Get(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,43 @@
+package gql
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor returns the opaque connection cursor of a post
+func EncodeCursor(post *models.Post) string {
+	raw := strconv.FormatInt(post.CreatedAt.UnixNano(), 10) + ":" + strconv.Itoa(post.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor returned by EncodeCursor
+func DecodeCursor(cursor string) (*models.PostCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	postID, err := strconv.Atoi(id)
+	if err != nil || postID <= 0 {
+		return nil, errInvalidCursor
+	}
+
+	return &models.PostCursor{CreatedAt: time.Unix(0, createdAt).UTC(), ID: postID}, nil
+}
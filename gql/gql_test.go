@@ -0,0 +1,169 @@
+package gql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/parser"
+
+	"gofr-blog-service/cache"
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// TestCursor tests that cursors round-trip and that malformed cursors are rejected
+func TestCursor(t *testing.T) {
+	post := &models.Post{ID: 42, CreatedAt: time.Date(2025, 9, 1, 12, 30, 0, 123456789, time.UTC)}
+
+	cursor, err := DecodeCursor(EncodeCursor(post))
+	if err != nil {
+		t.Fatalf("DecodeCursor: unexpected error %v", err)
+	}
+	if cursor.ID != post.ID || !cursor.CreatedAt.Equal(post.CreatedAt) {
+		t.Errorf("expected cursor of post %d at %v, got %+v", post.ID, post.CreatedAt, cursor)
+	}
+
+	for _, invalid := range []string{"", "not base64!", "MTIz", "YWJjOjE", "MTIzOjA"} {
+		if _, err := DecodeCursor(invalid); !errors.Is(err, errInvalidCursor) {
+			t.Errorf("DecodeCursor(%q): expected errInvalidCursor, got %v", invalid, err)
+		}
+	}
+}
+
+// TestLoader tests that keys loaded before the first thunk runs are fetched in one batch
+func TestLoader(t *testing.T) {
+	var batches [][]int
+	loader := NewLoader(func(keys []int) (map[int]string, error) {
+		batches = append(batches, keys)
+		values := make(map[int]string, len(keys))
+		for _, key := range keys {
+			if key != 3 {
+				values[key] = "author"
+			}
+		}
+		return values, nil
+	})
+
+	first, second, missing, again := loader.Load(1), loader.Load(2), loader.Load(3), loader.Load(1)
+	for _, thunk := range []func() (string, error){first, second, again} {
+		if value, err := thunk(); value != "author" || err != nil {
+			t.Errorf("expected author, got %q (%v)", value, err)
+		}
+	}
+	if value, err := missing(); value != "" || err != nil {
+		t.Errorf("expected the zero value for a missing key, got %q (%v)", value, err)
+	}
+
+	if _, err := loader.Load(4)(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 3 || len(batches[1]) != 1 {
+		t.Errorf("expected batches [1 2 3] and [4], got %v", batches)
+	}
+}
+
+// TestMeasure tests the depth and complexity of queries with lists, fragments and variables
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]any
+		depth      int
+		complexity int
+	}{
+		{"scalar", `{ post(id: 1) { id title } }`, nil, 2, 3},
+		{"default list size", `{ posts { edges { node { id } } } }`, nil, 4, 31},
+		{"first literal", `{ posts(first: 2) { edges { node { id } } } }`, nil, 4, 7},
+		{"first variable", `query($n: Int = 5) { posts(first: $n) { edges { node { id } } } }`,
+			map[string]any{"n": float64(3)}, 4, 10},
+		{"first variable default", `query($n: Int = 5) { posts(first: $n) { edges { node { id } } } }`, nil, 4, 16},
+		{"fragments", `{ post(id: 1) { ...p author { posts(first: 2) { ...p } } } } fragment p on Post { id title }`,
+			nil, 4, 9},
+		{"introspection is free", `{ __typename post(id: 1) { __typename id } }`, nil, 2, 2},
+		{"cyclic fragment", `{ post(id: 1) { ...a } } fragment a on Post { id ...a }`, nil, 2, 2},
+	}
+
+	for _, tt := range tests {
+		doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tt.name, err)
+		}
+
+		depth, complexity := measure(doc, operation(doc, ""), tt.variables)
+		if depth != tt.depth || complexity != tt.complexity {
+			t.Errorf("%s: expected depth %d and complexity %d, got %d and %d",
+				tt.name, tt.depth, tt.complexity, depth, complexity)
+		}
+	}
+}
+
+// TestServer_Limits tests that oversized queries and mutations over GET are refused before execution
+func TestServer_Limits(t *testing.T) {
+	server, err := NewServer(nil, nil, Validators{}, nil, Config{MaxDepth: 3, MaxComplexity: 20})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	ctx := &gofr.Context{}
+
+	tests := []struct {
+		name  string
+		req   Request
+		code  string
+		allow bool
+	}{
+		{"too deep", Request{Query: `{ posts { edges { node { id } } } }`}, CodeQueryTooComplex, true},
+		{"too complex", Request{Query: `{ post(id: 1) { relatedPosts(first: 50) { id title } } }`}, CodeQueryTooComplex, true},
+		{"mutation over GET", Request{Query: `mutation { deletePost(id: 1) }`}, CodeMutationNotAllowed, false},
+		{"missing query", Request{}, CodeBadUserInput, true},
+		{"persisted queries disabled", Request{Extensions: Extensions{PersistedQuery: &PersistedQuery{Version: 1}}},
+			CodePersistedQueryNotSupported, true},
+	}
+
+	for _, tt := range tests {
+		result := server.Execute(ctx, tt.req, tt.allow)
+		if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != tt.code {
+			t.Errorf("%s: expected a %s error, got %+v", tt.name, tt.code, result.Errors)
+		}
+	}
+
+	result := server.Execute(ctx, Request{Query: `{ post(id: 1) { unknown } }`}, true)
+	if len(result.Errors) == 0 || result.Data != nil {
+		t.Errorf("expected a validation error for an unknown field, got %+v", result)
+	}
+}
+
+// TestServer_PersistedQuery tests registering and looking up automatic persisted queries
+func TestServer_PersistedQuery(t *testing.T) {
+	server, err := NewServer(nil, nil, Validators{}, cache.NewMemory(10), Config{})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	ctx := &gofr.Context{}
+
+	query := `{ post(id: 1) { id } }`
+	sum := sha256.Sum256([]byte(query))
+	persisted := &PersistedQuery{Version: 1, SHA256Hash: hex.EncodeToString(sum[:])}
+
+	_, err = server.query(ctx, Request{Extensions: Extensions{PersistedQuery: persisted}})
+	var coded codedError
+	if !errors.As(err, &coded) || coded.code != CodePersistedQueryNotFound || err.Error() != "PersistedQueryNotFound" {
+		t.Errorf("expected PersistedQueryNotFound before registration, got %v", err)
+	}
+
+	if _, err = server.query(ctx, Request{Query: query + " ", Extensions: Extensions{PersistedQuery: persisted}}); !errors.Is(err, errPersistedQueryHash) {
+		t.Errorf("expected a hash mismatch, got %v", err)
+	}
+
+	if got, err := server.query(ctx, Request{Query: query, Extensions: Extensions{PersistedQuery: persisted}}); got != query || err != nil {
+		t.Errorf("expected the query to be registered, got %q (%v)", got, err)
+	}
+
+	if got, err := server.query(ctx, Request{Extensions: Extensions{PersistedQuery: persisted}}); got != query || err != nil {
+		t.Errorf("expected the registered query, got %q (%v)", got, err)
+	}
+}
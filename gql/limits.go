@@ -0,0 +1,165 @@
+package gql
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+
+	"gofr-blog-service/services"
+)
+
+// defaultListSizes is the number of items assumed for list fields queried without a first argument
+var defaultListSizes = map[string]int{
+	"posts":        defaultPostsFirst,
+	"relatedPosts": defaultRelated,
+}
+
+// operation returns the operation of doc named name, or its only operation when name is empty
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		op, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = op
+			continue
+		}
+		if op.Name != nil && op.Name.Value == name {
+			return op
+		}
+	}
+	return found
+}
+
+// measure returns the depth of op and its complexity: every field costs one, and the
+// selections of a list field count once per requested item. Fragments are expanded and
+// introspection fields are free.
+func measure(doc *ast.Document, op *ast.OperationDefinition, variables map[string]any) (depth, complexity int) {
+	m := &measurer{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: make(map[string]any),
+		measured:  make(map[string][2]int),
+		visiting:  make(map[string]bool),
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			m.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, definition := range op.VariableDefinitions {
+		if definition.Variable != nil && definition.DefaultValue != nil {
+			m.variables[definition.Variable.Name.Value] = definition.DefaultValue.GetValue()
+		}
+	}
+	for name, value := range variables {
+		m.variables[name] = value
+	}
+
+	return m.selectionSet(op.SelectionSet, 0)
+}
+
+// measurer walks the selections of an operation
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+	// measured holds the depth and complexity of fragments already walked, so fragments
+	// spread many times are walked once
+	measured map[string][2]int
+	visiting map[string]bool
+}
+
+// selectionSet returns the depth reached by a selection set nested depth fields deep, and its complexity
+func (m *measurer) selectionSet(set *ast.SelectionSet, depth int) (maxDepth, complexity int) {
+	maxDepth = depth
+	if set == nil {
+		return maxDepth, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch node := selection.(type) {
+		case *ast.Field:
+			if node.Name == nil || strings.HasPrefix(node.Name.Value, "__") {
+				continue
+			}
+			d, c = m.selectionSet(node.SelectionSet, depth+1)
+			c = 1 + m.listSize(node)*c
+		case *ast.InlineFragment:
+			d, c = m.selectionSet(node.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if node.Name == nil {
+				continue
+			}
+			d, c = m.fragment(node.Name.Value)
+			d += depth
+		}
+		maxDepth = max(maxDepth, d)
+		complexity += c
+	}
+	return maxDepth, complexity
+}
+
+// fragment returns the depth and complexity of a fragment relative to where it is spread.
+// Unknown and cyclic fragments count as empty; validation rejects them later.
+func (m *measurer) fragment(name string) (depth, complexity int) {
+	if measured, ok := m.measured[name]; ok {
+		return measured[0], measured[1]
+	}
+	fragment := m.fragments[name]
+	if fragment == nil || m.visiting[name] {
+		return 0, 0
+	}
+
+	m.visiting[name] = true
+	depth, complexity = m.selectionSet(fragment.SelectionSet, 0)
+	delete(m.visiting, name)
+
+	m.measured[name] = [2]int{depth, complexity}
+	return depth, complexity
+}
+
+// listSize returns the number of items a field is expected to return
+func (m *measurer) listSize(field *ast.Field) int {
+	size, ok := defaultListSizes[field.Name.Value]
+	if !ok {
+		size = 1
+	}
+
+	for _, argument := range field.Arguments {
+		if argument.Name == nil || argument.Name.Value != "first" {
+			continue
+		}
+		value := argument.Value.GetValue()
+		if variable, isVariable := argument.Value.(*ast.Variable); isVariable && variable.Name != nil {
+			value = m.variables[variable.Name.Value]
+		}
+		if first, valid := intValue(value); valid {
+			size = first
+		}
+	}
+	return min(max(size, 1), services.MaxPostPageSize)
+}
+
+// intValue converts a literal or variable value to an int
+func intValue(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,64 @@
+package gql
+
+import "sync"
+
+// Loader batches the loads of keys requested while one level of a GraphQL query resolves.
+// Load queues a key and returns a thunk; GraphQL resolves thunks breadth-first, so the first
+// thunk called fetches every key queued by its siblings in one call.
+type Loader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+// NewLoader creates a loader that fetches batches of keys with fetch.
+// Keys missing from the fetched map resolve to the zero value.
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// Load queues key and returns a thunk resolving to its value
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		return l.get(key)
+	}
+}
+
+// get returns the value of key, fetching the pending batch first when key is in it
+func (l *Loader[K, V]) get(key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, loaded := l.values[key]
+	if !loaded && l.errs[key] == nil && len(l.pending) > 0 {
+		batch := l.pending
+		l.pending = nil
+
+		values, err := l.fetch(batch)
+		for _, k := range batch {
+			if err != nil {
+				l.errs[k] = err
+				continue
+			}
+			l.values[k] = values[k]
+		}
+	}
+
+	return l.values[key], l.errs[key]
+}
@@ -0,0 +1,129 @@
+package gql
+
+import (
+	"github.com/graphql-go/graphql"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+)
+
+// mutationType builds the post mutations, validated by the same rules as the REST API
+func mutationType(posts *services.PostService, validators Validators, postType *graphql.Object,
+	status *graphql.Enum) *graphql.Object {
+	createInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreatePostInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":           &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"content":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"slug":            &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"authorId":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"status":          &graphql.InputObjectFieldConfig{Type: status, DefaultValue: "draft"},
+			"metaTitle":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"metaDescription": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"canonicalUrl":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"ogImage":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"noIndex":         &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	updateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdatePostInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"content":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"slug":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"status":          &graphql.InputObjectFieldConfig{Type: status},
+			"metaTitle":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"metaDescription": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"canonicalUrl":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"ogImage":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"noIndex":         &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPost": &graphql.Field{
+				Type: graphql.NewNonNull(postType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					req := createRequest(p.Args["input"].(map[string]any))
+					if err := validators.Create(req); err != nil {
+						return nil, userError(err)
+					}
+					return posts.CreatePost(requestFrom(p.Context).ctx, req)
+				},
+			},
+			"updatePost": &graphql.Field{
+				Type: graphql.NewNonNull(postType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, err := idArg(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					req := updateRequest(p.Args["input"].(map[string]any))
+					if err = validators.Update(req); err != nil {
+						return nil, userError(err)
+					}
+					return posts.UpdatePost(requestFrom(p.Context).ctx, id, req)
+				},
+			},
+			"deletePost": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, err := idArg(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					if err = posts.DeletePost(requestFrom(p.Context).ctx, id); err != nil {
+						return nil, err
+					}
+					return true, nil
+				},
+			},
+		},
+	})
+}
+
+// createRequest converts a CreatePostInput to a create request
+func createRequest(input map[string]any) models.CreatePostRequest {
+	var req models.CreatePostRequest
+	req.Title, _ = input["title"].(string)
+	req.Content, _ = input["content"].(string)
+	req.Slug, _ = input["slug"].(string)
+	req.AuthorID, _ = input["authorId"].(int)
+	req.Status, _ = input["status"].(string)
+	req.MetaTitle, _ = input["metaTitle"].(string)
+	req.MetaDescription, _ = input["metaDescription"].(string)
+	req.CanonicalURL, _ = input["canonicalUrl"].(string)
+	req.OGImage, _ = input["ogImage"].(string)
+	req.NoIndex, _ = input["noIndex"].(bool)
+	return req
+}
+
+// updateRequest converts an UpdatePostInput to an update request
+func updateRequest(input map[string]any) models.UpdatePostRequest {
+	var req models.UpdatePostRequest
+	req.Title, _ = input["title"].(string)
+	req.Content, _ = input["content"].(string)
+	req.Slug, _ = input["slug"].(string)
+	req.Status, _ = input["status"].(string)
+	req.MetaTitle, _ = input["metaTitle"].(string)
+	req.MetaDescription, _ = input["metaDescription"].(string)
+	req.CanonicalURL, _ = input["canonicalUrl"].(string)
+	req.OGImage, _ = input["ogImage"].(string)
+	if noIndex, ok := input["noIndex"].(bool); ok {
+		req.NoIndex = &noIndex
+	}
+	return req
+}
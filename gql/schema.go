@@ -0,0 +1,375 @@
+// Package gql serves a GraphQL API over posts, their authors and tags, with per-request batch
+// loading, query depth and complexity limits and automatic persisted queries.
+package gql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Default and maximum list sizes of fields taking a first argument
+const (
+	defaultPostsFirst  = 10
+	defaultAuthorFirst = 10
+	defaultRelated     = 3
+	maxAuthorPosts     = 50
+)
+
+// Validators apply the REST API's request validation to GraphQL mutations
+type Validators struct {
+	Create func(req models.CreatePostRequest) error
+	Update func(req models.UpdatePostRequest) error
+}
+
+// author is the source value of the Author type; authors are known only by the ids of posts
+type author struct {
+	ID int
+}
+
+// connection is the source value of the PostConnection type
+type connection struct {
+	page   *models.PostPage
+	filter models.PostFilter
+}
+
+type requestKey struct{}
+
+// request is the state of one GraphQL request shared by its resolvers
+type request struct {
+	ctx         *gofr.Context
+	authorPosts *Loader[int, []models.Post]
+	postTags    *Loader[int, []string]
+}
+
+// newRequest creates the state of a request, batching author post loads through posts and
+// post tag loads through tags. Without tags every post has none.
+func newRequest(ctx *gofr.Context, posts *services.PostService, tags *services.TagService) *request {
+	return &request{
+		ctx: ctx,
+		authorPosts: NewLoader(func(ids []int) (map[int][]models.Post, error) {
+			// One extra post per author lets relatedPosts skip the post it is related to
+			return posts.PublishedPostsByAuthors(ctx, ids, maxAuthorPosts+1)
+		}),
+		postTags: NewLoader(func(ids []int) (map[int][]string, error) {
+			if tags == nil {
+				return map[int][]string{}, nil
+			}
+			return tags.ForPosts(ctx, ids)
+		}),
+	}
+}
+
+// requestFrom returns the request state carried by the resolver context
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// schema builds the GraphQL schema over posts
+func schema(posts *services.PostService, validators Validators) (graphql.Schema, error) {
+	status := graphql.NewEnum(graphql.EnumConfig{
+		Name: "PostStatus",
+		Values: graphql.EnumValueConfigMap{
+			"draft":     &graphql.EnumValueConfig{Value: "draft"},
+			"published": &graphql.EnumValueConfig{Value: "published"},
+			"archived":  &graphql.EnumValueConfig{Value: "archived"},
+		},
+	})
+
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"id":              postField(graphql.NewNonNull(graphql.ID), func(p *models.Post) any { return p.ID }),
+			"title":           postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.Title }),
+			"content":         postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.Content }),
+			"slug":            postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.Slug }),
+			"authorId":        postField(graphql.NewNonNull(graphql.Int), func(p *models.Post) any { return p.AuthorID }),
+			"status":          postField(graphql.NewNonNull(status), func(p *models.Post) any { return p.Status }),
+			"createdAt":       postField(graphql.NewNonNull(graphql.DateTime), func(p *models.Post) any { return p.CreatedAt }),
+			"updatedAt":       postField(graphql.NewNonNull(graphql.DateTime), func(p *models.Post) any { return p.UpdatedAt }),
+			"publishedAt":     postField(graphql.DateTime, func(p *models.Post) any { return p.PublishedAt }),
+			"metaTitle":       postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.MetaTitle }),
+			"metaDescription": postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.MetaDescription }),
+			"canonicalUrl":    postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.CanonicalURL }),
+			"ogImage":         postField(graphql.NewNonNull(graphql.String), func(p *models.Post) any { return p.OGImage }),
+			"noIndex":         postField(graphql.NewNonNull(graphql.Boolean), func(p *models.Post) any { return p.NoIndex }),
+		},
+	})
+
+	authorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*author).ID, nil
+				},
+			},
+			"posts": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(postType))),
+				Description: "The newest published posts of the author",
+				Args:        firstArg(defaultAuthorFirst),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					load := requestFrom(p.Context).authorPosts.Load(p.Source.(*author).ID)
+					first := clampFirst(p.Args, maxAuthorPosts)
+					return func() (any, error) {
+						posts, err := load()
+						if err != nil {
+							return nil, err
+						}
+						return postPointers(posts, 0, first), nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	postType.AddFieldConfig("author", &graphql.Field{
+		Type: graphql.NewNonNull(authorType),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return &author{ID: p.Source.(*models.Post).AuthorID}, nil
+		},
+	})
+	postType.AddFieldConfig("tags", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+		Description: "The tags of the post in alphabetical order",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			post := p.Source.(*models.Post)
+			if post.Tags != nil {
+				return post.Tags, nil
+			}
+			load := requestFrom(p.Context).postTags.Load(post.ID)
+			return func() (any, error) {
+				tags, err := load()
+				if tags == nil {
+					tags = []string{}
+				}
+				return tags, err
+			}, nil
+		},
+	})
+	postType.AddFieldConfig("relatedPosts", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(postType))),
+		Description: "Other published posts by the same author, newest first",
+		Args:        firstArg(defaultRelated),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			post := p.Source.(*models.Post)
+			load := requestFrom(p.Context).authorPosts.Load(post.AuthorID)
+			first := clampFirst(p.Args, maxAuthorPosts)
+			return func() (any, error) {
+				posts, err := load()
+				if err != nil {
+					return nil, err
+				}
+				return postPointers(posts, post.ID, first), nil
+			}, nil
+		},
+	})
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PostEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return EncodeCursor(p.Source.(*models.Post)), nil
+				},
+			},
+			"node": &graphql.Field{
+				Type: graphql.NewNonNull(postType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*connection).page.HasNextPage, nil
+				},
+			},
+			"endCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					page := p.Source.(*connection).page
+					if len(page.Posts) == 0 {
+						return nil, nil
+					}
+					return EncodeCursor(&page.Posts[len(page.Posts)-1]), nil
+				},
+			},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PostConnection",
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return postPointers(p.Source.(*connection).page.Posts, 0, 0), nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Type: graphql.NewNonNull(pageInfoType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				},
+			},
+			"totalCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of posts matching the filter, counted only when selected",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return posts.CountPosts(requestFrom(p.Context).ctx, p.Source.(*connection).filter)
+				},
+			},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PostFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"status":   &graphql.InputObjectFieldConfig{Type: status},
+			"authorId": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"tag":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"post": &graphql.Field{
+				Type:        postType,
+				Description: "A post by id or slug",
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.ID},
+					"slug": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					ctx := requestFrom(p.Context).ctx
+					if slug, ok := p.Args["slug"].(string); ok {
+						return posts.GetPostBySlug(ctx, slug)
+					}
+					id, err := idArg(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return posts.GetPost(ctx, id, nil)
+				},
+			},
+			"posts": &graphql.Field{
+				Type:        graphql.NewNonNull(connectionType),
+				Description: "Posts newest first, paginated by cursor",
+				Args: graphql.FieldConfigArgument{
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPostsFirst},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String},
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					var after *models.PostCursor
+					if cursor, ok := p.Args["after"].(string); ok {
+						var err error
+						if after, err = DecodeCursor(cursor); err != nil {
+							return nil, userError(err)
+						}
+					}
+
+					var filter models.PostFilter
+					if args, ok := p.Args["filter"].(map[string]any); ok {
+						filter.Status, _ = args["status"].(string)
+						filter.AuthorID, _ = args["authorId"].(int)
+						if tag, ok := args["tag"].(string); ok {
+							if filter.Tag = services.NormalizeTag(tag); filter.Tag == "" {
+								return nil, userError(errors.New("invalid tag: " + tag))
+							}
+						}
+					}
+
+					page, err := posts.ListPostsPage(requestFrom(p.Context).ctx, filter, after,
+						clampFirst(p.Args, services.MaxPostPageSize))
+					if err != nil {
+						return nil, err
+					}
+					return &connection{page: page, filter: filter}, nil
+				},
+			},
+			"author": &graphql.Field{
+				Type: authorType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, err := idArg(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return &author{ID: id}, nil
+				},
+			},
+		},
+	})
+
+	mutation := mutationType(posts, validators, postType, status)
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// postField defines a post field read from the source post
+func postField(fieldType graphql.Output, value func(p *models.Post) any) *graphql.Field {
+	return &graphql.Field{
+		Type: fieldType,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return value(p.Source.(*models.Post)), nil
+		},
+	}
+}
+
+// firstArg defines the first argument of a list field
+func firstArg(def int) graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: def},
+	}
+}
+
+// clampFirst returns the first argument, limited to 1..limit
+func clampFirst(args map[string]any, limit int) int {
+	first, _ := args["first"].(int)
+	return min(max(first, 1), limit)
+}
+
+// idArg parses the id argument
+func idArg(args map[string]any) (int, error) {
+	raw, _ := args["id"].(string)
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, userError(errors.New("invalid post ID: " + raw))
+	}
+	return id, nil
+}
+
+// postPointers returns up to limit posts other than the post with skipID; a zero limit keeps every post
+func postPointers(posts []models.Post, skipID, limit int) []*models.Post {
+	pointers := make([]*models.Post, 0, len(posts))
+	for i := range posts {
+		if posts[i].ID == skipID {
+			continue
+		}
+		if limit > 0 && len(pointers) == limit {
+			break
+		}
+		pointers = append(pointers, &posts[i])
+	}
+	return pointers
+}
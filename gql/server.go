@@ -0,0 +1,237 @@
+package gql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"gofr-blog-service/cache"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Defaults of Config
+const (
+	DefaultMaxDepth          = 8
+	DefaultMaxComplexity     = 1000
+	DefaultPersistedQueryTTL = 7 * 24 * time.Hour
+)
+
+// persistedQueryKeyPrefix prefixes the cache keys of persisted queries, followed by their SHA-256 hash
+const persistedQueryKeyPrefix = "graphql:apq:"
+
+// Error codes reported in the extensions of GraphQL errors
+const (
+	CodeBadUserInput               = "BAD_USER_INPUT"
+	CodeQueryTooComplex            = "QUERY_TOO_COMPLEX"
+	CodeMutationNotAllowed         = "MUTATION_NOT_ALLOWED"
+	CodePersistedQueryNotFound     = "PERSISTED_QUERY_NOT_FOUND"
+	CodePersistedQueryNotSupported = "PERSISTED_QUERY_NOT_SUPPORTED"
+)
+
+// persistedQueryVersion is the supported version of the persisted query protocol
+const persistedQueryVersion = 1
+
+var (
+	errMissingQuery          = errors.New("missing query")
+	errMutationOverGET       = errors.New("mutations must be sent with POST")
+	errPersistedQueryVersion = errors.New("unsupported persisted query version")
+	errPersistedQueryHash    = errors.New("provided sha does not match query")
+
+	// Clients of automatic persisted queries recognize these messages
+	errPersistedQueryNotFound     = errors.New("PersistedQueryNotFound")
+	errPersistedQueryNotSupported = errors.New("PersistedQueryNotSupported")
+)
+
+// codedError is a GraphQL error carrying a machine readable code in its extensions
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e codedError) Error() string              { return e.err.Error() }
+func (e codedError) Unwrap() error              { return e.err }
+func (e codedError) Extensions() map[string]any { return map[string]any{"code": e.code} }
+
+// userError marks err as caused by the request
+func userError(err error) error {
+	return codedError{code: CodeBadUserInput, err: err}
+}
+
+// errorResult returns a result failed by err before execution
+func errorResult(err error) *graphql.Result {
+	formatted := gqlerrors.FormatError(err)
+	var coded codedError
+	if errors.As(err, &coded) {
+		formatted.Extensions = coded.Extensions()
+	}
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{formatted}}
+}
+
+// Config tunes the GraphQL endpoint
+type Config struct {
+	MaxDepth      int
+	MaxComplexity int
+	// PersistedQueryTTL is how long a registered persisted query is kept
+	PersistedQueryTTL time.Duration
+}
+
+// Request is a GraphQL request as sent by clients over HTTP
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+	Extensions    Extensions     `json:"extensions"`
+}
+
+// Extensions carries the request extensions understood by the server
+type Extensions struct {
+	PersistedQuery *PersistedQuery `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQuery references a query by its SHA-256 hash (automatic persisted queries)
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash"`
+}
+
+// Server executes GraphQL requests against the post service
+type Server struct {
+	schema    graphql.Schema
+	posts     *services.PostService
+	tags      *services.TagService
+	persisted cache.Cache
+	cfg       Config
+}
+
+// NewServer creates a GraphQL server over posts and their tags. Persisted queries are stored in
+// persisted; a nil cache disables them. Zero limits fall back to the defaults.
+func NewServer(posts *services.PostService, tags *services.TagService, validators Validators, persisted cache.Cache,
+	cfg Config) (*Server, error) {
+	s, err := schema(posts, validators)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = DefaultMaxDepth
+	}
+	if cfg.MaxComplexity <= 0 {
+		cfg.MaxComplexity = DefaultMaxComplexity
+	}
+	if cfg.PersistedQueryTTL <= 0 {
+		cfg.PersistedQueryTTL = DefaultPersistedQueryTTL
+	}
+
+	return &Server{schema: s, posts: posts, tags: tags, persisted: persisted, cfg: cfg}, nil
+}
+
+// Execute runs a request. Mutations are refused unless allowMutations is set, which keeps
+// GET requests free of side effects.
+func (s *Server) Execute(ctx *gofr.Context, req Request, allowMutations bool) *graphql.Result {
+	query, err := s.query(ctx, req)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if result := s.check(doc, req, allowMutations); result != nil {
+		return result
+	}
+
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, requestKey{}, newRequest(ctx, s.posts, s.tags)),
+	})
+}
+
+// check enforces the operation type and the depth and complexity limits before execution
+func (s *Server) check(doc *ast.Document, req Request, allowMutations bool) *graphql.Result {
+	op := operation(doc, req.OperationName)
+	if op == nil {
+		// Left to validation and execution, which report unknown or ambiguous operations
+		return nil
+	}
+
+	if op.Operation == ast.OperationTypeMutation && !allowMutations {
+		return errorResult(codedError{code: CodeMutationNotAllowed, err: errMutationOverGET})
+	}
+
+	depth, complexity := measure(doc, op, req.Variables)
+	if depth > s.cfg.MaxDepth {
+		return errorResult(codedError{code: CodeQueryTooComplex, err: errors.New("query depth " +
+			strconv.Itoa(depth) + " exceeds the maximum of " + strconv.Itoa(s.cfg.MaxDepth))})
+	}
+	if complexity > s.cfg.MaxComplexity {
+		return errorResult(codedError{code: CodeQueryTooComplex, err: errors.New("query complexity " +
+			strconv.Itoa(complexity) + " exceeds the maximum of " + strconv.Itoa(s.cfg.MaxComplexity))})
+	}
+	return nil
+}
+
+// query returns the query text of a request. A request carrying only a persisted query hash
+// is looked up; one carrying both the hash and the query registers the query under its hash.
+func (s *Server) query(ctx *gofr.Context, req Request) (string, error) {
+	persisted := req.Extensions.PersistedQuery
+	if persisted == nil {
+		if req.Query == "" {
+			return "", userError(errMissingQuery)
+		}
+		return req.Query, nil
+	}
+
+	if s.persisted == nil {
+		return "", codedError{code: CodePersistedQueryNotSupported, err: errPersistedQueryNotSupported}
+	}
+	if persisted.Version != persistedQueryVersion {
+		return "", userError(errPersistedQueryVersion)
+	}
+
+	hash := strings.ToLower(persisted.SHA256Hash)
+	key := persistedQueryKeyPrefix + hash
+
+	if req.Query == "" {
+		value, err := s.persisted.Get(ctx, key)
+		switch {
+		case errors.Is(err, cache.ErrMiss):
+			return "", codedError{code: CodePersistedQueryNotFound, err: errPersistedQueryNotFound}
+		case err != nil:
+			return "", err
+		}
+		return string(value), nil
+	}
+
+	sum := sha256.Sum256([]byte(req.Query))
+	if hex.EncodeToString(sum[:]) != hash {
+		return "", userError(errPersistedQueryHash)
+	}
+	if err := s.persisted.Set(ctx, key, []byte(req.Query), s.cfg.PersistedQueryTTL); err != nil {
+		ctx.Logger.Errorf("Failed to store persisted query %s: %v", hash, err)
+	}
+	return req.Query, nil
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var errForbidden = errors.New("admin API key required")
+
+// AuditHandler handles HTTP requests for the audit log
+type AuditHandler struct {
+	baseHandler
+	auditService *services.AuditService
+}
+
+// NewAuditHandler creates a new audit handler instance
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListAuditEntries handles GET /audit with filters and pagination
+func (ah *AuditHandler) ListAuditEntries(ctx *gofr.Context) (any, error) {
+	if err := ah.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := ah.extractAuditFilter(ctx)
+	if err != nil {
+		return ah.errorResponse("Invalid filter", err), nil
+	}
+
+	page, pageSize := ah.extractPaginationParams(ctx)
+
+	entries, err := ah.auditService.ListEntries(ctx, filter, page, pageSize)
+	if err != nil {
+		return ah.errorResponse("Failed to retrieve audit log", err), nil
+	}
+
+	return ah.successResponse("Audit log retrieved successfully", entries), nil
+}
+
+// extractAuditFilter reads the action, entity_type, entity_id, actor, request_id, from and to
+// query parameters; times are RFC 3339
+func (ah *AuditHandler) extractAuditFilter(ctx *gofr.Context) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Action:     ctx.Param("action"),
+		EntityType: ctx.Param("entity_type"),
+		Actor:      ctx.Param("actor"),
+		RequestID:  ctx.Param("request_id"),
+	}
+
+	if entityID := ctx.Param("entity_id"); entityID != "" {
+		id, err := strconv.Atoi(entityID)
+		if err != nil || id <= 0 {
+			return filter, errors.Join(errValidation, errors.New("entity_id must be a positive integer"))
+		}
+		filter.EntityID = id
+	}
+
+	for _, bound := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := ctx.Param(bound.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, errors.Join(errValidation, errors.New(bound.name+" must be an RFC 3339 timestamp"))
+		}
+		*bound.target = &t
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/ratelimit"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// AdminKeyHeader carries an API key, or the admin key, as an alternative to an
+// Authorization bearer token
+const AdminKeyHeader = "X-Admin-Key"
+
+var errUnauthenticated = errors.New("invalid API key")
+
+// Authenticate resolves the API key of a request, sent as "Authorization: Bearer <key>" or in
+// X-Admin-Key, to the principal it belongs to before calling next. Requests without a key
+// continue anonymously; requests with an invalid one are refused with 401 and count against
+// failures, and once their client IP used those up its keys are refused with 429 unchecked.
+func Authenticate(userService *services.UserService, failures *ratelimit.FailureLimit, next gofr.Handler) gofr.Handler {
+	return func(ctx *gofr.Context) (any, error) {
+		key := presentedKey(ctx)
+		if key == "" {
+			return next(ctx)
+		}
+		if err := failures.Check(ctx); err != nil {
+			return nil, err
+		}
+
+		principal, err := userService.Authenticate(ctx, key)
+		if errors.Is(err, services.ErrInvalidAPIKey) {
+			failures.Fail(ctx)
+			middleware.SetResponseHeader(ctx, "WWW-Authenticate", `Bearer realm="gofr-blog-service"`)
+			return nil, statusError{status: http.StatusUnauthorized, err: errUnauthenticated}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Context = middleware.WithPrincipal(ctx.Context, principal)
+		return next(ctx)
+	}
+}
+
+// presentedKey returns the bearer token of the request, or else its X-Admin-Key
+func presentedKey(ctx *gofr.Context) string {
+	scheme, token, ok := strings.Cut(middleware.RequestHeader(ctx, "Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return middleware.RequestHeader(ctx, AdminKeyHeader)
+}
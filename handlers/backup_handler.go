@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// BackupHandler handles HTTP requests for backups and restores
+type BackupHandler struct {
+	baseHandler
+	backupService *services.BackupService
+}
+
+// NewBackupHandler creates a new backup handler instance
+func NewBackupHandler(backupService *services.BackupService) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+	}
+}
+
+// Backup handles GET /admin/backup, streaming a backup archive of the posts and the other tables. Once the download
+// has started a failure can only cut it short, so it is logged and the archive fails its checks.
+func (bh *BackupHandler) Backup(ctx *gofr.Context) (any, error) {
+	if err := bh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filename := "backup-" + time.Now().UTC().Format("20060102-150405") + ".zip"
+	w, ok := middleware.StreamResponse(ctx, http.StatusOK, http.Header{
+		"Content-Type":        {"application/zip"},
+		"Content-Disposition": {`attachment; filename="` + filename + `"`},
+		"Cache-Control":       {"no-store"},
+	})
+	if !ok {
+		return bh.errorResponse("Streaming unsupported", errors.New("the backup route is not streamed")), nil
+	}
+
+	if count, err := bh.backupService.Backup(ctx, w); err != nil {
+		ctx.Logger.Errorf("Backup failed after %d posts: %v", count, err)
+	}
+	return nil, nil
+}
+
+// Restore handles POST /admin/restore?strategy=skip|overwrite|rename, whose body is a backup archive
+func (bh *BackupHandler) Restore(ctx *gofr.Context) (any, error) {
+	if err := bh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	data, ok := middleware.Body(ctx)
+	if !ok || len(data) == 0 {
+		return bh.errorResponse("Invalid request format",
+			errors.Join(errInvalidRequest, errors.New("the request body must be a backup archive"))), nil
+	}
+
+	report, err := bh.backupService.Restore(ctx, data, restoreStrategy(ctx.Param("strategy")))
+	if err != nil {
+		return bh.errorResponse("Failed to restore backup", err), nil
+	}
+
+	return bh.successResponse("Backup restored successfully", report), nil
+}
+
+// restoreStrategy returns strategy, defaulting to skip
+func restoreStrategy(strategy string) string {
+	if strategy == "" {
+		return models.RestoreSkip
+	}
+	return strategy
+}
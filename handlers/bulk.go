@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// BulkPosts handles POST /posts/bulk
+func (ph *PostHandler) BulkPosts(ctx *gofr.Context) (any, error) {
+	// Request parsing decorator
+	var req models.BulkRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ph.errorResponse("Invalid request format", errors.Join(errInvalidRequest, err)), nil
+	}
+
+	if req.Mode == "" {
+		req.Mode = models.BulkAtomic
+	}
+	if req.Mode != models.BulkAtomic && req.Mode != models.BulkBestEffort {
+		return ph.errorResponse("Validation failed", errors.Join(errValidation, errors.New("invalid mode: "+req.Mode))), nil
+	}
+	if len(req.Operations) == 0 || len(req.Operations) > services.MaxBulkOperations {
+		return ph.errorResponse("Validation failed", errors.Join(errValidation,
+			errors.New("operations must contain between 1 and "+strconv.Itoa(services.MaxBulkOperations)+" items"))), nil
+	}
+
+	// Validation decorator - invalid operations are reported per item
+	response := models.BulkResponse{Mode: req.Mode}
+	var valid []models.BulkOperation
+	var rejected []models.BulkItemResult
+	for i := range req.Operations {
+		op := req.Operations[i]
+		op.Index = i
+		if err := ph.prepareBulkOperation(&op); err != nil {
+			rejected = append(rejected, models.BulkItemResult{Index: i, Op: op.Op, ID: op.ID, Error: err.Error()})
+			continue
+		}
+		valid = append(valid, op)
+	}
+
+	var results []models.BulkItemResult
+	switch {
+	case len(rejected) > 0 && req.Mode == models.BulkAtomic:
+		results = services.AbortedBulkResults(valid)
+	case len(valid) > 0:
+		var err error
+		results, response.Committed, err = ph.postService.BulkPosts(ctx, req.Mode, valid)
+		if err != nil {
+			return ph.errorResponse("Failed to run bulk operations", err), nil
+		}
+	}
+
+	// Response assembly decorator - results are reported in request order
+	response.Results = make([]models.BulkItemResult, len(req.Operations))
+	for _, result := range append(results, rejected...) {
+		response.Results[result.Index] = result
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+
+	if response.Failed > 0 && !response.Committed {
+		return ph.successResponse("Bulk operations rolled back", response), nil
+	}
+	return ph.successResponse("Bulk operations completed", response), nil
+}
+
+// prepareBulkOperation decodes and validates one bulk operation with the single-post rules
+func (ph *PostHandler) prepareBulkOperation(op *models.BulkOperation) error {
+	if op.Op != models.BulkCreate && op.ID <= 0 {
+		return errors.Join(errInvalidID, errors.New("id is required for "+op.Op))
+	}
+
+	switch op.Op {
+	case models.BulkCreate:
+		var req models.CreatePostRequest
+		if err := decodeBulkPost(op.Post, &req); err != nil {
+			return err
+		}
+		if err := ph.validateCreateRequest(req); err != nil {
+			return err
+		}
+		op.Create = &req
+
+	case models.BulkUpdate:
+		var req models.UpdatePostRequest
+		if err := decodeBulkPost(op.Post, &req); err != nil {
+			return err
+		}
+		if err := ph.validateUpdateRequest(req); err != nil {
+			return err
+		}
+		op.Update = &req
+
+	case models.BulkStatus:
+		if err := ph.validateUpdateRequest(models.UpdatePostRequest{Status: op.Status}); err != nil {
+			return err
+		}
+		if op.Status == "" {
+			return errors.Join(errValidation, errors.New("status is required"))
+		}
+
+	case models.BulkDelete:
+
+	case models.BulkTags:
+		if op.Tags == nil {
+			return errors.Join(errValidation, errors.New("tags is required"))
+		}
+		tags, err := services.NormalizeTags(op.Tags)
+		if err != nil {
+			return errors.Join(errValidation, err)
+		}
+		op.Tags = tags
+
+	default:
+		return errors.Join(errValidation, errors.New("unknown op: "+op.Op))
+	}
+
+	return nil
+}
+
+// decodeBulkPost decodes the post body of a create or update operation
+func decodeBulkPost(raw json.RawMessage, target any) error {
+	if len(raw) == 0 {
+		return errors.Join(errValidation, errors.New("post is required"))
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return errors.Join(errInvalidRequest, err)
+	}
+	return nil
+}
@@ -0,0 +1,546 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gofr-blog-service/gql"
+	"gofr-blog-service/markdown"
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Output formats of the admin CLI, chosen with -output
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// defaultCLIListLimit is the number of posts listed without -limit
+const defaultCLIListLimit = 20
+
+var (
+	errInvalidOutput = errors.New("invalid output format, use table or json")
+	errMissingFlag   = errors.New("missing required flag")
+	errNotConfirmed  = errors.New("refusing to delete without -yes")
+)
+
+// AdminCLI implements the blogctl subcommands over the post service. Flags are read as
+// parameters (-id=3), and results are rendered as a table or, with -output=json, as JSON.
+type AdminCLI struct {
+	baseHandler
+	postHandler   *PostHandler
+	postService   *services.PostService
+	importService *services.ImportService
+	exportService *services.ExportService
+	backupService *services.BackupService
+	userService   *services.UserService
+}
+
+// NewAdminCLI creates the admin commands, validating posts with the post handler's rules
+func NewAdminCLI(ph *PostHandler, importService *services.ImportService, exportService *services.ExportService,
+	backupService *services.BackupService, userService *services.UserService) *AdminCLI {
+	return &AdminCLI{
+		postHandler:   ph,
+		postService:   ph.postService,
+		importService: importService,
+		exportService: exportService,
+		backupService: backupService,
+		userService:   userService,
+	}
+}
+
+// ListPosts handles "posts list [-status=] [-author_id=] [-limit=20] [-after=cursor]"
+func (ac *AdminCLI) ListPosts(ctx *gofr.Context) (any, error) {
+	filter := models.PostFilter{Status: ctx.Param("status")}
+	if authorID := ctx.Param("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil || id <= 0 {
+			return nil, errors.Join(errValidation, errors.New("author_id must be a positive integer"))
+		}
+		filter.AuthorID = id
+	}
+
+	limit := defaultCLIListLimit
+	if value := ctx.Param("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return nil, errors.Join(errValidation, errors.New("limit must be a positive integer"))
+		}
+	}
+
+	var after *models.PostCursor
+	if cursor := ctx.Param("after"); cursor != "" {
+		var err error
+		if after, err = gql.DecodeCursor(cursor); err != nil {
+			return nil, errors.Join(errValidation, err)
+		}
+	}
+
+	page, err := ac.postService.ListPostsPage(ctx, filter, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var next string
+	if page.HasNextPage {
+		next = gql.EncodeCursor(&page.Posts[len(page.Posts)-1])
+	}
+
+	rows := make([][]string, 0, len(page.Posts))
+	for i := range page.Posts {
+		rows = append(rows, postRow(&page.Posts[i]))
+	}
+	output, err := ac.render(ctx, map[string]any{"posts": page.Posts, "next_cursor": next}, postColumns, rows)
+	if err != nil || next == "" || ctx.Param("output") == OutputJSON {
+		return output, err
+	}
+	return output + "\nMore posts: -after=" + next, nil
+}
+
+// GetPost handles "posts get -id=" or "posts get -slug="
+func (ac *AdminCLI) GetPost(ctx *gofr.Context) (any, error) {
+	var (
+		post *models.Post
+		err  error
+	)
+	switch {
+	case ctx.Param("slug") != "":
+		post, err = ac.postService.GetPostBySlug(ctx, ctx.Param("slug"))
+	default:
+		var id int
+		if id, err = ac.idFlag(ctx); err != nil {
+			return nil, err
+		}
+		post, err = ac.postService.GetPost(ctx, id, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ac.render(ctx, post, []string{"FIELD", "VALUE"}, [][]string{
+		{"id", strconv.Itoa(post.ID)},
+		{"title", post.Title},
+		{"slug", post.Slug},
+		{"status", post.Status},
+		{"author_id", strconv.Itoa(post.AuthorID)},
+		{"created_at", post.CreatedAt.Format(time.RFC3339)},
+		{"updated_at", post.UpdatedAt.Format(time.RFC3339)},
+		{"published_at", formatOptionalTime(post.PublishedAt)},
+		{"content", services.Excerpt(post.Content, 80)},
+	})
+}
+
+// CreatePost handles "posts create -file=post.md [-author_id=] [-status=]". The Markdown file's
+// front matter sets title, slug, author_id, status and the SEO fields; the slug defaults to one
+// derived from the title, and flags override the front matter.
+func (ac *AdminCLI) CreatePost(ctx *gofr.Context) (any, error) {
+	ctx = ac.actorContext(ctx)
+
+	path := ctx.Param("file")
+	if path == "" {
+		return nil, errors.Join(errMissingFlag, errors.New("-file"))
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	fields, body := markdown.SplitFrontMatter(string(source))
+	for _, flag := range []string{"author_id", "status"} {
+		if value := ctx.Param(flag); value != "" {
+			fields[flag] = value
+		}
+	}
+
+	req := models.CreatePostRequest{
+		Title:           fields["title"],
+		Content:         strings.TrimSpace(body),
+		Slug:            fields["slug"],
+		Status:          fields["status"],
+		MetaTitle:       fields["meta_title"],
+		MetaDescription: fields["meta_description"],
+		CanonicalURL:    fields["canonical_url"],
+		OGImage:         fields["og_image"],
+	}
+	if req.Slug == "" {
+		req.Slug = services.Slugify(req.Title)
+	}
+	if req.Status == "" {
+		req.Status = "draft"
+	}
+	if fields["author_id"] != "" {
+		if req.AuthorID, err = strconv.Atoi(fields["author_id"]); err != nil {
+			return nil, errors.Join(errValidation, errors.New("author_id must be an integer"))
+		}
+	}
+	if fields["noindex"] != "" {
+		if req.NoIndex, err = strconv.ParseBool(fields["noindex"]); err != nil {
+			return nil, errors.Join(errValidation, errors.New("noindex must be true or false"))
+		}
+	}
+
+	if err = ac.postHandler.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	post, err := ac.postService.CreatePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, post, postColumns, [][]string{postRow(post)})
+}
+
+// PublishPost handles "posts publish -id="
+func (ac *AdminCLI) PublishPost(ctx *gofr.Context) (any, error) {
+	return ac.setStatus(ctx, "published")
+}
+
+// ArchivePost handles "posts archive -id="
+func (ac *AdminCLI) ArchivePost(ctx *gofr.Context) (any, error) {
+	return ac.setStatus(ctx, "archived")
+}
+
+// DeletePost handles "posts delete -id= -yes"
+func (ac *AdminCLI) DeletePost(ctx *gofr.Context) (any, error) {
+	ctx = ac.actorContext(ctx)
+
+	id, err := ac.idFlag(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if confirmed, _ := strconv.ParseBool(ctx.Param("yes")); !confirmed {
+		return nil, errNotConfirmed
+	}
+
+	if err = ac.postService.DeletePost(ctx, id); err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, map[string]any{"deleted_id": id}, []string{"DELETED_ID"}, [][]string{{strconv.Itoa(id)}})
+}
+
+// RegenerateSlugs handles "regenerate slugs [-id=1,2] [-dry-run]", deriving slugs from titles
+func (ac *AdminCLI) RegenerateSlugs(ctx *gofr.Context) (any, error) {
+	ctx = ac.actorContext(ctx)
+
+	var ids []int
+	if list := ctx.Param("id"); list != "" {
+		for _, value := range strings.Split(list, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || id <= 0 {
+				return nil, errors.Join(errInvalidID, errors.New("invalid post ID: "+value))
+			}
+			ids = append(ids, id)
+		}
+	}
+	dryRun, _ := strconv.ParseBool(ctx.Param("dry-run"))
+
+	changes, err := ac.postService.RegenerateSlugs(ctx, ids, dryRun)
+
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []string{strconv.Itoa(change.ID), change.From, change.To})
+	}
+	output, renderErr := ac.render(ctx, map[string]any{"dry_run": dryRun, "changes": changes},
+		[]string{"ID", "FROM", "TO"}, rows)
+	if err != nil {
+		// The slugs changed before the failure are reported along with it
+		return output, err
+	}
+	return output, renderErr
+}
+
+// RefreshCache handles "regenerate cache", dropping every cached post and list page
+func (ac *AdminCLI) RefreshCache(ctx *gofr.Context) (any, error) {
+	count, err := ac.postService.RefreshCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, map[string]any{"posts": count}, []string{"POSTS_DROPPED"}, [][]string{{strconv.Itoa(count)}})
+}
+
+// ImportWordPress handles "import wordpress -file=export.xml [-dry-run] [-default_author_id=]
+// [-author_map=login:id,...]", running the import job batch by batch until it finishes. An
+// interrupted import resumes when the same export is imported again.
+func (ac *AdminCLI) ImportWordPress(ctx *gofr.Context) (any, error) {
+	ctx = ac.actorContext(ctx)
+
+	path := ctx.Param("file")
+	if path == "" {
+		return nil, errors.Join(errMissingFlag, errors.New("-file"))
+	}
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	options, err := parseImportOptions(ctx.Param("default_author_id"), ctx.Param("author_map"))
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun, _ := strconv.ParseBool(ctx.Param("dry-run")); dryRun {
+		return ac.importReport(ctx, payload, options)
+	}
+
+	job, err := ac.importService.StartWordPress(ctx, payload, options)
+	for err == nil && (job.Status == models.ImportPending || job.Status == models.ImportRunning) {
+		job, err = ac.importService.RunBatch(ctx, job.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ac.render(ctx, job, []string{"JOB", "STATUS", "TOTAL", "IMPORTED", "SKIPPED", "FAILED", "LAST_ERROR"},
+		[][]string{{
+			strconv.Itoa(job.ID),
+			job.Status,
+			strconv.Itoa(job.Total),
+			strconv.Itoa(job.Imported),
+			strconv.Itoa(job.Skipped),
+			strconv.Itoa(job.Failed),
+			job.LastError,
+		}})
+}
+
+// importReport renders the dry-run report of an export: its counts, then a table of conflicts
+func (ac *AdminCLI) importReport(ctx *gofr.Context, payload []byte, options models.ImportOptions) (any, error) {
+	report, err := ac.importService.DryRunWordPress(ctx, payload, options)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{
+		{"posts", strconv.Itoa(report.Posts)},
+		{"authors", strconv.Itoa(report.Authors)},
+		{"categories", strconv.Itoa(report.Categories)},
+		{"tags", strconv.Itoa(report.Tags)},
+		{"new tags", strconv.Itoa(len(report.NewTags))},
+		{"comments", strconv.Itoa(report.Comments)},
+		{"conflicts", strconv.Itoa(len(report.Conflicts))},
+	}
+	for _, reason := range []string{services.ImportSkipPostType, services.ImportSkipStatus, services.ImportSkipMissingID} {
+		if count := report.Skipped[reason]; count > 0 {
+			rows = append(rows, []string{"skipped (" + reason + ")", strconv.Itoa(count)})
+		}
+	}
+
+	summary, err := ac.render(ctx, report, []string{"ITEMS", "COUNT"}, rows)
+	if err != nil || len(report.Conflicts) == 0 || ctx.Param("output") == OutputJSON {
+		return summary, err
+	}
+
+	conflicts := make([][]string, 0, len(report.Conflicts))
+	for _, conflict := range report.Conflicts {
+		holder := "-"
+		if conflict.PostID > 0 {
+			holder = strconv.Itoa(conflict.PostID)
+		}
+		conflicts = append(conflicts, []string{strconv.Itoa(conflict.SourceID), conflict.Slug, conflict.Reason, holder,
+			conflict.Title})
+	}
+	table, err := ac.render(ctx, nil, []string{"SOURCE_ID", "SLUG", "REASON", "POST_ID", "TITLE"}, conflicts)
+	return summary + "\n\n" + table, err
+}
+
+// ExportSite handles "export site [-format=hugo|jekyll] [-file=] [-status=] [-from=] [-to=]",
+// writing the posts as a zip of Markdown files to -file, by default <format>-export.zip
+func (ac *AdminCLI) ExportSite(ctx *gofr.Context) (any, error) {
+	options, err := extractExportOptions(ctx.Param)
+	if err != nil {
+		return nil, err
+	}
+
+	path := ctx.Param("file")
+	if path == "" {
+		path = options.Format + "-export.zip"
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	count, err := ac.exportService.ExportSite(ctx, file, options)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return ac.render(ctx, map[string]any{"file": path, "posts": count}, []string{"FILE", "POSTS"},
+		[][]string{{path, strconv.Itoa(count)}})
+}
+
+// CreateBackup handles "backup create [-file=]", writing a backup archive of the posts and
+// the other tables to -file, by default backup-<time>.zip
+func (ac *AdminCLI) CreateBackup(ctx *gofr.Context) (any, error) {
+	path := ctx.Param("file")
+	if path == "" {
+		path = "backup-" + time.Now().UTC().Format("20060102-150405") + ".zip"
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	count, err := ac.backupService.Backup(ctx, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return ac.render(ctx, map[string]any{"file": path, "posts": count}, []string{"FILE", "POSTS"},
+		[][]string{{path, strconv.Itoa(count)}})
+}
+
+// RestoreBackup handles "backup restore -file= [-strategy=skip|overwrite|rename]"
+func (ac *AdminCLI) RestoreBackup(ctx *gofr.Context) (any, error) {
+	ctx = ac.actorContext(ctx)
+
+	path := ctx.Param("file")
+	if path == "" {
+		return nil, errors.Join(errMissingFlag, errors.New("-file"))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidRequest, err)
+	}
+
+	report, err := ac.backupService.Restore(ctx, data, restoreStrategy(ctx.Param("strategy")))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{
+		{"posts created", strconv.Itoa(report.Created)},
+		{"posts overwritten", strconv.Itoa(report.Overwritten)},
+		{"posts skipped", strconv.Itoa(report.Skipped)},
+		{"posts remapped", strconv.Itoa(len(report.Remapped))},
+		{"posts renamed", strconv.Itoa(len(report.Renamed))},
+	}
+
+	entities := make([]string, 0, len(report.Records))
+	for entity := range report.Records {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	for _, entity := range entities {
+		rows = append(rows,
+			[]string{entity + " restored", strconv.Itoa(report.Records[entity].Restored)},
+			[]string{entity + " skipped", strconv.Itoa(report.Records[entity].Skipped)})
+	}
+	return ac.render(ctx, report, []string{"RECORDS", "COUNT"}, rows)
+}
+
+// setStatus moves the post of -id to status
+func (ac *AdminCLI) setStatus(ctx *gofr.Context, status string) (any, error) {
+	ctx = ac.actorContext(ctx)
+
+	id, err := ac.idFlag(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := ac.postService.UpdatePost(ctx, id, models.UpdatePostRequest{Status: status})
+	if err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, post, postColumns, [][]string{postRow(post)})
+}
+
+// idFlag reads and validates the -id flag
+func (ac *AdminCLI) idFlag(ctx *gofr.Context) (int, error) {
+	return ac.requiredIDFlag(ctx, "id", "post")
+}
+
+// requiredIDFlag reads and validates a flag holding the ID of an entity
+func (ac *AdminCLI) requiredIDFlag(ctx *gofr.Context, flag, entity string) (int, error) {
+	value := ctx.Param(flag)
+	if value == "" {
+		return 0, errors.Join(errMissingFlag, errors.New("-"+flag))
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, errors.Join(errInvalidID, errors.New("invalid "+entity+" ID: "+value))
+	}
+	return id, nil
+}
+
+// actorContext attributes the audit entries of a mutation to -actor, or to blogctl and the
+// operating system user. Whoever runs blogctl has database access, so the actor is trusted as
+// an admin principal.
+func (ac *AdminCLI) actorContext(ctx *gofr.Context) *gofr.Context {
+	actor := ctx.Param("actor")
+	if actor == "" {
+		actor = "blogctl"
+		if user := os.Getenv("USER"); user != "" {
+			actor += ":" + user
+		}
+	}
+
+	ctx.Context = middleware.WithPrincipal(ctx.Context, &models.Principal{ID: actor, Name: actor, Role: models.RoleAdmin})
+	return ctx
+}
+
+// render formats value as indented JSON, or rows under columns as an aligned table
+func (ac *AdminCLI) render(ctx *gofr.Context, value any, columns []string, rows [][]string) (string, error) {
+	switch ctx.Param("output") {
+	case OutputJSON:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "", OutputTable:
+		var out bytes.Buffer
+		table := tabwriter.NewWriter(&out, 0, 4, 2, ' ', 0)
+		_, _ = table.Write([]byte(strings.Join(columns, "\t") + "\n"))
+		for _, row := range rows {
+			_, _ = table.Write([]byte(strings.Join(row, "\t") + "\n"))
+		}
+		if err := table.Flush(); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(out.String(), "\n"), nil
+	default:
+		return "", errInvalidOutput
+	}
+}
+
+// postColumns are the table columns of postRow
+var postColumns = []string{"ID", "SLUG", "STATUS", "AUTHOR", "UPDATED", "TITLE"}
+
+// postRow returns the table row of a post
+func postRow(post *models.Post) []string {
+	return []string{
+		strconv.Itoa(post.ID),
+		post.Slug,
+		post.Status,
+		strconv.Itoa(post.AuthorID),
+		post.UpdatedAt.Format(time.RFC3339),
+		post.Title,
+	}
+}
+
+// formatOptionalTime formats t, or "-" when it is unset
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var errNotConfirmedRevoke = errors.New("refusing to revoke without -yes")
+
+// userColumns are the table columns of userRow
+var userColumns = []string{"ID", "NAME", "EMAIL", "ROLE", "DISABLED", "CREATED"}
+
+// apiKeyColumns are the table columns of apiKeyRow
+var apiKeyColumns = []string{"ID", "USER", "NAME", "PREFIX", "CREATED", "LAST_USED", "REVOKED"}
+
+// CreateUser handles "users create -name= -email= [-role=editor]"
+func (ac *AdminCLI) CreateUser(ctx *gofr.Context) (any, error) {
+	req := models.CreateUserRequest{
+		Name:  ctx.Param("name"),
+		Email: ctx.Param("email"),
+		Role:  ctx.Param("role"),
+	}
+	if req.Role == "" {
+		req.Role = models.RoleEditor
+	}
+
+	user, err := ac.userService.CreateUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, user, userColumns, [][]string{userRow(user)})
+}
+
+// ListUsers handles "users list"
+func (ac *AdminCLI) ListUsers(ctx *gofr.Context) (any, error) {
+	users, err := ac.userService.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(users))
+	for i := range users {
+		rows = append(rows, userRow(&users[i]))
+	}
+	return ac.render(ctx, users, userColumns, rows)
+}
+
+// DisableUser handles "users disable -id=", after which the user's keys stop authenticating
+func (ac *AdminCLI) DisableUser(ctx *gofr.Context) (any, error) {
+	return ac.setUserDisabled(ctx, true)
+}
+
+// EnableUser handles "users enable -id="
+func (ac *AdminCLI) EnableUser(ctx *gofr.Context) (any, error) {
+	return ac.setUserDisabled(ctx, false)
+}
+
+// CreateAPIKey handles "keys create -user_id= -name=", printing the key once
+func (ac *AdminCLI) CreateAPIKey(ctx *gofr.Context) (any, error) {
+	userID, err := ac.requiredIDFlag(ctx, "user_id", "user")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ac.userService.CreateAPIKey(ctx, userID, ctx.Param("name"))
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := ac.render(ctx, key, []string{"ID", "USER", "NAME", "KEY"},
+		[][]string{{strconv.Itoa(key.ID), strconv.Itoa(key.UserID), key.Name, key.Key}})
+	if err != nil || ctx.Param("output") == OutputJSON {
+		return output, err
+	}
+	return output + "\nStore the key now; it cannot be shown again.", nil
+}
+
+// ListAPIKeys handles "keys list [-user_id=]"
+func (ac *AdminCLI) ListAPIKeys(ctx *gofr.Context) (any, error) {
+	var userID int
+	if ctx.Param("user_id") != "" {
+		var err error
+		if userID, err = ac.requiredIDFlag(ctx, "user_id", "user"); err != nil {
+			return nil, err
+		}
+	}
+
+	keys, err := ac.userService.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(keys))
+	for i := range keys {
+		rows = append(rows, apiKeyRow(&keys[i]))
+	}
+	return ac.render(ctx, keys, apiKeyColumns, rows)
+}
+
+// RevokeAPIKey handles "keys revoke -id= -yes"
+func (ac *AdminCLI) RevokeAPIKey(ctx *gofr.Context) (any, error) {
+	id, err := ac.requiredIDFlag(ctx, "id", "API key")
+	if err != nil {
+		return nil, err
+	}
+	if confirmed, _ := strconv.ParseBool(ctx.Param("yes")); !confirmed {
+		return nil, errNotConfirmedRevoke
+	}
+
+	key, err := ac.userService.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, key, apiKeyColumns, [][]string{apiKeyRow(key)})
+}
+
+// setUserDisabled disables or re-enables the user of -id
+func (ac *AdminCLI) setUserDisabled(ctx *gofr.Context, disabled bool) (any, error) {
+	id, err := ac.requiredIDFlag(ctx, "id", "user")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := ac.userService.SetUserDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, err
+	}
+	return ac.render(ctx, user, userColumns, [][]string{userRow(user)})
+}
+
+// userRow returns the table row of a user
+func userRow(user *models.User) []string {
+	return []string{
+		strconv.Itoa(user.ID),
+		user.Name,
+		user.Email,
+		user.Role,
+		strconv.FormatBool(user.Disabled),
+		user.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// apiKeyRow returns the table row of an API key
+func apiKeyRow(key *models.APIKey) []string {
+	return []string{
+		strconv.Itoa(key.ID),
+		strconv.Itoa(key.UserID),
+		key.Name,
+		key.Prefix + "...",
+		key.CreatedAt.Format(time.RFC3339),
+		formatOptionalTime(key.LastUsedAt),
+		formatOptionalTime(key.RevokedAt),
+	}
+}
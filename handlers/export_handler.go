@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+	"gofr-blog-service/staticsite"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ExportHandler handles HTTP requests for static site exports
+type ExportHandler struct {
+	baseHandler
+	exportService *services.ExportService
+}
+
+// NewExportHandler creates a new export handler instance
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportSite handles GET /export?format=hugo|jekyll, streaming a zip of the posts as Markdown
+// files. Once the download has started a failure can only cut it short, so it is logged and
+// the client is left with an incomplete archive.
+func (eh *ExportHandler) ExportSite(ctx *gofr.Context) (any, error) {
+	if err := eh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	options, err := extractExportOptions(ctx.Param)
+	if err != nil {
+		return eh.errorResponse("Validation failed", err), nil
+	}
+
+	filename := options.Format + "-export-" + time.Now().UTC().Format("20060102") + ".zip"
+	w, ok := middleware.StreamResponse(ctx, http.StatusOK, http.Header{
+		"Content-Type":        {"application/zip"},
+		"Content-Disposition": {`attachment; filename="` + filename + `"`},
+		"Cache-Control":       {"no-store"},
+	})
+	if !ok {
+		return eh.errorResponse("Streaming unsupported", errors.New("the export route is not streamed")), nil
+	}
+
+	if count, err := eh.exportService.ExportSite(ctx, w, options); err != nil {
+		ctx.Logger.Errorf("Site export failed after %d posts: %v", count, err)
+	}
+	return nil, nil
+}
+
+// extractExportOptions reads the format (default hugo), status, from and to parameters;
+// times are RFC 3339
+func extractExportOptions(param func(string) string) (models.ExportOptions, error) {
+	options := models.ExportOptions{Format: param("format"), Status: param("status")}
+	if options.Format == "" {
+		options.Format = staticsite.FormatHugo
+	}
+	if !staticsite.ValidFormat(options.Format) {
+		return options, errors.Join(errValidation, staticsite.ErrUnknownFormat)
+	}
+
+	switch options.Status {
+	case "", "draft", "published", "archived":
+	default:
+		return options, errors.Join(errValidation, errors.New("invalid status: "+options.Status))
+	}
+
+	for _, bound := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &options.From}, {"to", &options.To}} {
+		value := param(bound.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return options, errors.Join(errValidation, errors.New(bound.name+" must be an RFC 3339 timestamp"))
+		}
+		*bound.target = &t
+	}
+
+	return options, nil
+}
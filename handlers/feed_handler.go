@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+var (
+	errInvalidAuthorID = errors.New("invalid author ID")
+	errInvalidTag      = errors.New("invalid tag")
+)
+
+// errNotModified short-circuits a conditional GET with 304 Not Modified
+var errNotModified = notModifiedError{}
+
+// notModifiedError carries the 304 status code to GoFr's responder
+type notModifiedError struct{}
+
+func (notModifiedError) Error() string   { return "not modified" }
+func (notModifiedError) StatusCode() int { return http.StatusNotModified }
+
+// feedRenderer renders a feed document for a filter at a known version
+type feedRenderer func(ctx *gofr.Context, filter models.FeedFilter, version *models.FeedVersion) ([]byte, error)
+
+// FeedHandler handles HTTP requests for syndication feeds
+type FeedHandler struct {
+	baseHandler
+	feedService *services.FeedService
+}
+
+// NewFeedHandler creates a new feed handler instance
+func NewFeedHandler(feedService *services.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// RSS handles GET /feed.rss
+func (fh *FeedHandler) RSS(ctx *gofr.Context) (any, error) {
+	return fh.serveFeed(ctx, "application/rss+xml; charset=utf-8", fh.feedService.RSS)
+}
+
+// Atom handles GET /feed.atom
+func (fh *FeedHandler) Atom(ctx *gofr.Context) (any, error) {
+	return fh.serveFeed(ctx, "application/atom+xml; charset=utf-8", fh.feedService.Atom)
+}
+
+// JSONFeed handles GET /feed.json with page-based pagination
+func (fh *FeedHandler) JSONFeed(ctx *gofr.Context) (any, error) {
+	return fh.serveFeed(ctx, "application/feed+json; charset=utf-8", fh.feedService.JSONFeed)
+}
+
+// serveFeed answers conditional requests from the feed version and renders the feed otherwise
+func (fh *FeedHandler) serveFeed(ctx *gofr.Context, contentType string, render feedRenderer) (any, error) {
+	// Filter extraction decorator
+	filter, err := fh.extractFeedFilter(ctx)
+	if err != nil {
+		return fh.errorResponse("Invalid feed filter", err), nil
+	}
+
+	// Conditional GET decorator
+	version, err := fh.feedService.GetFeedVersion(ctx, filter)
+	if err != nil {
+		return fh.errorResponse("Failed to build feed", err), nil
+	}
+
+	if fh.notModified(ctx, version, "public, max-age=300") {
+		return nil, errNotModified
+	}
+
+	// Rendering decorator
+	feed, err := render(ctx, filter, version)
+	if err != nil {
+		return fh.errorResponse("Failed to build feed", err), nil
+	}
+
+	return response.File{Content: feed, ContentType: contentType}, nil
+}
+
+// notModified sets the validators for a version of a resource and reports whether the client copy is current.
+// If-Modified-Since is ignored when If-None-Match is present, as RFC 9110 requires.
+func (bh baseHandler) notModified(ctx *gofr.Context, version *models.FeedVersion, cacheControl string) bool {
+	etag := versionETag(version)
+	lastModified := version.LastModified.UTC().Truncate(time.Second)
+
+	middleware.SetResponseHeader(ctx, "ETag", etag)
+	middleware.SetResponseHeader(ctx, "Last-Modified", lastModified.Format(http.TimeFormat))
+	middleware.SetResponseHeader(ctx, "Cache-Control", cacheControl)
+
+	if ifNoneMatch := middleware.RequestHeader(ctx, "If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	if since, err := http.ParseTime(middleware.RequestHeader(ctx, "If-Modified-Since")); err == nil {
+		return !lastModified.After(since)
+	}
+
+	return false
+}
+
+// extractFeedFilter extracts the author, tag and pagination filters of a feed
+func (fh *FeedHandler) extractFeedFilter(ctx *gofr.Context) (models.FeedFilter, error) {
+	filter := models.FeedFilter{Limit: services.DefaultFeedSize}
+
+	if pageStr := ctx.Param("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 1 {
+			filter.Offset = (page - 1) * filter.Limit
+		}
+	}
+
+	if authorStr := ctx.Param("author_id"); authorStr != "" {
+		authorID, err := strconv.Atoi(authorStr)
+		if err != nil || authorID <= 0 {
+			return filter, errors.Join(errInvalidAuthorID, errors.New("invalid author ID format: "+authorStr))
+		}
+		filter.AuthorID = authorID
+	}
+
+	if tag := ctx.Param("tag"); tag != "" {
+		filter.Tag = services.NormalizeTag(tag)
+		if filter.Tag == "" {
+			return filter, errors.Join(errInvalidTag, errors.New("invalid tag format: "+tag))
+		}
+	}
+
+	return filter, nil
+}
+
+// versionETag returns a weak entity tag for a version of a resource
+func versionETag(version *models.FeedVersion) string {
+	tag := strconv.FormatInt(version.LastModified.UnixNano(), 36) + "-" + strconv.Itoa(version.Count)
+	if version.Variant != "" {
+		tag += "-" + version.Variant
+	}
+	return `W/"` + tag + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches etag using weak comparison
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gofr-blog-service/cache"
+	"gofr-blog-service/gql"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+// GraphQLHandler serves the GraphQL endpoint over the post service
+type GraphQLHandler struct {
+	baseHandler
+	server *gql.Server
+}
+
+// NewGraphQLHandler creates a GraphQL handler over the posts and tags of the post handler, whose
+// mutations reuse its validation. Persisted queries are stored in persisted; a nil cache disables them.
+func NewGraphQLHandler(ph *PostHandler, persisted cache.Cache, cfg gql.Config) (*GraphQLHandler, error) {
+	server, err := gql.NewServer(ph.postService, ph.tags, gql.Validators{
+		Create: ph.validateCreateRequest,
+		Update: ph.validateUpdateRequest,
+	}, persisted, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphQLHandler{server: server}, nil
+}
+
+// Query handles GET /graphql, which runs queries and persisted queries but no mutations.
+// Variables and extensions are passed as JSON encoded query parameters.
+func (gh *GraphQLHandler) Query(ctx *gofr.Context) (any, error) {
+	req := gql.Request{
+		Query:         ctx.Param("query"),
+		OperationName: ctx.Param("operationName"),
+	}
+
+	for name, target := range map[string]any{
+		"variables":  &req.Variables,
+		"extensions": &req.Extensions,
+	} {
+		if value := ctx.Param(name); value != "" {
+			if err := json.Unmarshal([]byte(value), target); err != nil {
+				return gh.errorResponse("Invalid request format",
+					errors.Join(errInvalidRequest, errors.New("invalid "+name+" parameter"))), nil
+			}
+		}
+	}
+
+	return response.Raw{Data: gh.server.Execute(ctx, req, false)}, nil
+}
+
+// Execute handles POST /graphql with a JSON GraphQL request
+func (gh *GraphQLHandler) Execute(ctx *gofr.Context) (any, error) {
+	var req gql.Request
+	if err := ctx.Bind(&req); err != nil {
+		return gh.errorResponse("Invalid request format", errors.Join(errInvalidRequest, err)), nil
+	}
+
+	return response.Raw{Data: gh.server.Execute(ctx, req, true)}, nil
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/proto/blogpb"
+	"gofr-blog-service/services"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+)
+
+var (
+	errWatchDisabled = errors.New("post watch is not enabled")
+	errWatchBehind   = errors.New("watcher fell behind, resubscribe")
+)
+
+// BlogServer serves the gRPC BlogService over the post service, validating requests with the
+// post handler's rules
+type BlogServer struct {
+	blogpb.UnimplementedBlogServiceServer
+
+	// Container is injected by GoFr when the service is registered
+	*container.Container
+
+	postHandler *PostHandler
+	watch       *services.PostWatch
+}
+
+// NewBlogServer creates the gRPC server of posts. A nil watch makes WatchPosts unavailable.
+func NewBlogServer(ph *PostHandler, watch *services.PostWatch) *BlogServer {
+	return &BlogServer{
+		postHandler: ph,
+		watch:       watch,
+	}
+}
+
+// CreatePost handles BlogService.CreatePost; the status defaults to draft
+func (bs *BlogServer) CreatePost(ctx context.Context, req *blogpb.CreatePostRequest) (*blogpb.Post, error) {
+	create := models.CreatePostRequest{
+		Title:           req.GetTitle(),
+		Content:         req.GetContent(),
+		Slug:            req.GetSlug(),
+		AuthorID:        int(req.GetAuthorId()),
+		Status:          req.GetStatus(),
+		MetaTitle:       req.GetMetaTitle(),
+		MetaDescription: req.GetMetaDescription(),
+		CanonicalURL:    req.GetCanonicalUrl(),
+		OGImage:         req.GetOgImage(),
+		NoIndex:         req.GetNoindex(),
+	}
+	if create.Status == "" {
+		create.Status = "draft"
+	}
+	if err := bs.postHandler.validateCreateRequest(create); err != nil {
+		return nil, grpcError(err)
+	}
+
+	post, err := bs.postHandler.postService.CreatePost(bs.context(ctx, req), create)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return postProto(post), nil
+}
+
+// GetPost handles BlogService.GetPost
+func (bs *BlogServer) GetPost(ctx context.Context, req *blogpb.GetPostRequest) (*blogpb.Post, error) {
+	gctx := bs.context(ctx, req)
+
+	var (
+		post *models.Post
+		err  error
+	)
+	switch lookup := req.GetLookup().(type) {
+	case *blogpb.GetPostRequest_Slug:
+		post, err = bs.postHandler.postService.GetPostBySlug(gctx, lookup.Slug)
+	case *blogpb.GetPostRequest_Id:
+		if lookup.Id <= 0 {
+			return nil, grpcError(errInvalidID)
+		}
+		post, err = bs.postHandler.postService.GetPost(gctx, int(lookup.Id), nil)
+	default:
+		return nil, grpcError(errors.Join(errInvalidRequest, errors.New("id or slug is required")))
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return postProto(post), nil
+}
+
+// ListPosts handles BlogService.ListPosts
+func (bs *BlogServer) ListPosts(ctx context.Context, req *blogpb.ListPostsRequest) (*blogpb.ListPostsResponse, error) {
+	list, err := bs.postHandler.postService.ListPosts(bs.context(ctx, req), int(req.GetPage()), int(req.GetPageSize()), nil)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &blogpb.ListPostsResponse{
+		Posts:      make([]*blogpb.Post, 0, len(list.Posts)),
+		TotalCount: int32(list.TotalCount),
+		Page:       int32(list.Page),
+		PageSize:   int32(list.PageSize),
+		TotalPages: int32(list.TotalPages),
+	}
+	for i := range list.Posts {
+		resp.Posts = append(resp.Posts, postProto(&list.Posts[i]))
+	}
+	return resp, nil
+}
+
+// UpdatePost handles BlogService.UpdatePost
+func (bs *BlogServer) UpdatePost(ctx context.Context, req *blogpb.UpdatePostRequest) (*blogpb.Post, error) {
+	if req.GetId() <= 0 {
+		return nil, grpcError(errInvalidID)
+	}
+
+	update := models.UpdatePostRequest{
+		Title:           req.GetTitle(),
+		Content:         req.GetContent(),
+		Slug:            req.GetSlug(),
+		Status:          req.GetStatus(),
+		MetaTitle:       req.GetMetaTitle(),
+		MetaDescription: req.GetMetaDescription(),
+		CanonicalURL:    req.GetCanonicalUrl(),
+		OGImage:         req.GetOgImage(),
+		NoIndex:         req.Noindex,
+	}
+	if err := bs.postHandler.validateUpdateRequest(update); err != nil {
+		return nil, grpcError(err)
+	}
+
+	post, err := bs.postHandler.postService.UpdatePost(bs.context(ctx, req), int(req.GetId()), update)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return postProto(post), nil
+}
+
+// DeletePost handles BlogService.DeletePost
+func (bs *BlogServer) DeletePost(ctx context.Context, req *blogpb.DeletePostRequest) (*blogpb.DeletePostResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, grpcError(errInvalidID)
+	}
+
+	if err := bs.postHandler.postService.DeletePost(bs.context(ctx, req), int(req.GetId())); err != nil {
+		return nil, grpcError(err)
+	}
+	return &blogpb.DeletePostResponse{DeletedId: req.GetId()}, nil
+}
+
+// WatchPosts handles BlogService.WatchPosts, streaming the events committed through this
+// instance until the client goes away. A client that cannot keep up is ended with
+// ResourceExhausted and should call again.
+func (bs *BlogServer) WatchPosts(req *blogpb.WatchPostsRequest, stream blogpb.BlogService_WatchPostsServer) error {
+	if bs.watch == nil {
+		return status.Error(codes.Unavailable, errWatchDisabled.Error())
+	}
+
+	filter := services.PostEventFilter{AuthorID: int(req.GetAuthorId()), Types: req.GetTypes()}
+	for _, tag := range req.GetTags() {
+		filter.Tags = append(filter.Tags, services.NormalizeTag(tag))
+	}
+
+	sub := bs.watch.Subscribe("")
+	defer sub.Cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case event, ok := <-sub.Events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, errWatchBehind.Error())
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := stream.Send(postEventProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// context returns the GoFr context of a call. Call metadata is exposed as request headers,
+// so x-actor and x-read-primary work as they do over HTTP.
+func (bs *BlogServer) context(ctx context.Context, req any) *gofr.Context {
+	header := http.Header{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			for _, value := range values {
+				header.Add(key, value)
+			}
+		}
+	}
+
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	ctx = middleware.WithRequestHeaders(ctx, header, remoteAddr)
+	return &gofr.Context{
+		Context:   ctx,
+		Container: bs.Container,
+		Request:   grpcRequest{ctx: ctx, header: header, msg: req},
+	}
+}
+
+// grpcRequest adapts a gRPC request message to GoFr's Request interface
+type grpcRequest struct {
+	ctx    context.Context
+	header http.Header
+	msg    any
+}
+
+func (r grpcRequest) Context() context.Context { return r.ctx }
+func (r grpcRequest) Param(string) string      { return "" }
+func (r grpcRequest) PathParam(string) string  { return "" }
+func (r grpcRequest) Params(string) []string   { return nil }
+func (r grpcRequest) HostName() string         { return r.header.Get(":authority") }
+
+// Bind copies the fields of the request message into i
+func (r grpcRequest) Bind(i any) error {
+	body, err := json.Marshal(r.msg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, i)
+}
+
+// grpcError maps a service error to a gRPC status
+func grpcError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, errValidation), errors.Is(err, errInvalidID), errors.Is(err, errInvalidRequest):
+		code = codes.InvalidArgument
+	case errors.Is(err, store.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+// postProto converts a post to its protobuf message
+func postProto(post *models.Post) *blogpb.Post {
+	msg := &blogpb.Post{
+		Id:              int64(post.ID),
+		Title:           post.Title,
+		Content:         post.Content,
+		Slug:            post.Slug,
+		AuthorId:        int64(post.AuthorID),
+		Status:          post.Status,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		MetaTitle:       post.MetaTitle,
+		MetaDescription: post.MetaDescription,
+		CanonicalUrl:    post.CanonicalURL,
+		OgImage:         post.OGImage,
+		Noindex:         post.NoIndex,
+		Tags:            post.Tags,
+	}
+	if post.PublishedAt != nil {
+		msg.PublishedAt = timestamppb.New(*post.PublishedAt)
+	}
+	return msg
+}
+
+// postEventProto converts a post event to its protobuf message
+func postEventProto(event *models.PostEvent) *blogpb.PostEvent {
+	msg := &blogpb.PostEvent{
+		Id:            event.ID,
+		Type:          event.Type,
+		OccurredAt:    timestamppb.New(event.OccurredAt),
+		PostId:        int64(event.PostID),
+		ChangedFields: event.ChangedFields,
+	}
+	if event.Post != nil {
+		msg.Post = postProto(event.Post)
+	}
+	return msg
+}
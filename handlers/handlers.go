@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"errors"
+	"mime"
+	"net/http"
+	"strings"
 
+	"gofr-blog-service/middleware"
 	"gofr-blog-service/models"
+	"gofr-blog-service/patch"
 	"gofr-blog-service/services"
 
 	"gofr.dev/pkg/gofr"
@@ -14,20 +19,54 @@ var (
 	errInvalidRequest = errors.New("invalid request format")
 	errValidation     = errors.New("validation failed")
 	errInvalidID      = errors.New("invalid post ID")
+	errInvalidFields  = errors.New("invalid fields parameter")
+
+	errInvalidPathParam = errors.New("invalid path parameter")
+)
+
+// Idempotency-Key handling for POST /posts
+const (
+	IdempotencyKeyHeader       = "Idempotency-Key"
+	IdempotentReplayedHeader   = "Idempotent-Replayed"
+	maxIdempotencyKeyLength    = 255
+	createPostIdempotencyScope = "POST /posts"
 )
 
+// acceptPatch lists the patch media types accepted by PATCH /posts/{id}
+const acceptPatch = patch.MergePatchMediaType + ", " + patch.JSONPatchMediaType
+
 // PostHandler handles HTTP requests for posts with decorators pattern
 type PostHandler struct {
-	postService *services.PostService
+	baseHandler
+	postService  *services.PostService
+	seoService   *services.SEOService
+	idempotency  *services.IdempotencyService
+	translations *services.TranslationService
+	tags         *services.TagService
 }
 
-// NewPostHandler creates a new post handler instance (dependency injection decorator)
-func NewPostHandler(postService *services.PostService) *PostHandler {
+// NewPostHandler creates a new post handler instance (dependency injection decorator).
+// A nil idempotency service ignores the Idempotency-Key header.
+func NewPostHandler(postService *services.PostService, seoService *services.SEOService,
+	idempotency *services.IdempotencyService) *PostHandler {
 	return &PostHandler{
 		postService: postService,
+		seoService:  seoService,
+		idempotency: idempotency,
 	}
 }
 
+// SetTranslations enables the translation endpoints and localizes GET /posts and
+// GET /posts/{id} along the locales requested with ?lang= and Accept-Language
+func (ph *PostHandler) SetTranslations(translations *services.TranslationService) {
+	ph.translations = translations
+}
+
+// SetTags enables the post tag endpoints
+func (ph *PostHandler) SetTags(tags *services.TagService) {
+	ph.tags = tags
+}
+
 // CreatePost handles POST /posts (HTTP decorator pattern)
 func (ph *PostHandler) CreatePost(ctx *gofr.Context) (any, error) {
 	// Request parsing decorator
@@ -41,17 +80,53 @@ func (ph *PostHandler) CreatePost(ctx *gofr.Context) (any, error) {
 		return ph.errorResponse("Validation failed", err), nil
 	}
 
+	// Idempotency decorator - replay the stored response of a retried request
+	var key string
+	if ph.idempotency != nil {
+		key = middleware.RequestHeader(ctx, IdempotencyKeyHeader)
+	}
+	if key != "" {
+		if len(key) > maxIdempotencyKeyLength {
+			return ph.errorResponse("Validation failed",
+				errors.Join(errValidation, errors.New("Idempotency-Key must be at most 255 characters"))), nil
+		}
+
+		replay, err := ph.idempotency.Begin(ctx, key, createPostIdempotencyScope, req)
+		switch {
+		case errors.Is(err, services.ErrIdempotencyMismatch):
+			return nil, statusError{status: http.StatusUnprocessableEntity, err: err}
+		case errors.Is(err, services.ErrIdempotencyInProgress):
+			return nil, statusError{status: http.StatusConflict, err: err}
+		case err != nil:
+			return ph.errorResponse("Failed to create post", err), nil
+		case replay != nil:
+			middleware.SetResponseHeader(ctx, IdempotentReplayedHeader, "true")
+			return replay, nil
+		}
+	}
+
+	// Success response decorator, stored for the key in the transaction creating the post
+	respond := func(post *models.Post) any {
+		return ph.successResponse("Post created successfully", post)
+	}
+	var complete services.PostHook
+	if key != "" {
+		complete = ph.idempotency.Completion(ctx, key, createPostIdempotencyScope, req, respond)
+	}
+
 	// Business logic delegation decorator
-	post, err := ph.postService.CreatePost(ctx, req)
+	post, err := ph.postService.CreatePostWith(ctx, req, complete)
 	if err != nil {
+		if key != "" {
+			ph.idempotency.Release(ctx, key)
+		}
 		return ph.errorResponse("Failed to create post", err), nil
 	}
 
-	// Success response decorator
-	return ph.successResponse("Post created successfully", post), nil
+	return respond(post), nil
 }
 
-// GetPost handles GET /posts/{id}
+// GetPost handles GET /posts/{id}, localized when translations are enabled
 func (ph *PostHandler) GetPost(ctx *gofr.Context) (any, error) {
 	// Parameter extraction decorator
 	id, err := ph.extractIDParam(ctx)
@@ -59,26 +134,131 @@ func (ph *PostHandler) GetPost(ctx *gofr.Context) (any, error) {
 		return ph.errorResponse("Invalid post ID", err), nil
 	}
 
+	// Fieldset extraction decorator
+	fields, err := ph.extractFieldsParam(ctx, nil)
+	if err != nil {
+		return ph.errorResponse("Invalid fields parameter", err), nil
+	}
+
 	// Service call decorator
-	post, err := ph.postService.GetPost(ctx, id)
+	post, err := ph.postService.GetPost(ctx, id, fields)
 	if err != nil {
 		return ph.errorResponse("Post not found", err), nil
 	}
 
+	// Localization decorator
+	if ph.translations != nil {
+		if err = ph.translations.Localize(ctx, ph.localeChain(ctx), post); err != nil {
+			return ph.errorResponse("Failed to retrieve post", err), nil
+		}
+		middleware.SetResponseHeader(ctx, "Content-Language", post.Locale)
+	}
+
+	if fields != nil {
+		return ph.successResponse("Post retrieved successfully", ph.project(post, fields)), nil
+	}
+
 	return ph.successResponse("Post retrieved successfully", post), nil
 }
 
-// ListPosts handles GET /posts with pagination
+// GetPostBySlug handles GET /posts/slug/{slug}
+func (ph *PostHandler) GetPostBySlug(ctx *gofr.Context) (any, error) {
+	// Fieldset extraction decorator
+	fields, err := ph.extractFieldsParam(ctx, nil)
+	if err != nil {
+		return ph.errorResponse("Invalid fields parameter", err), nil
+	}
+
+	// Service call decorator
+	post, err := ph.postService.GetPostBySlug(ctx, ctx.PathParam("slug"))
+	if err != nil {
+		return ph.errorResponse("Post not found", err), nil
+	}
+
+	if fields != nil {
+		return ph.successResponse("Post retrieved successfully", post.Project(fields)), nil
+	}
+
+	return ph.successResponse("Post retrieved successfully", post), nil
+}
+
+// GetPostSEO handles GET /posts/{id}/seo with JSON-LD and Open Graph output
+func (ph *PostHandler) GetPostSEO(ctx *gofr.Context) (any, error) {
+	// Parameter extraction decorator
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	// Service call decorator
+	seo, err := ph.seoService.GetPostSEO(ctx, id)
+	if err != nil {
+		return ph.errorResponse("Post not found", err), nil
+	}
+
+	return ph.successResponse("SEO metadata retrieved successfully", seo), nil
+}
+
+// ListPosts handles GET /posts with pagination.
+// Lists default to the summary fieldset; ?view=full or an explicit ?fields= overrides it.
+// Pages carry a weak ETag of the newest update time and post count, and If-None-Match
+// answers 304 Not Modified without reading the page. Posts are localized when translations
+// are enabled, and the ETag then names the locale chain as well.
 func (ph *PostHandler) ListPosts(ctx *gofr.Context) (any, error) {
 	// Query parameter extraction decorator
 	page, pageSize := ph.extractPaginationParams(ctx)
 
+	defaultFields := models.PostSummaryFields
+	if ctx.Param("view") == "full" {
+		defaultFields = nil
+	}
+
+	fields, err := ph.extractFieldsParam(ctx, defaultFields)
+	if err != nil {
+		return ph.errorResponse("Invalid fields parameter", err), nil
+	}
+
+	// Conditional GET decorator, answered before any post is read
+	version, err := ph.postService.GetListVersion(ctx)
+	if err != nil {
+		return ph.errorResponse("Failed to retrieve posts", err), nil
+	}
+
+	// Locale decorator, named in the ETag since pages differ per locale chain
+	var chain []string
+	if ph.translations != nil {
+		chain = ph.localeChain(ctx)
+		if len(chain) > 1 {
+			localized := *version
+			localized.Variant = strings.Join(chain, ".")
+			version = &localized
+		}
+	}
+	if ph.notModified(ctx, version, "no-cache") {
+		return nil, errNotModified
+	}
+
 	// Service call with error handling decorator
-	posts, err := ph.postService.ListPosts(ctx, page, pageSize)
+	posts, err := ph.postService.ListPosts(ctx, page, pageSize, fields)
 	if err != nil {
 		return ph.errorResponse("Failed to retrieve posts", err), nil
 	}
 
+	// Localization decorator
+	if ph.translations != nil {
+		localized := make([]*models.Post, 0, len(posts.Posts))
+		for i := range posts.Posts {
+			localized = append(localized, &posts.Posts[i])
+		}
+		if err = ph.translations.Localize(ctx, chain, localized...); err != nil {
+			return ph.errorResponse("Failed to retrieve posts", err), nil
+		}
+	}
+
+	if fields != nil {
+		return ph.successResponse("Posts retrieved successfully", ph.projectList(posts, fields)), nil
+	}
+
 	return ph.successResponse("Posts retrieved successfully", posts), nil
 }
 
@@ -110,6 +290,42 @@ func (ph *PostHandler) UpdatePost(ctx *gofr.Context) (any, error) {
 	return ph.successResponse("Post updated successfully", post), nil
 }
 
+// PatchPost handles PATCH /posts/{id} with a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) body
+func (ph *PostHandler) PatchPost(ctx *gofr.Context) (any, error) {
+	// Parameter extraction decorator
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	// Media type negotiation decorator
+	mediaType, _, _ := mime.ParseMediaType(middleware.RequestHeader(ctx, "Content-Type"))
+	if mediaType != patch.MergePatchMediaType && mediaType != patch.JSONPatchMediaType {
+		middleware.SetResponseHeader(ctx, "Accept-Patch", acceptPatch)
+		return nil, statusError{status: http.StatusUnsupportedMediaType, err: services.ErrUnsupportedPatch}
+	}
+
+	body, ok := middleware.Body(ctx)
+	if !ok || len(body) == 0 {
+		return ph.errorResponse("Invalid request format", errors.Join(errInvalidRequest, errors.New("empty patch"))), nil
+	}
+
+	// Service call decorator - the patched post is validated by the create rules
+	post, err := ph.postService.PatchPost(ctx, id, mediaType, body, ph.validateCreateRequest)
+	switch {
+	case errors.Is(err, patch.ErrTestFailed):
+		return nil, statusError{status: http.StatusConflict, err: err}
+	case errors.Is(err, patch.ErrInvalidPatch), errors.Is(err, patch.ErrPathNotFound):
+		return ph.errorResponse("Invalid patch", err), nil
+	case errors.Is(err, errValidation):
+		return ph.errorResponse("Validation failed", err), nil
+	case err != nil:
+		return ph.errorResponse("Failed to update post", err), nil
+	}
+
+	return ph.successResponse("Post updated successfully", post), nil
+}
+
 // DeletePost handles DELETE /posts/{id}
 func (ph *PostHandler) DeletePost(ctx *gofr.Context) (any, error) {
 	// Parameter extraction decorator
@@ -129,27 +345,27 @@ func (ph *PostHandler) DeletePost(ctx *gofr.Context) (any, error) {
 	}), nil
 }
 
-// Response formatting decorators for consistent API responses
+// projectList limits every post in a list response to the given fieldset
+func (ph *PostHandler) projectList(list *models.PostListResponse, fields []string) *models.SparsePostListResponse {
+	posts := make([]map[string]any, 0, len(list.Posts))
+	for i := range list.Posts {
+		posts = append(posts, ph.project(&list.Posts[i], fields))
+	}
 
-// successResponse creates a standardized success response
-func (ph *PostHandler) successResponse(message string, data any) map[string]any {
-	return map[string]any{
-		"success": true,
-		"message": message,
-		"data":    data,
+	return &models.SparsePostListResponse{
+		Posts:      posts,
+		TotalCount: list.TotalCount,
+		Page:       list.Page,
+		PageSize:   list.PageSize,
+		TotalPages: list.TotalPages,
 	}
 }
 
-// errorResponse creates a standardized error response
-func (ph *PostHandler) errorResponse(message string, err error) map[string]any {
-	response := map[string]any{
-		"success": false,
-		"message": message,
+// project limits a post to the given fieldset, keeping the locale of a localized post
+func (ph *PostHandler) project(post *models.Post, fields []string) map[string]any {
+	projected := post.Project(fields)
+	if post.Locale != "" {
+		projected["locale"] = post.Locale
 	}
-
-	if err != nil {
-		response["error"] = err.Error()
-	}
-
-	return response
+	return projected
 }
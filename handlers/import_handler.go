@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ImportHandler handles HTTP requests for imports from other blogging platforms
+type ImportHandler struct {
+	baseHandler
+	importService *services.ImportService
+}
+
+// NewImportHandler creates a new import handler instance
+func NewImportHandler(importService *services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportWordPress handles POST /admin/imports/wordpress, whose body is a WXR export.
+// With dry_run=true it reports what the import would do; otherwise it queues an import job.
+func (ih *ImportHandler) ImportWordPress(ctx *gofr.Context) (any, error) {
+	if err := ih.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	payload, ok := middleware.Body(ctx)
+	if !ok || len(payload) == 0 {
+		return ih.errorResponse("Invalid request format",
+			errors.Join(errInvalidRequest, errors.New("the request body must be a WXR export"))), nil
+	}
+
+	options, err := parseImportOptions(ctx.Param("default_author_id"), ctx.Param("author_map"))
+	if err != nil {
+		return ih.errorResponse("Validation failed", err), nil
+	}
+
+	if dryRun, _ := strconv.ParseBool(ctx.Param("dry_run")); dryRun {
+		report, err := ih.importService.DryRunWordPress(ctx, payload, options)
+		if err != nil {
+			return ih.errorResponse("Failed to check import", err), nil
+		}
+		return ih.successResponse("Import checked successfully", report), nil
+	}
+
+	job, err := ih.importService.StartWordPress(ctx, payload, options)
+	if err != nil {
+		return ih.errorResponse("Failed to start import", err), nil
+	}
+
+	return ih.successResponse("Import started successfully", job), nil
+}
+
+// GetImport handles GET /admin/imports/{id}, reporting the progress of an import job
+func (ih *ImportHandler) GetImport(ctx *gofr.Context) (any, error) {
+	if err := ih.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := ih.extractPathInt(ctx, "id")
+	if err != nil {
+		return ih.errorResponse("Invalid import ID", err), nil
+	}
+
+	job, err := ih.importService.GetJob(ctx, id)
+	if err != nil {
+		return ih.errorResponse("Import not found", err), nil
+	}
+
+	return ih.successResponse("Import retrieved successfully", job), nil
+}
+
+// parseImportOptions reads a default author id and an author map of login:id pairs
+// separated by commas, e.g. "admin:1,jane:4"
+func parseImportOptions(defaultAuthorID, authorMap string) (models.ImportOptions, error) {
+	var options models.ImportOptions
+	if defaultAuthorID != "" {
+		id, err := strconv.Atoi(defaultAuthorID)
+		if err != nil || id <= 0 {
+			return options, errors.Join(errValidation, errors.New("default_author_id must be a positive integer"))
+		}
+		options.DefaultAuthorID = id
+	}
+
+	if authorMap == "" {
+		return options, nil
+	}
+	options.AuthorMap = make(map[string]int)
+	for _, pair := range strings.Split(authorMap, ",") {
+		login, value, found := strings.Cut(strings.TrimSpace(pair), ":")
+		id, err := strconv.Atoi(value)
+		if !found || login == "" || err != nil || id <= 0 {
+			return options, errors.Join(errValidation, errors.New("author_map must be login:id pairs, got: "+pair))
+		}
+		options.AuthorMap[login] = id
+	}
+	return options, nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gofr-blog-service/middleware"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// baseHandler carries the response and parameter decorators shared by every handler
+type baseHandler struct{}
+
+// Response formatting decorators for consistent API responses
+
+// successResponse creates a standardized success response
+func (bh baseHandler) successResponse(message string, data any) map[string]any {
+	return map[string]any{
+		"success": true,
+		"message": message,
+		"data":    data,
+	}
+}
+
+// errorResponse creates a standardized error response
+func (bh baseHandler) errorResponse(message string, err error) map[string]any {
+	response := map[string]any{
+		"success": false,
+		"message": message,
+	}
+
+	if err != nil {
+		response["error"] = err.Error()
+	}
+
+	return response
+}
+
+// statusError carries an HTTP status code to GoFr's responder for failures that clients
+// must tell apart from a plain error response
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e statusError) Error() string   { return e.err.Error() }
+func (e statusError) Unwrap() error   { return e.err }
+func (e statusError) StatusCode() int { return e.status }
+
+// requireAdmin checks that Authenticate resolved the request to an admin: the admin key, or
+// the API key of an admin user. Anonymous requests get 401 and other callers 403.
+func (bh baseHandler) requireAdmin(ctx *gofr.Context) error {
+	principal := middleware.Principal(ctx)
+	switch {
+	case principal == nil:
+		middleware.SetResponseHeader(ctx, "WWW-Authenticate", `Bearer realm="gofr-blog-service"`)
+		return statusError{status: http.StatusUnauthorized, err: errForbidden}
+	case !principal.IsAdmin():
+		return statusError{status: http.StatusForbidden, err: errForbidden}
+	}
+	return nil
+}
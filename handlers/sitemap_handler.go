@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+const sitemapContentType = "application/xml; charset=utf-8"
+
+var errInvalidSitemap = errors.New("invalid sitemap number")
+
+// SitemapHandler handles HTTP requests for XML sitemaps
+type SitemapHandler struct {
+	baseHandler
+	sitemapService *services.SitemapService
+}
+
+// NewSitemapHandler creates a new sitemap handler instance
+func NewSitemapHandler(sitemapService *services.SitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapService: sitemapService,
+	}
+}
+
+// Sitemap handles GET /sitemap.xml
+func (sh *SitemapHandler) Sitemap(ctx *gofr.Context) (any, error) {
+	sitemap, err := sh.sitemapService.Sitemap(ctx)
+	if err != nil {
+		return sh.errorResponse("Failed to build sitemap", err), nil
+	}
+
+	return response.File{Content: sitemap, ContentType: sitemapContentType}, nil
+}
+
+// ChildSitemap handles GET /sitemap-{page}.xml of a sitemap index
+func (sh *SitemapHandler) ChildSitemap(ctx *gofr.Context) (any, error) {
+	index, err := strconv.Atoi(ctx.PathParam("page"))
+	if err != nil || index <= 0 {
+		return sh.errorResponse("Invalid sitemap number", errInvalidSitemap), nil
+	}
+
+	sitemap, err := sh.sitemapService.ChildSitemap(ctx, index)
+	if err != nil {
+		return sh.errorResponse("Sitemap not found", err), nil
+	}
+
+	return response.File{Content: sitemap, ContentType: sitemapContentType}, nil
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Stream control messages, sent alongside post events
+const (
+	// StreamReset tells a resuming client that events were missed and it should reload posts
+	StreamReset = "stream.reset"
+	// StreamPing keeps idle WebSocket connections alive
+	StreamPing = "stream.ping"
+)
+
+// Stream timing
+const (
+	streamRetry     = 3 * time.Second
+	streamKeepAlive = 15 * time.Second
+)
+
+// StreamHandler pushes post changes to dashboards as Server-Sent Events and over WebSockets
+type StreamHandler struct {
+	baseHandler
+	watch *services.PostWatch
+}
+
+// NewStreamHandler creates a stream handler over the post watch
+func NewStreamHandler(watch *services.PostWatch) *StreamHandler {
+	return &StreamHandler{watch: watch}
+}
+
+// ServeSSE handles GET /posts/stream with Accept: text/event-stream once Recent accepted it.
+// Every event carries its id, so a reconnecting EventSource resumes after Last-Event-ID from
+// the buffered events.
+func (sh *StreamHandler) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		sh.writeJSON(w, http.StatusInternalServerError,
+			sh.errorResponse("Streaming unsupported", errors.New("response writer cannot flush")))
+		return
+	}
+
+	query := r.URL.Query()
+	filter, err := sh.extractFilter(query.Get)
+	if err != nil {
+		sh.writeJSON(w, http.StatusBadRequest, sh.errorResponse("Validation failed", err))
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("last_event_id")
+	}
+
+	sub := sh.watch.Subscribe(lastEventID)
+	defer sub.Cancel()
+
+	w.Header().Set("Content-Type", middleware.EventStreamMediaType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = io.WriteString(w, "retry: "+strconv.FormatInt(streamRetry.Milliseconds(), 10)+"\n\n")
+	if sub.Gap {
+		_, _ = io.WriteString(w, "event: "+StreamReset+"\ndata: {}\n\n")
+	}
+	for _, event := range sub.Replay {
+		if err = sh.writeSSE(w, event, filter); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(streamKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			// A watcher that fell behind is closed; the client reconnects and resumes
+			if !ok {
+				return
+			}
+			err = sh.writeSSE(w, event, filter)
+		case <-ticker.C:
+			_, err = io.WriteString(w, ": ping\n\n")
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// WebSocket handles GET /posts/stream/ws, sending post events as JSON messages. Browsers cannot
+// set headers on a WebSocket, so the event to resume after is passed as ?last_event_id=.
+func (sh *StreamHandler) WebSocket(ctx *gofr.Context) (any, error) {
+	filter, err := sh.extractFilter(ctx.Param)
+	if err != nil {
+		_ = ctx.WriteMessageToSocket(sh.errorResponse("Validation failed", err))
+		return nil, err
+	}
+
+	sub := sh.watch.Subscribe(ctx.Param("last_event_id"))
+	defer sub.Cancel()
+
+	if sub.Gap {
+		if err = ctx.WriteMessageToSocket(map[string]string{"type": StreamReset}); err != nil {
+			return nil, err
+		}
+	}
+	for _, event := range sub.Replay {
+		if !filter.Matches(event) {
+			continue
+		}
+		if err = ctx.WriteMessageToSocket(event); err != nil {
+			return nil, err
+		}
+	}
+
+	ticker := time.NewTicker(streamKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-sub.Events:
+			if !ok {
+				return nil, errWatchBehind
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			err = ctx.WriteMessageToSocket(event)
+		case <-ticker.C:
+			err = ctx.WriteMessageToSocket(map[string]string{"type": StreamPing})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Recent handles GET /posts/stream, returning the buffered events after ?last_event_id= so
+// clients can poll cheaply. Requests accepting text/event-stream are handed to ServeSSE.
+func (sh *StreamHandler) Recent(ctx *gofr.Context) (any, error) {
+	filter, err := sh.extractFilter(ctx.Param)
+	if err != nil {
+		return sh.errorResponse("Validation failed", err), nil
+	}
+	if middleware.AcceptEventStream(ctx) {
+		return nil, nil
+	}
+
+	buffered, found := sh.watch.Since(ctx.Param("last_event_id"))
+	events := make([]*models.PostEvent, 0, len(buffered))
+	for _, event := range buffered {
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	return sh.successResponse("Post events retrieved successfully", map[string]any{
+		"events": events,
+		"reset":  !found,
+	}), nil
+}
+
+// writeSSE writes event as a Server-Sent Event. Events outside the filter are sent as a bare id,
+// which moves the client's Last-Event-ID forward without dispatching anything.
+func (sh *StreamHandler) writeSSE(w io.Writer, event *models.PostEvent, filter services.PostEventFilter) error {
+	if !filter.Matches(event) {
+		_, err := io.WriteString(w, "id: "+event.ID+"\n\n")
+		return err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "id: "+event.ID+"\nevent: "+event.Type+"\ndata: "+string(data)+"\n\n")
+	return err
+}
+
+// writeJSON writes a JSON response outside GoFr's responder
+func (sh *StreamHandler) writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// extractFilter reads the author_id, status and comma-separated type and tag filters from param
+func (sh *StreamHandler) extractFilter(param func(string) string) (services.PostEventFilter, error) {
+	var filter services.PostEventFilter
+
+	if authorID := param("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil || id <= 0 {
+			return filter, errors.Join(errValidation, errors.New("author_id must be a positive integer"))
+		}
+		filter.AuthorID = id
+	}
+
+	if status := param("status"); status != "" {
+		if !slices.Contains([]string{"draft", "published", "archived"}, status) {
+			return filter, errors.Join(errValidation, errors.New("invalid status: "+status))
+		}
+		filter.Status = status
+	}
+
+	if types := param("type"); types != "" {
+		for _, eventType := range strings.Split(types, ",") {
+			eventType = strings.TrimSpace(eventType)
+			if eventType == "*" {
+				filter.Types = nil
+				return filter, nil
+			}
+			if !services.IsWebhookEventType(eventType) {
+				return filter, errors.Join(errValidation, errors.New("invalid event type: "+eventType))
+			}
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+
+	if tags := param("tag"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			normalized := services.NormalizeTag(tag)
+			if normalized == "" {
+				return filter, errors.Join(errValidation, errors.New("invalid tag: "+tag))
+			}
+			filter.Tags = append(filter.Tags, normalized)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// GetPostTags handles GET /posts/{id}/tags
+func (ph *PostHandler) GetPostTags(ctx *gofr.Context) (any, error) {
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	tags, err := ph.tags.List(ctx, id)
+	if err != nil {
+		return ph.errorResponse("Failed to retrieve tags", err), nil
+	}
+
+	return ph.successResponse("Tags retrieved successfully", tags), nil
+}
+
+// PutPostTags handles PUT /posts/{id}/tags, replacing the tags of the post
+func (ph *PostHandler) PutPostTags(ctx *gofr.Context) (any, error) {
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	var req models.PostTagsRequest
+	if err = ctx.Bind(&req); err != nil {
+		return ph.errorResponse("Invalid request format", errors.Join(errInvalidRequest, err)), nil
+	}
+
+	tags, err := ph.tags.Set(ctx, id, req.Tags)
+	if err != nil {
+		return ph.errorResponse("Failed to save tags", err), nil
+	}
+
+	return ph.successResponse("Tags saved successfully", tags), nil
+}
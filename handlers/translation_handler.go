@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+
+	"gofr-blog-service/i18n"
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ListTranslations handles GET /posts/{id}/translations
+func (ph *PostHandler) ListTranslations(ctx *gofr.Context) (any, error) {
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	translations, err := ph.translations.List(ctx, id)
+	if err != nil {
+		return ph.errorResponse("Failed to retrieve translations", err), nil
+	}
+
+	return ph.successResponse("Translations retrieved successfully", translations), nil
+}
+
+// PutTranslation handles PUT /posts/{id}/translations/{locale}, creating or replacing the
+// post's translation into one of the site's locales
+func (ph *PostHandler) PutTranslation(ctx *gofr.Context) (any, error) {
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	var req models.TranslationRequest
+	if err = ctx.Bind(&req); err != nil {
+		return ph.errorResponse("Invalid request format", errors.Join(errInvalidRequest, err)), nil
+	}
+	if err = ph.validateTranslationRequest(req); err != nil {
+		return ph.errorResponse("Validation failed", err), nil
+	}
+
+	translation, err := ph.translations.Upsert(ctx, id, ctx.PathParam("locale"), req)
+	if err != nil {
+		return ph.errorResponse("Failed to save translation", err), nil
+	}
+
+	return ph.successResponse("Translation saved successfully", translation), nil
+}
+
+// DeleteTranslation handles DELETE /posts/{id}/translations/{locale}
+func (ph *PostHandler) DeleteTranslation(ctx *gofr.Context) (any, error) {
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	locale := ctx.PathParam("locale")
+	if err = ph.translations.Delete(ctx, id, locale); err != nil {
+		return ph.errorResponse("Failed to delete translation", err), nil
+	}
+
+	return ph.successResponse("Translation deleted successfully", map[string]any{
+		"post_id": id,
+		"locale":  i18n.Normalize(locale),
+	}), nil
+}
+
+// GetPostLocales handles GET /posts/{id}/locales, listing the site locales the post is
+// available and missing in
+func (ph *PostHandler) GetPostLocales(ctx *gofr.Context) (any, error) {
+	id, err := ph.extractIDParam(ctx)
+	if err != nil {
+		return ph.errorResponse("Invalid post ID", err), nil
+	}
+
+	locales, err := ph.translations.Locales(ctx, id)
+	if err != nil {
+		return ph.errorResponse("Failed to retrieve locales", err), nil
+	}
+
+	return ph.successResponse("Locales retrieved successfully", locales), nil
+}
+
+// localeChain returns the site locales to render a response in: the tags of ?lang= in order,
+// then those of Accept-Language, ending with the default locale. Responses vary with
+// Accept-Language from then on.
+func (ph *PostHandler) localeChain(ctx *gofr.Context) []string {
+	middleware.SetResponseHeader(ctx, "Vary", "Accept-Language")
+
+	requested := i18n.ParseList(ctx.Param("lang"))
+	requested = append(requested, i18n.ParseAcceptLanguage(middleware.RequestHeader(ctx, "Accept-Language"))...)
+	return ph.translations.Chain(requested)
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"errors"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"gofr-blog-service/models"
 
@@ -25,6 +27,12 @@ func (ph *PostHandler) parseUpdateRequest(ctx *gofr.Context, req *models.UpdateP
 	return nil
 }
 
+// CreateValidator returns the validation rules of created posts, for services that create posts
+// outside of requests, such as imports
+func (ph *PostHandler) CreateValidator() func(models.CreatePostRequest) error {
+	return ph.validateCreateRequest
+}
+
 // validateCreateRequest validates the create post request
 func (ph *PostHandler) validateCreateRequest(req models.CreatePostRequest) error {
 	if req.Title == "" {
@@ -45,6 +53,9 @@ func (ph *PostHandler) validateCreateRequest(req models.CreatePostRequest) error
 	if req.AuthorID <= 0 {
 		return errors.Join(errValidation, errors.New("valid author ID is required"))
 	}
+	if err := ph.validateSEOFields(req.MetaTitle, req.MetaDescription, req.CanonicalURL, req.OGImage); err != nil {
+		return err
+	}
 	if req.Status == "" {
 		req.Status = "draft"
 	}
@@ -65,6 +76,9 @@ func (ph *PostHandler) validateUpdateRequest(req models.UpdatePostRequest) error
 	if req.Content != "" && len(req.Content) < 10 {
 		return errors.Join(errValidation, errors.New("content must be at least 10 characters"))
 	}
+	if err := ph.validateSEOFields(req.MetaTitle, req.MetaDescription, req.CanonicalURL, req.OGImage); err != nil {
+		return err
+	}
 	if req.Status != "" {
 		validStatuses := []string{"draft", "published", "archived"}
 		valid := false
@@ -81,8 +95,45 @@ func (ph *PostHandler) validateUpdateRequest(req models.UpdatePostRequest) error
 	return nil
 }
 
+// validateTranslationRequest validates a translation with the title, content and SEO rules of posts
+func (ph *PostHandler) validateTranslationRequest(req models.TranslationRequest) error {
+	if len(req.Title) < 3 || len(req.Title) > 200 {
+		return errors.Join(errValidation, errors.New("title must be between 3 and 200 characters"))
+	}
+	if len(req.Content) < 10 {
+		return errors.Join(errValidation, errors.New("content must be at least 10 characters"))
+	}
+	if len(req.Slug) < 3 || len(req.Slug) > 200 {
+		return errors.Join(errValidation, errors.New("slug must be between 3 and 200 characters"))
+	}
+	return ph.validateSEOFields(req.MetaTitle, req.MetaDescription, req.CanonicalURL, req.OGImage)
+}
+
+// validateSEOFields validates the optional SEO metadata shared by create and update requests
+func (ph *PostHandler) validateSEOFields(metaTitle, metaDescription, canonicalURL, ogImage string) error {
+	if len(metaTitle) > 200 {
+		return errors.Join(errValidation, errors.New("meta title must be at most 200 characters"))
+	}
+	if len(metaDescription) > 300 {
+		return errors.Join(errValidation, errors.New("meta description must be at most 300 characters"))
+	}
+	if canonicalURL != "" && !isAbsoluteURL(canonicalURL) {
+		return errors.Join(errValidation, errors.New("canonical URL must be an absolute http(s) URL"))
+	}
+	if ogImage != "" && !isAbsoluteURL(ogImage) {
+		return errors.Join(errValidation, errors.New("og image must be an absolute http(s) URL"))
+	}
+	return nil
+}
+
+// isAbsoluteURL reports whether raw is an absolute http or https URL
+func isAbsoluteURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // extractIDParam extracts and validates ID parameter from URL
-func (ph *PostHandler) extractIDParam(ctx *gofr.Context) (int, error) {
+func (bh baseHandler) extractIDParam(ctx *gofr.Context) (int, error) {
 	idStr := ctx.PathParam("id")
 	if idStr == "" {
 		return 0, errors.Join(errInvalidID, errors.New("missing post ID"))
@@ -100,8 +151,17 @@ func (ph *PostHandler) extractIDParam(ctx *gofr.Context) (int, error) {
 	return id, nil
 }
 
+// extractPathInt extracts a positive integer path parameter
+func (bh baseHandler) extractPathInt(ctx *gofr.Context, name string) (int, error) {
+	value, err := strconv.Atoi(ctx.PathParam(name))
+	if err != nil || value <= 0 {
+		return 0, errors.Join(errInvalidPathParam, errors.New(name+" must be a positive integer"))
+	}
+	return value, nil
+}
+
 // extractPaginationParams extracts pagination parameters with defaults
-func (ph *PostHandler) extractPaginationParams(ctx *gofr.Context) (page, pageSize int) {
+func (bh baseHandler) extractPaginationParams(ctx *gofr.Context) (page, pageSize int) {
 	page = 1
 	pageSize = 10
 
@@ -119,3 +179,26 @@ func (ph *PostHandler) extractPaginationParams(ctx *gofr.Context) (page, pageSiz
 
 	return page, pageSize
 }
+
+// extractFieldsParam extracts the comma-separated fields parameter.
+// It returns defaults when the parameter is absent and always includes id in an explicit fieldset.
+func (ph *PostHandler) extractFieldsParam(ctx *gofr.Context, defaults []string) ([]string, error) {
+	fieldsStr := ctx.Param("fields")
+	if fieldsStr == "" {
+		return defaults, nil
+	}
+
+	fields := []string{"id"}
+	for _, field := range strings.Split(fieldsStr, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || field == "id" {
+			continue
+		}
+		if !models.IsPostField(field) {
+			return nil, errors.Join(errInvalidFields, errors.New("unknown field: "+field))
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
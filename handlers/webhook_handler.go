@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/services"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions and their delivery logs
+type WebhookHandler struct {
+	baseHandler
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler instance.
+// Requests must be authenticated as an admin, since subscriptions choose where the service
+// sends requests and deliveries hold event payloads.
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook handles POST /webhooks
+func (wh *WebhookHandler) CreateWebhook(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var req models.CreateWebhookRequest
+	if err := ctx.Bind(&req); err != nil {
+		return wh.errorResponse("Invalid request format", errors.Join(errInvalidRequest, err)), nil
+	}
+
+	if req.URL == "" {
+		return wh.errorResponse("Validation failed", errors.Join(errValidation, errors.New("url is required"))), nil
+	}
+	if len(req.EventTypes) == 0 {
+		return wh.errorResponse("Validation failed", errors.Join(errValidation, errors.New("event_types is required"))), nil
+	}
+	if err := wh.validateWebhookFields(req.URL, req.Secret, req.EventTypes); err != nil {
+		return wh.errorResponse("Validation failed", err), nil
+	}
+
+	sub, err := wh.webhookService.CreateSubscription(ctx, req)
+	if err != nil {
+		return wh.errorResponse("Failed to create webhook", err), nil
+	}
+
+	return wh.successResponse("Webhook created successfully", sub), nil
+}
+
+// ListWebhooks handles GET /webhooks
+func (wh *WebhookHandler) ListWebhooks(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	subs, err := wh.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		return wh.errorResponse("Failed to retrieve webhooks", err), nil
+	}
+
+	return wh.successResponse("Webhooks retrieved successfully", subs), nil
+}
+
+// GetWebhook handles GET /webhooks/{id}
+func (wh *WebhookHandler) GetWebhook(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := wh.extractPathInt(ctx, "id")
+	if err != nil {
+		return wh.errorResponse("Invalid webhook ID", err), nil
+	}
+
+	sub, err := wh.webhookService.GetSubscription(ctx, id)
+	if err != nil {
+		return wh.errorResponse("Webhook not found", err), nil
+	}
+
+	return wh.successResponse("Webhook retrieved successfully", sub), nil
+}
+
+// UpdateWebhook handles PUT /webhooks/{id}
+func (wh *WebhookHandler) UpdateWebhook(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := wh.extractPathInt(ctx, "id")
+	if err != nil {
+		return wh.errorResponse("Invalid webhook ID", err), nil
+	}
+
+	var req models.UpdateWebhookRequest
+	if bindErr := ctx.Bind(&req); bindErr != nil {
+		return wh.errorResponse("Invalid request format", errors.Join(errInvalidRequest, bindErr)), nil
+	}
+
+	if validateErr := wh.validateWebhookFields(req.URL, req.Secret, req.EventTypes); validateErr != nil {
+		return wh.errorResponse("Validation failed", validateErr), nil
+	}
+
+	sub, err := wh.webhookService.UpdateSubscription(ctx, id, req)
+	if err != nil {
+		return wh.errorResponse("Failed to update webhook", err), nil
+	}
+
+	return wh.successResponse("Webhook updated successfully", sub), nil
+}
+
+// DeleteWebhook handles DELETE /webhooks/{id}
+func (wh *WebhookHandler) DeleteWebhook(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := wh.extractPathInt(ctx, "id")
+	if err != nil {
+		return wh.errorResponse("Invalid webhook ID", err), nil
+	}
+
+	if err = wh.webhookService.DeleteSubscription(ctx, id); err != nil {
+		return wh.errorResponse("Failed to delete webhook", err), nil
+	}
+
+	return wh.successResponse("Webhook deleted successfully", map[string]any{
+		"deleted_id": id,
+	}), nil
+}
+
+// ListDeliveries handles GET /webhooks/{id}/deliveries with pagination and an optional status filter
+func (wh *WebhookHandler) ListDeliveries(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := wh.extractPathInt(ctx, "id")
+	if err != nil {
+		return wh.errorResponse("Invalid webhook ID", err), nil
+	}
+
+	status := ctx.Param("status")
+	if status != "" && status != models.DeliveryPending && status != models.DeliverySucceeded &&
+		status != models.DeliveryFailed {
+		return wh.errorResponse("Validation failed", errors.Join(errValidation, errors.New("invalid status: "+status))), nil
+	}
+
+	page, pageSize := wh.extractPaginationParams(ctx)
+
+	deliveries, err := wh.webhookService.ListDeliveries(ctx, id, status, page, pageSize)
+	if err != nil {
+		return wh.errorResponse("Failed to retrieve deliveries", err), nil
+	}
+
+	return wh.successResponse("Deliveries retrieved successfully", deliveries), nil
+}
+
+// ReplayDelivery handles POST /webhooks/{id}/deliveries/{delivery_id}/replay
+func (wh *WebhookHandler) ReplayDelivery(ctx *gofr.Context) (any, error) {
+	if err := wh.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := wh.extractPathInt(ctx, "id")
+	if err != nil {
+		return wh.errorResponse("Invalid webhook ID", err), nil
+	}
+
+	deliveryID, err := wh.extractPathInt(ctx, "delivery_id")
+	if err != nil {
+		return wh.errorResponse("Invalid delivery ID", err), nil
+	}
+
+	delivery, err := wh.webhookService.ReplayDelivery(ctx, id, int64(deliveryID))
+	if err != nil {
+		return wh.errorResponse("Failed to replay delivery", err), nil
+	}
+
+	return wh.successResponse("Delivery queued for replay", delivery), nil
+}
+
+// validateWebhookFields validates the optional fields shared by create and update requests
+func (wh *WebhookHandler) validateWebhookFields(url, secret string, eventTypes []string) error {
+	if url != "" && !isAbsoluteURL(url) {
+		return errors.Join(errValidation, errors.New("url must be an absolute http(s) URL"))
+	}
+	if secret != "" && len(secret) < 16 {
+		return errors.Join(errValidation, errors.New("secret must be at least 16 characters"))
+	}
+	for _, eventType := range eventTypes {
+		if !services.IsWebhookEventType(eventType) {
+			return errors.Join(errValidation, errors.New("unknown event type: "+eventType))
+		}
+	}
+	return nil
+}
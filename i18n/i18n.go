@@ -0,0 +1,149 @@
+// Package i18n parses language tags and resolves the locale fallback chain of a request
+// against the locales a site publishes in.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize returns tag in canonical case, such as "de-AT", "zh-Hant" or "ja", accepting
+// underscores as separators. It returns "" for malformed tags.
+func Normalize(tag string) string {
+	subtags := strings.FieldsFunc(strings.TrimSpace(tag), func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 {
+		return ""
+	}
+
+	for i, subtag := range subtags {
+		if len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return ""
+		}
+		switch {
+		case i == 0:
+			if len(subtag) < 2 || len(subtag) > 3 || !isAlpha(subtag) {
+				return ""
+			}
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 2 && isAlpha(subtag):
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4 && isAlpha(subtag):
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+// Base returns the language subtag of a normalized tag, such as "de" for "de-AT"
+func Base(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
+
+// ParseList parses a comma-separated list of tags such as "en,de,ja", dropping malformed
+// and repeated tags
+func ParseList(list string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, raw := range strings.Split(list, ",") {
+		tag := Normalize(raw)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header, most preferred first.
+// Tags refused with q=0, the "*" wildcard and malformed tags are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+
+		if tag = Normalize(tag); tag != "" && q > 0 {
+			ranges = append(ranges, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	tags := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		tags = append(tags, r.tag)
+	}
+	return tags
+}
+
+// Chain returns the locales to try for the requested tags, in order: each requested tag
+// that is supported, followed by its base language when that is supported, and finally
+// fallback. Every locale appears once and the chain always ends with fallback.
+func Chain(requested, supported []string, fallback string) []string {
+	available := make(map[string]bool, len(supported))
+	for _, locale := range supported {
+		available[locale] = true
+	}
+
+	var chain []string
+	seen := map[string]bool{fallback: true}
+	add := func(locale string) {
+		if available[locale] && !seen[locale] {
+			seen[locale] = true
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, tag := range requested {
+		tag = Normalize(tag)
+		if tag == fallback {
+			break
+		}
+		add(tag)
+		base := Base(tag)
+		if base == fallback {
+			break
+		}
+		add(base)
+	}
+	return append(chain, fallback)
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNormalize tests canonicalizing the case and separators of language tags
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{"EN", "en"},
+		{"de_at", "de-AT"},
+		{" zh-hant-tw ", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"*", ""},
+		{"e", ""},
+		{"en-<script>", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.tag); got != tt.expected {
+			t.Errorf("Normalize(%q): expected %q, got %q", tt.tag, tt.expected, got)
+		}
+	}
+}
+
+// TestParseAcceptLanguage tests ordering header tags by quality and dropping refused ones
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{"de-AT,de;q=0.9,en;q=0.8", []string{"de-AT", "de", "en"}},
+		{"en;q=0.5, ja", []string{"ja", "en"}},
+		{"fr;q=0, *;q=0.1, de", []string{"de"}},
+		{"", []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseAcceptLanguage(%q): expected %v, got %v", tt.header, tt.expected, got)
+		}
+	}
+}
+
+// TestChain tests resolving requested tags to supported locales ending with the fallback
+func TestChain(t *testing.T) {
+	supported := []string{"en", "de", "de-AT", "ja"}
+	tests := []struct {
+		requested []string
+		expected  []string
+	}{
+		{[]string{"de-AT"}, []string{"de-AT", "de", "en"}},
+		{[]string{"de-CH", "ja"}, []string{"de", "ja", "en"}},
+		{[]string{"fr"}, []string{"en"}},
+		{[]string{"en-GB", "ja"}, []string{"en"}},
+		{nil, []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		if got := Chain(tt.requested, supported, "en"); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Chain(%v): expected %v, got %v", tt.requested, tt.expected, got)
+		}
+	}
+}
+
+// TestParseList tests parsing configured locale lists
+func TestParseList(t *testing.T) {
+	if got := ParseList("en, DE,ja,,de"); !reflect.DeepEqual(got, []string{"en", "de", "ja"}) {
+		t.Errorf("Expected [en de ja], got %v", got)
+	}
+}
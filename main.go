@@ -1,10 +1,24 @@
 package main
 
 import (
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
 	"gofr.dev/pkg/gofr"
 
+	"gofr-blog-service/cache"
+	"gofr-blog-service/gql"
 	"gofr-blog-service/handlers"
+	"gofr-blog-service/i18n"
+	"gofr-blog-service/middleware"
 	"gofr-blog-service/migrations"
+	"gofr-blog-service/models"
+	"gofr-blog-service/proto/blogpb"
+	"gofr-blog-service/ratelimit"
 	"gofr-blog-service/services"
 	"gofr-blog-service/store"
 )
@@ -15,14 +29,219 @@ func main() {
 	// Add database migrations from migrations package
 	app.Migrate(migrations.All())
 
+	// Expose request and response headers to handlers
+	app.UseMiddleware(middleware.Headers)
+
+	// Believe the forwarding headers of the reverse proxies in TRUSTED_PROXIES only
+	trustedProxies, err := middleware.ParseTrustedProxies(app.Config.Get("TRUSTED_PROXIES"))
+	if err != nil {
+		app.Logger().Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	app.UseMiddleware(middleware.TrustedProxies(trustedProxies))
+
+	// Compress responses of at least COMPRESS_MIN_BYTES with brotli or gzip
+	app.UseMiddleware(middleware.Compress(configInt(app, "COMPRESS_MIN_BYTES", middleware.DefaultCompressMinSize)))
+
+	// Keep PATCH bodies, whose patch media types GoFr's Bind does not decode
+	app.UseMiddleware(middleware.RawBody)
+
+	// Keep WordPress exports uploaded for import, of up to IMPORT_MAX_MB
+	app.UseMiddleware(middleware.RawBodyFor(http.MethodPost, "/admin/imports/wordpress",
+		configInt(app, "IMPORT_MAX_MB", 64)<<20))
+
+	// Keep backup archives uploaded for restore, of up to BACKUP_MAX_MB
+	app.UseMiddleware(middleware.RawBodyFor(http.MethodPost, "/admin/restore", configInt(app, "BACKUP_MAX_MB", 256)<<20))
+
+	// Let the static site export and backups write their zip as they read the posts
+	app.UseMiddleware(middleware.Streaming(http.MethodGet, "/export"))
+	app.UseMiddleware(middleware.Streaming(http.MethodGet, "/admin/backup"))
+
 	// Initialize store (new layer)
 	postStore := store.NewPostStore()
 
+	// Domain events go to configurable topics on the app's pub/sub backend
+	topics := services.DefaultEventTopics()
+	for eventType, topic := range map[string]string{
+		models.PostCreated:   app.Config.Get("POST_CREATED_TOPIC"),
+		models.PostUpdated:   app.Config.Get("POST_UPDATED_TOPIC"),
+		models.PostPublished: app.Config.Get("POST_PUBLISHED_TOPIC"),
+		models.PostDeleted:   app.Config.Get("POST_DELETED_TOPIC"),
+	} {
+		if topic != "" {
+			topics[eventType] = topic
+		}
+	}
+
+	// Route post reads to read replicas, e.g. DB_REPLICA_HOSTS=replica-1:5432,replica-2:5432
+	if dsns := replicaDSNs(app); len(dsns) > 0 {
+		replicas, err := store.OpenReplicas(dsns, store.ReplicaConfig{
+			MaxOpenConns: configInt(app, "DB_MAX_OPEN_CONNECTION", 0),
+			MaxIdleConns: configInt(app, "DB_MAX_IDLE_CONNECTION", 0),
+		}, app.Logger())
+		if err != nil {
+			app.Logger().Fatalf("Failed to open read replicas: %v", err)
+		}
+		postStore.SetReplicas(replicas)
+		app.AddCronJob(app.Config.GetOrDefault("DB_REPLICA_HEALTH_SCHEDULE", "*/10 * * * * *"), "replica-health",
+			replicas.CheckHealth)
+	}
+
 	// Initialize services with store dependency
-	postService := services.NewPostService(postStore)
+	outboxStore := store.NewOutboxStore()
+	webhookStore := store.NewWebhookStore()
+	auditStore := store.NewAuditStore()
+	postService := services.NewPostService(postStore, outboxStore, webhookStore, auditStore,
+		services.NewPostEvents(topics))
+
+	// Transaction isolation per post mutation, e.g. TX_ISOLATION_BULK=serializable
+	for _, operation := range []string{
+		services.OpCreatePost, services.OpUpdatePost, services.OpPatchPost, services.OpDeletePost, services.OpBulkPosts,
+	} {
+		isolation, err := store.ParseIsolation(app.Config.Get("TX_ISOLATION_" + strings.ToUpper(operation)))
+		if err != nil {
+			app.Logger().Fatalf("Invalid transaction isolation for %s posts: %v", operation, err)
+		}
+		postService.SetTxOptions(operation, store.TxOptions{Isolation: isolation})
+	}
+
+	// Reads go to the primary for a short window after a client's mutation
+	postService.SetReadYourWrites(time.Duration(configInt(app, "READ_YOUR_WRITES_SECONDS", 5)) * time.Second)
+
+	// Read posts through Redis, or an in-process cache when REDIS_HOST is not set
+	appCache := cache.NewRedis(cache.NewMemory(configInt(app, "CACHE_MEMORY_ENTRIES", cache.DefaultMemoryEntries)))
+	postService.SetCache(services.NewPostCache(appCache,
+		time.Duration(configInt(app, "CACHE_TTL_SECONDS", 300))*time.Second))
+
+	// Committed post events are streamed to gRPC, SSE and WebSocket watchers; the last
+	// POST_WATCH_HISTORY events are kept for Last-Event-ID resumption
+	postWatch := services.NewPostWatch(configInt(app, "POST_WATCH_BUFFER", services.DefaultWatchBuffer),
+		configInt(app, "POST_WATCH_HISTORY", services.DefaultWatchHistory))
+	postService.SetWatch(postWatch)
+
+	// GET /posts/stream with Accept: text/event-stream streams past GoFr's responder once the
+	// route has authenticated and rate limited it
+	streamHandler := handlers.NewStreamHandler(postWatch)
+	app.UseMiddleware(middleware.EventStream("/posts/stream", http.HandlerFunc(streamHandler.ServeSSE)))
+
+	// Relay outbox events to the pub/sub backend
+	outboxRelay := services.NewOutboxRelay(outboxStore, services.OutboxRelayConfig{
+		BatchSize:   configInt(app, "OUTBOX_BATCH_SIZE", 100),
+		MaxAttempts: configInt(app, "OUTBOX_MAX_ATTEMPTS", 10),
+		BaseBackoff: time.Second,
+		Lease:       time.Duration(configInt(app, "OUTBOX_LEASE_SECONDS", 60)) * time.Second,
+	})
+	app.AddCronJob(app.Config.GetOrDefault("OUTBOX_RELAY_SCHEDULE", "*/5 * * * * *"), "outbox-relay", outboxRelay.Run)
+
+	// Deliver signed webhooks with retries, never to internal addresses unless
+	// WEBHOOK_ALLOW_PRIVATE_URLS is set for local development
+	allowPrivateWebhooks, _ := strconv.ParseBool(app.Config.Get("WEBHOOK_ALLOW_PRIVATE_URLS"))
+	webhookSender := services.NewWebhookSender(
+		time.Duration(configInt(app, "WEBHOOK_TIMEOUT_SECONDS", 10))*time.Second, allowPrivateWebhooks)
+	webhookService := services.NewWebhookService(webhookStore, webhookSender)
+	webhookDispatcher := services.NewWebhookDispatcher(webhookStore, webhookSender,
+		services.WebhookDispatcherConfig{
+			BatchSize:   configInt(app, "WEBHOOK_BATCH_SIZE", 20),
+			MaxAttempts: configInt(app, "WEBHOOK_MAX_ATTEMPTS", 8),
+			BaseBackoff: 10 * time.Second,
+			Lease:       time.Duration(configInt(app, "WEBHOOK_LEASE_SECONDS", 300)) * time.Second,
+		})
+	app.AddCronJob(app.Config.GetOrDefault("WEBHOOK_DISPATCH_SCHEDULE", "*/5 * * * * *"), "webhook-dispatcher",
+		webhookDispatcher.Run)
+
+	// Purge audit entries past the retention period
+	auditService := services.NewAuditService(auditStore,
+		time.Duration(configInt(app, "AUDIT_RETENTION_DAYS", 365))*24*time.Hour)
+	app.AddCronJob(app.Config.GetOrDefault("AUDIT_PURGE_SCHEDULE", "0 30 3 * * *"), "audit-purge",
+		auditService.PurgeExpired)
+
+	// Replay responses of retried POST /posts requests carrying an Idempotency-Key
+	idempotencyService := services.NewIdempotencyService(store.NewIdempotencyStore(),
+		time.Duration(configInt(app, "IDEMPOTENCY_TTL_HOURS", 24))*time.Hour)
+	app.AddCronJob(app.Config.GetOrDefault("IDEMPOTENCY_PURGE_SCHEDULE", "0 0 * * * *"), "idempotency-purge",
+		idempotencyService.PurgeExpired)
+
+	// Post translations into SITE_LOCALES; the first locale is the one posts are written in
+	translationService := services.NewTranslationService(store.NewTranslationStore(), postService,
+		i18n.ParseList(app.Config.GetOrDefault("SITE_LOCALES", services.DefaultLocale)))
+
+	// Post tags, listed in feed items and selecting per-tag feeds
+	tagService := services.NewTagService(store.NewTagStore(), postService)
+	postService.SetTags(tagService)
+
+	// Public site settings used for canonical links and structured data
+	site := services.SiteConfig{
+		BaseURL: app.Config.GetOrDefault("SITE_URL", "http://localhost:8000"),
+		Name:    app.Config.GetOrDefault("SITE_NAME", "GoFr Blog"),
+
+		PermalinkPattern: app.Config.GetOrDefault("PERMALINK_PATTERN", services.DefaultPermalinkPattern),
+		Locale:           translationService.DefaultLocale(),
+	}
+
+	seoService := services.NewSEOService(postService, site)
+	feedService := services.NewFeedService(postStore, site)
+	feedService.SetTranslations(translationService)
+	feedService.SetTags(tagService)
+	sitemapService := services.NewSitemapService(postStore, site)
+	sitemapService.SetTranslations(translationService)
 
 	// Initialize handlers
-	postHandler := handlers.NewPostHandler(postService)
+	postHandler := handlers.NewPostHandler(postService, seoService, idempotencyService)
+	postHandler.SetTranslations(translationService)
+	postHandler.SetTags(tagService)
+	feedHandler := handlers.NewFeedHandler(feedService)
+	sitemapHandler := handlers.NewSitemapHandler(sitemapService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+
+	// WordPress imports run in batches of IMPORT_BATCH_SIZE posts, resuming unfinished jobs
+	importService := services.NewImportService(store.NewImportStore(), postService, postHandler.CreateValidator(),
+		services.ImportConfig{BatchSize: configInt(app, "IMPORT_BATCH_SIZE", services.DefaultImportBatchSize)})
+	importService.SetTags(tagService)
+	app.AddCronJob(app.Config.GetOrDefault("IMPORT_SCHEDULE", "*/10 * * * * *"), "import-batches", importService.Run)
+	importHandler := handlers.NewImportHandler(importService)
+	exportService := services.NewExportService(postStore, site)
+	exportService.SetTags(tagService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	backupService := services.NewBackupService(postService, postHandler.CreateValidator())
+	backupService.SetWebhookSender(webhookSender)
+	backupHandler := handlers.NewBackupHandler(backupService)
+
+	// GraphQL over posts; persisted queries share the post cache
+	graphqlHandler, err := handlers.NewGraphQLHandler(postHandler, appCache, gql.Config{
+		MaxDepth:          configInt(app, "GRAPHQL_MAX_DEPTH", gql.DefaultMaxDepth),
+		MaxComplexity:     configInt(app, "GRAPHQL_MAX_COMPLEXITY", gql.DefaultMaxComplexity),
+		PersistedQueryTTL: time.Duration(configInt(app, "GRAPHQL_PERSISTED_QUERY_TTL_HOURS", 168)) * time.Hour,
+	})
+	if err != nil {
+		app.Logger().Fatalf("Failed to build the GraphQL schema: %v", err)
+	}
+
+	// gRPC BlogService on GRPC_PORT, for internal services
+	app.RegisterService(&blogpb.BlogService_ServiceDesc, handlers.NewBlogServer(postHandler, postWatch))
+
+	// Rate limits per client and route, e.g. RATE_LIMIT_POSTS_CREATE=10/m; "off" disables one.
+	// Callers are authenticated first, so limits apply per principal.
+	limiter := ratelimit.NewLimiter(ratelimit.NewRedis(ratelimit.NewMemory()))
+	parseLimit := func(route, def string) ratelimit.Limit {
+		routeLimit, err := ratelimit.ParseLimit(app.Config.GetOrDefault("RATE_LIMIT_"+strings.ToUpper(route), def))
+		if err != nil {
+			app.Logger().Fatalf("Invalid rate limit for %s: %v", route, err)
+		}
+		return routeLimit
+	}
+
+	// API keys of users, managed with blogctl, and the ADMIN_API_KEY identify callers. Invalid
+	// keys count against RATE_LIMIT_AUTH_FAILURES per client IP, which is refused once it is
+	// used up, so guessing keys cannot run a database lookup per attempt.
+	userService := services.NewUserService(store.NewUserStore(), app.Config.Get("ADMIN_API_KEY"))
+	authFailures := limiter.Failures("auth_failures", parseLimit("auth_failures", "10/m"))
+	authenticate := func(handler gofr.Handler) gofr.Handler {
+		return handlers.Authenticate(userService, authFailures, handler)
+	}
+
+	limit := func(route, def string, handler gofr.Handler) gofr.Handler {
+		return authenticate(limiter.Limit(route, parseLimit(route, def), handler))
+	}
 
 	// Health check
 	app.GET("/health", func(ctx *gofr.Context) (any, error) {
@@ -34,11 +253,99 @@ func main() {
 	})
 
 	// Simplified Post routes
-	app.GET("/posts", postHandler.ListPosts)
-	app.GET("/posts/{id}", postHandler.GetPost)
-	app.POST("/posts", postHandler.CreatePost)
-	app.PUT("/posts/{id}", postHandler.UpdatePost)
-	app.DELETE("/posts/{id}", postHandler.DeletePost)
+	app.GET("/posts", limit("posts_list", "120/m", postHandler.ListPosts))
+	// Live post changes, registered ahead of /posts/{id}: SSE through the EventStream middleware,
+	// buffered events as JSON for other clients, and a WebSocket alternative
+	app.GET("/posts/stream", limit("posts_stream", "60/m", streamHandler.Recent))
+	app.WebSocket("/posts/stream/ws", limit("posts_stream", "60/m", streamHandler.WebSocket))
+	app.GET("/posts/{id}", limit("posts_get", "300/m", postHandler.GetPost))
+	app.GET("/posts/slug/{slug}", limit("posts_get", "300/m", postHandler.GetPostBySlug))
+	app.POST("/posts", limit("posts_create", "10/m", postHandler.CreatePost))
+	app.POST("/posts/bulk", limit("posts_bulk", "5/m", postHandler.BulkPosts))
+	app.PUT("/posts/{id}", limit("posts_update", "30/m", postHandler.UpdatePost))
+	app.PATCH("/posts/{id}", limit("posts_update", "30/m", postHandler.PatchPost))
+	app.DELETE("/posts/{id}", limit("posts_delete", "30/m", postHandler.DeletePost))
+	app.GET("/posts/{id}/seo", limit("posts_get", "300/m", postHandler.GetPostSEO))
+
+	// Post translations and the locales each post is missing
+	app.GET("/posts/{id}/translations", limit("posts_get", "300/m", postHandler.ListTranslations))
+	app.PUT("/posts/{id}/translations/{locale}", limit("posts_update", "30/m", postHandler.PutTranslation))
+	app.DELETE("/posts/{id}/translations/{locale}", limit("posts_update", "30/m", postHandler.DeleteTranslation))
+	app.GET("/posts/{id}/locales", limit("posts_get", "300/m", postHandler.GetPostLocales))
+
+	// Post tags
+	app.GET("/posts/{id}/tags", limit("posts_get", "300/m", postHandler.GetPostTags))
+	app.PUT("/posts/{id}/tags", limit("posts_update", "30/m", postHandler.PutPostTags))
+
+	// Syndication feeds of published posts
+	app.GET("/feed.rss", limit("feeds", "60/m", feedHandler.RSS))
+	app.GET("/feed.atom", limit("feeds", "60/m", feedHandler.Atom))
+	app.GET("/feed.json", limit("feeds", "60/m", feedHandler.JSONFeed))
+
+	// GraphQL queries over GET and POST, mutations over POST only
+	app.GET("/graphql", limit("graphql", "120/m", graphqlHandler.Query))
+	app.POST("/graphql", limit("graphql", "120/m", graphqlHandler.Execute))
+
+	// XML sitemaps of indexable published posts
+	app.GET("/sitemap.xml", sitemapHandler.Sitemap)
+	app.GET("/sitemap-{page:[0-9]+}.xml", sitemapHandler.ChildSitemap)
+
+	// Admin-only webhook subscriptions and delivery logs
+	app.POST("/webhooks", limit("webhooks", "30/m", webhookHandler.CreateWebhook))
+	app.GET("/webhooks", limit("webhooks", "30/m", webhookHandler.ListWebhooks))
+	app.GET("/webhooks/{id}", limit("webhooks", "30/m", webhookHandler.GetWebhook))
+	app.PUT("/webhooks/{id}", limit("webhooks", "30/m", webhookHandler.UpdateWebhook))
+	app.DELETE("/webhooks/{id}", limit("webhooks", "30/m", webhookHandler.DeleteWebhook))
+	app.GET("/webhooks/{id}/deliveries", limit("webhooks", "30/m", webhookHandler.ListDeliveries))
+	app.POST("/webhooks/{id}/deliveries/{delivery_id}/replay", limit("webhooks", "30/m", webhookHandler.ReplayDelivery))
+
+	// Admin-only audit log
+	app.GET("/audit", authenticate(auditHandler.ListAuditEntries))
+
+	// Admin-only imports
+	app.POST("/admin/imports/wordpress", authenticate(importHandler.ImportWordPress))
+	app.GET("/admin/imports/{id}", authenticate(importHandler.GetImport))
+
+	// Admin-only static site export
+	app.GET("/export", limit("export", "5/m", exportHandler.ExportSite))
+
+	// Admin-only backup and restore
+	app.GET("/admin/backup", authenticate(backupHandler.Backup))
+	app.POST("/admin/restore", authenticate(backupHandler.Restore))
 
 	app.Run()
 }
+
+// configInt reads an integer setting, falling back to def when it is missing or invalid
+func configInt(app *gofr.App, key string, def int) int {
+	value, err := strconv.Atoi(app.Config.Get(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// replicaDSNs returns a postgres data source name per DB_REPLICA_HOSTS entry, keyed by host.
+// Replicas use the primary's credentials, database and SSL mode.
+func replicaDSNs(app *gofr.App) map[string]string {
+	dsns := make(map[string]string)
+	for _, host := range strings.Split(app.Config.Get("DB_REPLICA_HOSTS"), ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, app.Config.GetOrDefault("DB_PORT", "5432"))
+		}
+
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(app.Config.Get("DB_USER"), app.Config.Get("DB_PASSWORD")),
+			Host:     host,
+			Path:     "/" + app.Config.Get("DB_NAME"),
+			RawQuery: url.Values{"sslmode": {app.Config.GetOrDefault("DB_SSL_MODE", "disable")}}.Encode(),
+		}
+		dsns[host] = dsn.String()
+	}
+	return dsns
+}
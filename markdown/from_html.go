@@ -0,0 +1,226 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// WordPress shortcodes wrapping or standing in for media, whose inner content is kept
+	shortcodePattern  = regexp.MustCompile(`\[/?(?:caption|gallery|embed|audio|video|playlist)\b[^\]]*\]`)
+	blankLinePattern  = regexp.MustCompile(`\n[ \t]*\n\s*`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	extraLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// paragraphBreak marks the blank lines of text while its whitespace is collapsed
+const paragraphBreak = "\x00"
+
+// FromHTML converts post HTML, such as WordPress content, to the Markdown subset rendered by
+// ToHTML. Blank lines in text separate paragraphs as WordPress's autop does. Elements without a
+// Markdown form keep their text; scripts, styles and forms are dropped.
+func FromHTML(source string) string {
+	source = shortcodePattern.ReplaceAllString(source, "")
+
+	nodes, err := html.ParseFragment(strings.NewReader(source), &html.Node{Type: html.ElementNode, Data: "body",
+		DataAtom: atom.Body})
+	if err != nil {
+		return strings.TrimSpace(source)
+	}
+
+	var out strings.Builder
+	for _, node := range nodes {
+		out.WriteString(convertNode(node))
+	}
+
+	text := strings.ReplaceAll(out.String(), paragraphBreak, "\n\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(extraLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// convertNode returns the Markdown of node and its children
+func convertNode(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		text := blankLinePattern.ReplaceAllString(n.Data, paragraphBreak)
+		return whitespacePattern.ReplaceAllString(text, " ")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Form, atom.Button, atom.Input, atom.Select, atom.Textarea:
+		return ""
+	case atom.Br:
+		return "\n"
+	case atom.Hr:
+		return block("---")
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return block(strings.Repeat("#", level) + " " + inline(children(n)))
+	case atom.Strong, atom.B:
+		return wrap(children(n), "**")
+	case atom.Em, atom.I:
+		return wrap(children(n), "*")
+	case atom.Code:
+		return wrap(textContent(n), "`")
+	case atom.A:
+		text := inline(children(n))
+		href := attr(n, "href")
+		switch {
+		case href == "":
+			return text
+		case text == "":
+			return href
+		}
+		return "[" + text + "](" + href + ")"
+	case atom.Img:
+		if src := attr(n, "src"); src != "" {
+			return "![" + attr(n, "alt") + "](" + src + ")"
+		}
+		return ""
+	case atom.Iframe, atom.Video, atom.Audio:
+		if src := attr(n, "src"); src != "" {
+			return block("[" + src + "](" + src + ")")
+		}
+		return ""
+	case atom.Pre:
+		return block("```" + codeLanguage(n) + "\n" + strings.Trim(textContent(n), "\n") + "\n```")
+	case atom.Blockquote:
+		quoted := strings.TrimSpace(strings.ReplaceAll(children(n), paragraphBreak, "\n\n"))
+		lines := strings.Split(extraLinesPattern.ReplaceAllString(quoted, "\n\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+strings.TrimSpace(line), " ")
+		}
+		return block(strings.Join(lines, "\n"))
+	case atom.Ul, atom.Ol:
+		return block(list(n, ""))
+	case atom.Tr:
+		var cells []string
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.DataAtom == atom.Td || c.DataAtom == atom.Th {
+				cells = append(cells, inline(children(c)))
+			}
+		}
+		return "\n" + strings.Join(cells, " | ") + "\n"
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Figure, atom.Figcaption,
+		atom.Table, atom.Dl, atom.Dt, atom.Dd:
+		return block(children(n))
+	}
+	return children(n)
+}
+
+// children returns the Markdown of the children of n
+func children(n *html.Node) string {
+	var out strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		out.WriteString(convertNode(c))
+	}
+	return out.String()
+}
+
+// list returns the items of a ul or ol, nested lists indented under their item
+func list(n *html.Node, indent string) string {
+	var out strings.Builder
+	number := 1
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		var text strings.Builder
+		var nested strings.Builder
+		for c := item.FirstChild; c != nil; c = c.NextSibling {
+			if c.DataAtom == atom.Ul || c.DataAtom == atom.Ol {
+				nested.WriteString(list(c, indent+"  "))
+				continue
+			}
+			text.WriteString(convertNode(c))
+		}
+		out.WriteString(indent + marker + inline(text.String()) + "\n" + nested.String())
+	}
+	return out.String()
+}
+
+// block sets Markdown apart as a block
+func block(markdown string) string {
+	return paragraphBreak + strings.TrimSpace(markdown) + paragraphBreak
+}
+
+// inline flattens Markdown to a single line
+func inline(markdown string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(strings.ReplaceAll(markdown, paragraphBreak, " "), " "))
+}
+
+// wrap surrounds inline Markdown with a delimiter, keeping surrounding spaces outside it
+func wrap(markdown, delimiter string) string {
+	text := inline(markdown)
+	if text == "" {
+		return markdown
+	}
+	prefix, suffix := "", ""
+	if strings.HasPrefix(markdown, " ") {
+		prefix = " "
+	}
+	if strings.HasSuffix(markdown, " ") {
+		suffix = " "
+	}
+	return prefix + delimiter + text + delimiter + suffix
+}
+
+// textContent returns the raw text under n
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var out strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom == atom.Br {
+			out.WriteString("\n")
+			continue
+		}
+		out.WriteString(textContent(c))
+	}
+	return out.String()
+}
+
+// codeLanguage returns the language of a pre block from a language-* or lang-* class
+func codeLanguage(pre *html.Node) string {
+	for _, n := range []*html.Node{pre, pre.FirstChild} {
+		if n == nil {
+			continue
+		}
+		for _, class := range strings.Fields(attr(n, "class")) {
+			for _, prefix := range []string{"language-", "lang-"} {
+				if language, ok := strings.CutPrefix(class, prefix); ok {
+					return language
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// attr returns the value of an attribute of n
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package markdown
+
+import (
+	"testing"
+)
+
+// TestFromHTML tests converting post HTML to Markdown
+func TestFromHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"paragraphs", "<p>Hello <strong>big</strong> <em>world</em></p><p>Second</p>", "Hello **big** *world*\n\nSecond"},
+		{"autop", "First line\nstill first\n\nSecond paragraph", "First line still first\n\nSecond paragraph"},
+		{"heading", "<h2>Getting <code>go</code> started</h2>", "## Getting `go` started"},
+		{"link and image", `<a href="https://gofr.dev">GoFr</a> <img src="/a.png" alt="A">`, "[GoFr](https://gofr.dev) ![A](/a.png)"},
+		{"lists", "<ul><li>one<ul><li>nested</li></ul></li><li>two</li></ul><ol><li>first</li></ol>",
+			"- one\n  - nested\n- two\n\n1. first"},
+		{"code block", `<pre><code class="language-go">fmt.Println("&lt;hi&gt;")
+</code></pre>`, "```go\nfmt.Println(\"<hi>\")\n```"},
+		{"blockquote", "<blockquote><p>quoted</p><p>twice</p></blockquote>", "> quoted\n>\n> twice"},
+		{"gutenberg and shortcodes", "<!-- wp:paragraph --><p>[caption id=\"x\"]<img src=\"/b.png\" alt=\"\"> Caption[/caption]</p><!-- /wp:paragraph -->",
+			"![](/b.png) Caption"},
+		{"dropped", "<p>Keep</p><script>alert(1)</script>", "Keep"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromHTML(tt.source); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// frontMatterFence opens and closes a YAML front matter block
+const frontMatterFence = "---"
+
+// SplitFrontMatter separates the YAML front matter of a Markdown document from its body.
+// Only flat "key: value" pairs are read; quoted values are unquoted and comments and blank
+// lines are skipped. A document without front matter is returned whole as the body.
+func SplitFrontMatter(source string) (map[string]string, string) {
+	source = strings.ReplaceAll(strings.TrimPrefix(source, "\ufeff"), "\r\n", "\n")
+	if !strings.HasPrefix(source, frontMatterFence+"\n") {
+		return map[string]string{}, source
+	}
+
+	block, body, found := strings.Cut(source[len(frontMatterFence)+1:], "\n"+frontMatterFence)
+	if !found {
+		return map[string]string{}, source
+	}
+	if rest, ok := strings.CutPrefix(body, "\n"); ok || body == "" {
+		body = rest
+	} else {
+		// The fence must be a line of its own
+		return map[string]string{}, source
+	}
+
+	fields := make(map[string]string)
+	for _, line := range strings.Split(block, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+	return fields, strings.TrimLeft(body, "\n")
+}
+
+// unquote strips the double or single quotes around a YAML scalar
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
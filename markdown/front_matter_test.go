@@ -0,0 +1,24 @@
+package markdown
+
+import (
+	"testing"
+)
+
+// TestSplitFrontMatter tests reading flat front matter and separating the body
+func TestSplitFrontMatter(t *testing.T) {
+	source := "---\r\ntitle: \"Hello: \\\"World\\\"\"\r\nslug: 'it''s-here'\r\n# comment\r\nauthor_id: 7\r\n---\r\n\r\n# Body\r\n"
+
+	fields, body := SplitFrontMatter(source)
+	if fields["title"] != `Hello: "World"` || fields["slug"] != "it's-here" || fields["author_id"] != "7" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+	if body != "# Body\n" {
+		t.Errorf("Expected the body after the front matter, got %q", body)
+	}
+
+	for _, plain := range []string{"# No front matter\n", "---\ntitle: unclosed\n", "---\n"} {
+		if fields, body = SplitFrontMatter(plain); len(fields) != 0 || body != plain {
+			t.Errorf("%q: expected no front matter, got %v and %q", plain, fields, body)
+		}
+	}
+}
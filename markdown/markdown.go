@@ -0,0 +1,172 @@
+// Package markdown renders the Markdown subset used in post content to HTML.
+// All text is HTML-escaped, so raw HTML embedded in content is never passed through.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	orderedItemPattern = regexp.MustCompile(`^\d+[.)]\s+`)
+	rulePattern        = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	imagePattern       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	linkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	codeSpanPattern    = regexp.MustCompile("`([^`]+)`")
+	strongPattern      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emphasisPattern    = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// ToHTML renders Markdown source to HTML
+func ToHTML(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	var out strings.Builder
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + renderInline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			i = renderCodeBlock(&out, lines, i)
+		case headingPattern.MatchString(trimmed):
+			flushParagraph()
+			m := headingPattern.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			out.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+		case rulePattern.MatchString(trimmed):
+			flushParagraph()
+			out.WriteString("<hr>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			i = renderBlockquote(&out, lines, i)
+		case isListItem(trimmed):
+			flushParagraph()
+			i = renderList(&out, lines, i)
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+
+	return out.String()
+}
+
+// renderCodeBlock renders a fenced code block starting at lines[start] and returns the last line consumed
+func renderCodeBlock(out *strings.Builder, lines []string, start int) int {
+	language := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[start]), "```"))
+
+	var code []string
+	i := start + 1
+	for ; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+		code = append(code, lines[i])
+	}
+
+	if language != "" {
+		out.WriteString(`<pre><code class="language-` + html.EscapeString(language) + `">`)
+	} else {
+		out.WriteString("<pre><code>")
+	}
+	out.WriteString(html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+	return i
+}
+
+// renderBlockquote renders consecutive quoted lines starting at lines[start] and returns the last line consumed
+func renderBlockquote(out *strings.Builder, lines []string, start int) int {
+	var quoted []string
+	i := start
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+		quoted = append(quoted, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")))
+	}
+
+	out.WriteString("<blockquote>\n" + ToHTML(strings.Join(quoted, "\n")) + "</blockquote>\n")
+
+	return i - 1
+}
+
+// renderList renders consecutive list items starting at lines[start] and returns the last line consumed
+func renderList(out *strings.Builder, lines []string, start int) int {
+	tag := "ul"
+	if orderedItemPattern.MatchString(strings.TrimSpace(lines[start])) {
+		tag = "ol"
+	}
+
+	out.WriteString("<" + tag + ">\n")
+	i := start
+	for ; i < len(lines) && isListItem(strings.TrimSpace(lines[i])); i++ {
+		out.WriteString("<li>" + renderInline(stripListMarker(strings.TrimSpace(lines[i]))) + "</li>\n")
+	}
+	out.WriteString("</" + tag + ">\n")
+
+	return i - 1
+}
+
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") ||
+		strings.HasPrefix(line, "+ ") || orderedItemPattern.MatchString(line)
+}
+
+func stripListMarker(line string) string {
+	if loc := orderedItemPattern.FindStringIndex(line); loc != nil {
+		return line[loc[1]:]
+	}
+	return strings.TrimSpace(line[2:])
+}
+
+// renderInline escapes text and renders code spans, images, links and emphasis
+func renderInline(text string) string {
+	// Code spans are rendered first and swapped for placeholders so their contents stay literal
+	var spans []string
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "<code>"+html.EscapeString(match[1:len(match)-1])+"</code>")
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	})
+
+	text = html.EscapeString(text)
+
+	text = imagePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := imagePattern.FindStringSubmatch(match)
+		if !isSafeURL(m[2]) {
+			return m[1]
+		}
+		return `<img src="` + m[2] + `" alt="` + m[1] + `">`
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := linkPattern.FindStringSubmatch(match)
+		if !isSafeURL(m[2]) {
+			return m[1]
+		}
+		return `<a href="` + m[2] + `">` + m[1] + `</a>`
+	})
+	text = strongPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emphasisPattern.ReplaceAllString(text, "<em>$1$2</em>")
+
+	for i, span := range spans {
+		text = strings.Replace(text, "\x00"+strconv.Itoa(i)+"\x00", span, 1)
+	}
+
+	return text
+}
+
+// isSafeURL rejects script-capable URL schemes in links and images
+func isSafeURL(escapedURL string) bool {
+	u := strings.ToLower(html.UnescapeString(escapedURL))
+	return !strings.HasPrefix(u, "javascript:") && !strings.HasPrefix(u, "vbscript:") &&
+		!strings.HasPrefix(u, "data:")
+}
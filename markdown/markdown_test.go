@@ -0,0 +1,33 @@
+package markdown
+
+import (
+	"testing"
+)
+
+// TestToHTML tests rendering of the supported Markdown blocks and inlines
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"heading", "## Getting *started*", "<h2>Getting <em>started</em></h2>\n"},
+		{"paragraph", "Hello **GoFr**\nand `go run`.", "<p>Hello <strong>GoFr</strong> and <code>go run</code>.</p>\n"},
+		{"link", "See [docs](https://gofr.dev)", "<p>See <a href=\"https://gofr.dev\">docs</a></p>\n"},
+		{"unsafe link", "[click](javascript:void)", "<p>click</p>\n"},
+		{"escaping", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"list", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"},
+		{"ordered list", "1. one\n2. two", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>\n"},
+		{"code block", "```go\nfmt.Println(\"<hi>\")\n```", "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)</code></pre>\n"},
+		{"blockquote", "> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>\n"},
+		{"rule", "---", "<hr>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.source); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
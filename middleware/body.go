@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// maxRawBodySize caps the request bodies kept by RawBody
+const maxRawBodySize = 1 << 20
+
+// RawBody keeps the body of PATCH requests in the request context.
+// GoFr's Bind only decodes the media types it knows, so patch documents
+// (application/merge-patch+json, application/json-patch+json) are read from here instead.
+func RawBody(next http.Handler) http.Handler {
+	return keepBody(next, maxRawBodySize, func(r *http.Request) bool { return r.Method == http.MethodPatch })
+}
+
+// RawBodyFor keeps the bodies of method requests to path, of up to maxSize bytes, in the request
+// context, for uploads larger than RawBody allows or in formats Bind does not decode
+func RawBodyFor(method, path string, maxSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return keepBody(next, maxSize, func(r *http.Request) bool { return r.Method == method && r.URL.Path == path })
+	}
+}
+
+// keepBody keeps the bodies of the requests matched by match, rejecting those over maxSize bytes
+func keepBody(next http.Handler, maxSize int, match func(r *http.Request) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !match(r) || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, int64(maxSize)+1))
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxSize {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), rawBodyKey, body)))
+	})
+}
+
+// Body returns the request body kept by RawBody and whether there is one
+func Body(ctx context.Context) ([]byte, bool) {
+	body, ok := ctx.Value(rawBodyKey).([]byte)
+	return body, ok
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRawBodyFor tests that only matching requests keep their bodies, up to the size limit
+func TestRawBodyFor(t *testing.T) {
+	tests := []struct {
+		method, path, body string
+		expectedStatus     int
+		expectedBody       string
+	}{
+		{http.MethodPost, "/imports", "<rss/>", http.StatusOK, "<rss/>"},
+		{http.MethodPost, "/imports", strings.Repeat("x", 11), http.StatusRequestEntityTooLarge, ""},
+		{http.MethodPost, "/other", strings.Repeat("x", 11), http.StatusOK, ""},
+		{http.MethodPut, "/imports", "<rss/>", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		var kept string
+		handler := RawBodyFor(http.MethodPost, "/imports", 10)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			body, _ := Body(r.Context())
+			kept = string(body)
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+		if rec.Code != tt.expectedStatus {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.expectedStatus, rec.Code)
+		}
+		if kept != tt.expectedBody {
+			t.Errorf("%s %s: expected kept body %q, got %q", tt.method, tt.path, tt.expectedBody, kept)
+		}
+	}
+}
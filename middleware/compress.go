@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content codings offered by Compress, in order of preference
+const (
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// DefaultCompressMinSize is the smallest response body worth compressing
+const DefaultCompressMinSize = 1024
+
+var errHijackUnsupported = errors.New("response writer does not support hijacking")
+
+var (
+	gzipWriters   = sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+	brotliWriters = sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, brotli.DefaultCompression) }}
+)
+
+// Compress compresses response bodies of at least minSize bytes with brotli or gzip,
+// negotiated through Accept-Encoding. Smaller bodies, responses that are already encoded,
+// media that are compressed already and upgraded connections are passed through.
+func Compress(minSize int) func(http.Handler) http.Handler {
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := NegotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// NegotiateEncoding picks the preferred content coding accepted by an Accept-Encoding header,
+// or "" for identity. Brotli wins ties with gzip.
+func NegotiateEncoding(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		q := 1.0
+		if name, value, ok := strings.Cut(params, "="); ok && strings.TrimSpace(name) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		qualities[coding] = q
+	}
+
+	quality := func(coding string) float64 {
+		if q, ok := qualities[coding]; ok {
+			return q
+		}
+		return qualities["*"]
+	}
+
+	br, gz := quality(EncodingBrotli), quality(EncodingGzip)
+	switch {
+	case br > 0 && br >= gz:
+		return EncodingBrotli
+	case gz > 0:
+		return EncodingGzip
+	}
+	return ""
+}
+
+// compressWriter buffers the start of a response until it knows whether the body reaches
+// the size threshold, then streams it either compressed or as is
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+// WriteHeader defers the status line until the encoding is decided
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+// Write buffers the body until the size threshold is reached
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	if cw.decided {
+		return cw.write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.minSize {
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush decides the encoding with what has been written so far and flushes it to the client
+func (cw *compressWriter) Flush() {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	if !cw.decided {
+		if err := cw.decide(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hands the connection to the handler, which then writes raw bytes
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errHijackUnsupported
+	}
+	cw.decided = true
+	return hijacker.Hijack()
+}
+
+// Close writes a buffered short body and finishes the compressed stream
+func (cw *compressWriter) Close() {
+	if !cw.decided {
+		if cw.status == 0 {
+			return
+		}
+		_ = cw.decide()
+	}
+	if cw.encoder == nil {
+		return
+	}
+
+	_ = cw.encoder.Close()
+	switch encoder := cw.encoder.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(encoder)
+	case *brotli.Writer:
+		brotliWriters.Put(encoder)
+	}
+	cw.encoder = nil
+}
+
+// decide starts compression when the buffered body is large enough and compressible,
+// then writes the status line and the buffer
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+
+	header := cw.Header()
+	if cw.compressible(header) {
+		header.Add("Vary", "Accept-Encoding")
+		if len(cw.buf) >= cw.minSize {
+			header.Set("Content-Encoding", cw.encoding)
+			header.Del("Content-Length")
+			cw.encoder = cw.newEncoder()
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := cw.write(buf)
+	return err
+}
+
+// compressible reports whether the response may be compressed
+func (cw *compressWriter) compressible(header http.Header) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(cw.buf)
+	}
+	return compressibleType(contentType)
+}
+
+// compressibleType reports whether a media type benefits from compression.
+// Event streams are excluded so each event reaches the client when it is flushed.
+func compressibleType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-ndjson",
+		"application/yaml", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// newEncoder takes a pooled encoder writing to the underlying response
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == EncodingBrotli {
+		encoder := brotliWriters.Get().(*brotli.Writer)
+		encoder.Reset(cw.ResponseWriter)
+		return encoder
+	}
+
+	encoder := gzipWriters.Get().(*gzip.Writer)
+	encoder.Reset(cw.ResponseWriter)
+	return encoder
+}
+
+// write writes to the encoder when compressing and to the response otherwise
+func (cw *compressWriter) write(p []byte) (int, error) {
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+// TestNegotiateEncoding tests content coding negotiation with quality values
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", EncodingGzip},
+		{"gzip, deflate, br", EncodingBrotli},
+		{"br;q=0.5, gzip", EncodingGzip},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", EncodingBrotli},
+		{"*;q=0.1, gzip;q=0.8", EncodingGzip},
+		{"GZIP;q=0.9", EncodingGzip},
+	}
+
+	for _, tt := range tests {
+		if got := NegotiateEncoding(tt.acceptEncoding); got != tt.expected {
+			t.Errorf("NegotiateEncoding(%q): expected %q, got %q", tt.acceptEncoding, tt.expected, got)
+		}
+	}
+}
+
+// TestCompress tests that only large compressible bodies are compressed
+func TestCompress(t *testing.T) {
+	large := `{"posts":"` + strings.Repeat("lorem ipsum ", 200) + `"}`
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		status         int
+		body           string
+		encoding       string
+	}{
+		{"gzip", "gzip", "application/json", http.StatusOK, large, EncodingGzip},
+		{"brotli", "br, gzip", "application/json", http.StatusOK, large, EncodingBrotli},
+		{"below threshold", "gzip", "application/json", http.StatusOK, `{"posts":[]}`, ""},
+		{"not accepted", "", "application/json", http.StatusOK, large, ""},
+		{"incompressible type", "gzip", "image/png", http.StatusOK, large, ""},
+		{"event stream", "gzip", "text/event-stream", http.StatusOK, large, ""},
+		{"not modified", "gzip", "application/json", http.StatusNotModified, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compress(1024)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				// Write in chunks so the threshold is crossed mid-body
+				for i := 0; i < len(tt.body); i += 100 {
+					_, _ = io.WriteString(w, tt.body[i:min(i+100, len(tt.body))])
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Fatalf("Expected Content-Encoding %q, got %q", tt.encoding, got)
+			}
+
+			var reader io.Reader = rec.Body
+			switch tt.encoding {
+			case EncodingGzip:
+				gz, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("Invalid gzip stream: %v", err)
+				}
+				reader = gz
+			case EncodingBrotli:
+				reader = brotli.NewReader(rec.Body)
+			}
+
+			body, err := io.ReadAll(reader)
+			if err != nil || string(body) != tt.body {
+				t.Errorf("Expected the original body back, got %d bytes (%v)", len(body), err)
+			}
+		})
+	}
+}
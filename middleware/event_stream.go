@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// EventStreamMediaType is the media type of Server-Sent Events
+const EventStreamMediaType = "text/event-stream"
+
+// EventStream serves GET requests to path that accept text/event-stream with h, since GoFr's
+// responder writes whole responses and cannot stream. They first go through the GoFr route,
+// which must be registered for path, so its authentication and rate limits apply: h serves
+// them once the route's handler calls AcceptEventStream, and what the responder wrote is
+// discarded. Otherwise the route's response is sent, e.g. a 401 or 429.
+func EventStream(path string, h http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || r.URL.Path != path || !acceptsEventStream(r.Header.Get("Accept")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sw := &streamWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), eventStreamKey, sw)))
+			if sw.taken {
+				h.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// AcceptEventStream hands a request intercepted by EventStream to its event stream handler once
+// the GoFr handler returns. It returns false for any other request.
+func AcceptEventStream(ctx context.Context) bool {
+	sw, ok := ctx.Value(eventStreamKey).(*streamWriter)
+	if !ok {
+		return false
+	}
+	sw.taken = true
+	return true
+}
+
+// acceptsEventStream reports whether an Accept header lists text/event-stream
+func acceptsEventStream(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == EventStreamMediaType {
+			return true
+		}
+	}
+	return false
+}
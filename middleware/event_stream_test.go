@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestEventStream tests that only GETs of the stream path accepting text/event-stream are
+// streamed, and only once the route accepted them
+func TestEventStream(t *testing.T) {
+	stream := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = io.WriteString(w, "data: {}\n\n")
+	})
+	// The GoFr route refuses requests without a key, as authentication or a rate limit would
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Admin-Key") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		AcceptEventStream(r.Context())
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"data":null}`)
+	})
+	handler := EventStream("/posts/stream", stream)(next)
+
+	tests := []struct {
+		method, path, accept, key string
+		expected                  int
+	}{
+		{http.MethodGet, "/posts/stream", "text/event-stream", "key", http.StatusTeapot},
+		{http.MethodGet, "/posts/stream", "application/json, text/event-stream;q=0.9", "key", http.StatusTeapot},
+		{http.MethodGet, "/posts/stream", "text/event-stream", "", http.StatusUnauthorized},
+		{http.MethodGet, "/posts/stream", "application/json", "key", http.StatusOK},
+		{http.MethodGet, "/posts/stream", "", "key", http.StatusOK},
+		{http.MethodPost, "/posts/stream", "text/event-stream", "key", http.StatusOK},
+		{http.MethodGet, "/posts", "text/event-stream", "key", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+		req.Header.Set("Accept", tt.accept)
+		req.Header.Set("X-Admin-Key", tt.key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.expected {
+			t.Errorf("%s %s (Accept %q): expected %d, got %d", tt.method, tt.path, tt.accept, tt.expected, rec.Code)
+		}
+		if rec.Code == http.StatusTeapot && rec.Body.String() != "data: {}\n\n" {
+			t.Errorf("%s %s (Accept %q): expected only the stream, got %q", tt.method, tt.path, tt.accept, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Package middleware contains HTTP middleware registered on the GoFr app.
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+)
+
+type contextKey int
+
+const (
+	requestHeaderKey contextKey = iota
+	responseHeaderKey
+	remoteAddrKey
+	rawBodyKey
+	streamKey
+	clientIPKey
+	principalKey
+	eventStreamKey
+)
+
+// RequestIDHeader carries the id correlating a request across logs, audit entries and responses
+const RequestIDHeader = "X-Request-ID"
+
+// Headers exposes the incoming request headers and the outgoing response headers
+// to GoFr handlers through the request context. Requests without an X-Request-ID
+// are given one, and the id is echoed on the response.
+func Headers(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(RequestIDHeader) == "" {
+			r.Header.Set(RequestIDHeader, newRequestID())
+		}
+		w.Header().Set(RequestIDHeader, r.Header.Get(RequestIDHeader))
+
+		ctx := context.WithValue(r.Context(), requestHeaderKey, r.Header)
+		ctx = context.WithValue(ctx, responseHeaderKey, w.Header())
+		ctx = context.WithValue(ctx, remoteAddrKey, r.RemoteAddr)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithRequestHeaders returns ctx carrying header as the incoming request headers and remoteAddr
+// as the client address, for calls that do not pass through Headers such as gRPC calls
+func WithRequestHeaders(ctx context.Context, header http.Header, remoteAddr string) context.Context {
+	ctx = context.WithValue(ctx, requestHeaderKey, header)
+	return context.WithValue(ctx, remoteAddrKey, remoteAddr)
+}
+
+// RequestHeader returns the value of an incoming request header, or "" when it is absent
+func RequestHeader(ctx context.Context, key string) string {
+	header, ok := ctx.Value(requestHeaderKey).(http.Header)
+	if !ok {
+		return ""
+	}
+	return header.Get(key)
+}
+
+// SetResponseHeader sets a header on the outgoing response.
+// It is a no-op when the Headers middleware is not installed.
+func SetResponseHeader(ctx context.Context, key, value string) {
+	header, ok := ctx.Value(responseHeaderKey).(http.Header)
+	if !ok {
+		return
+	}
+	header.Set(key, value)
+}
+
+// RequestID returns the id of the current request, or "" outside an HTTP request
+func RequestID(ctx context.Context) string {
+	return RequestHeader(ctx, RequestIDHeader)
+}
+
+// ClientIP returns the address of the client: the one TrustedProxies resolved from the
+// forwarding headers of a trusted proxy, or else the connection's remote address
+func ClientIP(ctx context.Context) string {
+	if clientIP, ok := ctx.Value(clientIPKey).(string); ok && clientIP != "" {
+		return clientIP
+	}
+
+	remoteAddr, _ := ctx.Value(remoteAddrKey).(string)
+	return remoteHost(remoteAddr)
+}
+
+// remoteHost returns the host of a host:port remote address
+func remoteHost(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
+
+// newRequestID returns a random 128-bit hex id
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"context"
+
+	"gofr-blog-service/models"
+)
+
+// WithPrincipal returns ctx carrying principal as the authenticated caller
+func WithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// Principal returns the authenticated caller of ctx, or nil for anonymous requests
+func Principal(ctx context.Context) *models.Principal {
+	principal, _ := ctx.Value(principalKey).(*models.Principal)
+	return principal
+}
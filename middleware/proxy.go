@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+var errInvalidProxy = errors.New("invalid trusted proxy, use an IP address or CIDR range")
+
+// ParseTrustedProxies parses a comma-separated list of IP addresses and CIDR ranges, such as
+// "10.0.0.0/8,127.0.0.1"
+func ParseTrustedProxies(list string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, errors.Join(errInvalidProxy, errors.New(entry))
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, errors.Join(errInvalidProxy, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// TrustedProxies resolves the client address of requests relayed by proxies for ClientIP.
+// X-Forwarded-For and X-Real-IP are only read when the connection comes from one of the
+// proxies, and X-Forwarded-For is walked from the right, skipping proxy hops, so the address
+// is the last one a trusted proxy saw rather than one the client wrote. Without proxies the
+// forwarding headers are ignored.
+func TrustedProxies(proxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientIP := forwardedClientIP(r.Header, remoteHost(r.RemoteAddr), proxies)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey, clientIP)))
+		})
+	}
+}
+
+// forwardedClientIP returns the client address of a request from peer: the right-most
+// X-Forwarded-For hop that is not a trusted proxy, X-Real-IP when a trusted proxy sent no
+// X-Forwarded-For, or peer itself when it is not trusted
+func forwardedClientIP(header http.Header, peer string, proxies []*net.IPNet) string {
+	if !trusted(peer, proxies) {
+		return peer
+	}
+
+	var hops []string
+	for _, value := range header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	if len(hops) == 0 {
+		if realIP := strings.TrimSpace(header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+			return realIP
+		}
+		return peer
+	}
+
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// A trusted proxy forwarded a malformed hop; the last proxy before it is all that is known
+			break
+		}
+		client = hop
+		if !trusted(hop, proxies) {
+			break
+		}
+	}
+	return client
+}
+
+// trusted reports whether address is within one of proxies
+func trusted(address string, proxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestParseTrustedProxies tests parsing addresses and CIDR ranges of proxies
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 127.0.0.1,,::1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(proxies) != 3 {
+		t.Fatalf("Expected 3 proxies, got %d", len(proxies))
+	}
+	if !trusted("10.1.2.3", proxies) || !trusted("127.0.0.1", proxies) || trusted("127.0.0.2", proxies) {
+		t.Errorf("Unexpected proxy ranges %v", proxies)
+	}
+
+	if _, err = ParseTrustedProxies("10.0.0.0/8,proxy.local"); err == nil {
+		t.Error("Expected an error for a host name")
+	}
+}
+
+// TestForwardedClientIP tests which forwarding headers are believed, and from which peers
+func TestForwardedClientIP(t *testing.T) {
+	proxies, _ := ParseTrustedProxies("10.0.0.0/8")
+
+	tests := []struct {
+		name     string
+		peer     string
+		header   http.Header
+		expected string
+	}{
+		{"untrusted peer", "203.0.113.9", http.Header{"X-Forwarded-For": {"198.51.100.1"}}, "203.0.113.9"},
+		{"untrusted peer real ip", "203.0.113.9", http.Header{"X-Real-Ip": {"198.51.100.1"}}, "203.0.113.9"},
+		{"trusted peer", "10.0.0.2", http.Header{"X-Forwarded-For": {"198.51.100.1"}}, "198.51.100.1"},
+		{"spoofed hop", "10.0.0.2", http.Header{"X-Forwarded-For": {"1.2.3.4, 198.51.100.1"}}, "198.51.100.1"},
+		{"proxy chain", "10.0.0.2", http.Header{"X-Forwarded-For": {"198.51.100.1, 10.0.0.7"}}, "198.51.100.1"},
+		{"split header", "10.0.0.2", http.Header{"X-Forwarded-For": {"1.2.3.4", "198.51.100.1"}}, "198.51.100.1"},
+		{"all proxies", "10.0.0.2", http.Header{"X-Forwarded-For": {"10.0.0.9, 10.0.0.7"}}, "10.0.0.9"},
+		{"malformed hop", "10.0.0.2", http.Header{"X-Forwarded-For": {"unknown, 10.0.0.7"}}, "10.0.0.7"},
+		{"real ip", "10.0.0.2", http.Header{"X-Real-Ip": {"198.51.100.1"}}, "198.51.100.1"},
+		{"no headers", "10.0.0.2", http.Header{}, "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := forwardedClientIP(tt.header, tt.peer, proxies); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
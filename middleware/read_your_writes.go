@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Read-your-writes signals.
+// ReadPrimaryHeader asks for a single request to read from the primary database; the cookie
+// set by MarkWrite does so for every request of the client until it expires.
+const (
+	ReadPrimaryHeader    = "X-Read-Primary"
+	ReadYourWritesCookie = "read_your_writes"
+)
+
+// MarkWrite asks the client to read from the primary for window after a successful write.
+// The cookie holds the deadline in Unix milliseconds. Other cookies of the response are kept,
+// and a later write of the same request replaces the cookie of an earlier one.
+func MarkWrite(ctx context.Context, window time.Duration, now time.Time) {
+	if window <= 0 {
+		return
+	}
+
+	header, ok := ctx.Value(responseHeaderKey).(http.Header)
+	if !ok {
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     ReadYourWritesCookie,
+		Value:    strconv.FormatInt(now.Add(window).UnixMilli(), 10),
+		Path:     "/",
+		MaxAge:   int((window + time.Second - 1) / time.Second),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	previous := header.Values("Set-Cookie")
+	header.Del("Set-Cookie")
+	for _, value := range previous {
+		if !strings.HasPrefix(value, ReadYourWritesCookie+"=") {
+			header.Add("Set-Cookie", value)
+		}
+	}
+	header.Add("Set-Cookie", cookie.String())
+}
+
+// ReadPrimary reports whether the request asked to read from the primary, with the
+// X-Read-Primary header or a cookie from MarkWrite whose deadline has not passed.
+// Deadlines further than window ahead are ignored.
+func ReadPrimary(ctx context.Context, window time.Duration, now time.Time) bool {
+	if readPrimary, err := strconv.ParseBool(RequestHeader(ctx, ReadPrimaryHeader)); err == nil && readPrimary {
+		return true
+	}
+
+	header, ok := ctx.Value(requestHeaderKey).(http.Header)
+	if !ok {
+		return false
+	}
+
+	cookie, err := (&http.Request{Header: header}).Cookie(ReadYourWritesCookie)
+	if err != nil {
+		return false
+	}
+
+	deadline, err := strconv.ParseInt(strings.TrimSpace(cookie.Value), 10, 64)
+	if err != nil {
+		return false
+	}
+
+	until := time.UnixMilli(deadline)
+	return now.Before(until) && !until.After(now.Add(window))
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestReadPrimary tests the header and cookie signals that send reads to the primary
+func TestReadPrimary(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	window := 5 * time.Second
+	cookie := func(deadline time.Time) string {
+		return ReadYourWritesCookie + "=" + strconv.FormatInt(deadline.UnixMilli(), 10)
+	}
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		expected bool
+	}{
+		{"no signal", http.Header{}, false},
+		{"header", http.Header{ReadPrimaryHeader: {"true"}}, true},
+		{"header false", http.Header{ReadPrimaryHeader: {"false"}}, false},
+		{"cookie in window", http.Header{"Cookie": {cookie(now.Add(2 * time.Second))}}, true},
+		{"cookie expired", http.Header{"Cookie": {cookie(now.Add(-time.Second))}}, false},
+		{"cookie beyond window", http.Header{"Cookie": {cookie(now.Add(time.Hour))}}, false},
+		{"cookie malformed", http.Header{"Cookie": {ReadYourWritesCookie + "=soon"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), requestHeaderKey, tt.header)
+			if got := ReadPrimary(ctx, window, now); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestMarkWrite tests that the cookie set after a write is honored by ReadPrimary
+func TestMarkWrite(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	response := http.Header{}
+	ctx := context.WithValue(context.Background(), responseHeaderKey, response)
+
+	MarkWrite(ctx, 5*time.Second, now)
+
+	setCookie := response.Get("Set-Cookie")
+	if setCookie == "" {
+		t.Fatal("Expected a Set-Cookie header")
+	}
+
+	cookies := (&http.Response{Header: http.Header{"Set-Cookie": {setCookie}}}).Cookies()
+	request := http.Header{}
+	for _, c := range cookies {
+		request.Add("Cookie", c.Name+"="+c.Value)
+	}
+	ctx = context.WithValue(context.Background(), requestHeaderKey, request)
+
+	if !ReadPrimary(ctx, 5*time.Second, now.Add(time.Second)) {
+		t.Error("Expected reads inside the window to go to the primary")
+	}
+	if ReadPrimary(ctx, 5*time.Second, now.Add(6*time.Second)) {
+		t.Error("Expected reads after the window to go to replicas")
+	}
+}
+
+// TestMarkWrite_KeepsOtherCookies tests that the cookie is added beside other cookies of the
+// response and replaced, not repeated, by a second write
+func TestMarkWrite_KeepsOtherCookies(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	response := http.Header{"Set-Cookie": {"session=abc; Path=/"}}
+	ctx := context.WithValue(context.Background(), responseHeaderKey, response)
+
+	MarkWrite(ctx, 5*time.Second, now)
+	MarkWrite(ctx, 5*time.Second, now.Add(time.Second))
+
+	cookies := (&http.Response{Header: response}).Cookies()
+	if len(cookies) != 2 || cookies[0].Name != "session" || cookies[1].Name != ReadYourWritesCookie {
+		t.Fatalf("Expected the session cookie and one read-your-writes cookie, got %v", response.Values("Set-Cookie"))
+	}
+	if expected := strconv.FormatInt(now.Add(6*time.Second).UnixMilli(), 10); cookies[1].Value != expected {
+		t.Errorf("Expected the deadline of the later write %s, got %s", expected, cookies[1].Value)
+	}
+}
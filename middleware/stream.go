@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// Streaming lets the GoFr handler of method requests to path write the response body itself
+// through StreamResponse, for bodies too large to hold in memory, since GoFr's responder
+// writes whole responses. Once the handler has taken the response, whatever the responder
+// writes after it is discarded.
+func Streaming(method, path string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != method || r.URL.Path != path {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sw := &streamWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), streamKey, sw)))
+		})
+	}
+}
+
+// StreamResponse takes over the response of a request handled by Streaming: it writes status
+// with the response headers and header, and returns the writer of the body. It returns false
+// outside such a request or when the response was already taken.
+func StreamResponse(ctx context.Context, status int, header http.Header) (io.Writer, bool) {
+	sw, ok := ctx.Value(streamKey).(*streamWriter)
+	if !ok || sw.taken {
+		return nil, false
+	}
+
+	for key, values := range header {
+		sw.ResponseWriter.Header()[key] = values
+	}
+	sw.ResponseWriter.WriteHeader(status)
+	sw.taken = true
+	return sw.ResponseWriter, true
+}
+
+// streamWriter discards the responder's writes once the handler has taken the response
+type streamWriter struct {
+	http.ResponseWriter
+	taken bool
+}
+
+// WriteHeader writes the status line unless the handler took the response
+func (sw *streamWriter) WriteHeader(status int) {
+	if !sw.taken {
+		sw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// Write writes the body unless the handler took the response
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	if sw.taken {
+		return len(p), nil
+	}
+	return sw.ResponseWriter.Write(p)
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestStreaming tests that a taken response keeps the handler's body and drops the responder's
+func TestStreaming(t *testing.T) {
+	handler := Streaming(http.MethodGet, "/export")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body, ok := StreamResponse(r.Context(), http.StatusOK, http.Header{"Content-Type": {"application/zip"}}); ok {
+			_, _ = io.WriteString(body, "PK")
+		}
+		// GoFr's responder writes after the handler returns
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"data":null}`)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/export", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "PK" || rec.Result().Header.Get("Content-Type") != "application/zip" {
+		t.Errorf("expected the streamed zip, got %d %q %q", rec.Code, rec.Result().Header.Get("Content-Type"), rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts", nil))
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"data":null}` {
+		t.Errorf("expected other routes to be left alone, got %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func add_seo_fields_to_posts() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				ALTER TABLE posts
+					ADD COLUMN IF NOT EXISTS meta_title VARCHAR(200) NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS meta_description VARCHAR(300) NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS og_image TEXT NOT NULL DEFAULT '',
+					ADD COLUMN IF NOT EXISTS noindex BOOLEAN NOT NULL DEFAULT FALSE;
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func add_published_at_to_posts() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				ALTER TABLE posts ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE;
+
+				-- Backfill already published posts with their creation time
+				UPDATE posts SET published_at = created_at
+					WHERE status = 'published' AND published_at IS NULL;
+
+				CREATE INDEX IF NOT EXISTS idx_posts_published_at ON posts(published_at);
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_outbox_table() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS outbox (
+					id BIGSERIAL PRIMARY KEY,
+					aggregate_id INTEGER NOT NULL,
+					event_type VARCHAR(50) NOT NULL,
+					topic VARCHAR(200) NOT NULL,
+					payload JSONB NOT NULL,
+					attempts INTEGER NOT NULL DEFAULT 0,
+					last_error TEXT NOT NULL DEFAULT '',
+					next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					sent_at TIMESTAMP WITH TIME ZONE,
+					failed_at TIMESTAMP WITH TIME ZONE
+				);
+
+				-- Pending rows are read in id order per aggregate by the relay
+				CREATE INDEX IF NOT EXISTS idx_outbox_pending
+					ON outbox(aggregate_id, id) WHERE sent_at IS NULL AND failed_at IS NULL;
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_webhook_tables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+					id SERIAL PRIMARY KEY,
+					url TEXT NOT NULL,
+					secret TEXT NOT NULL,
+					event_types TEXT NOT NULL,
+					active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE TABLE IF NOT EXISTS webhook_deliveries (
+					id BIGSERIAL PRIMARY KEY,
+					subscription_id INTEGER NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+					event_id VARCHAR(64) NOT NULL,
+					event_type VARCHAR(50) NOT NULL,
+					payload JSONB NOT NULL,
+					status VARCHAR(20) NOT NULL DEFAULT 'pending'
+						CHECK (status IN ('pending', 'succeeded', 'failed')),
+					attempts INTEGER NOT NULL DEFAULT 0,
+					last_status_code INTEGER NOT NULL DEFAULT 0,
+					last_error TEXT NOT NULL DEFAULT '',
+					next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					delivered_at TIMESTAMP WITH TIME ZONE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription
+					ON webhook_deliveries(subscription_id, id);
+				CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+					ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+				CREATE TRIGGER update_webhook_subscriptions_updated_at
+					BEFORE UPDATE ON webhook_subscriptions
+					FOR EACH ROW
+					EXECUTE FUNCTION update_updated_at_column();
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_audit_log_table() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS audit_log (
+					id BIGSERIAL PRIMARY KEY,
+					action VARCHAR(30) NOT NULL,
+					entity_type VARCHAR(30) NOT NULL,
+					entity_id INTEGER NOT NULL,
+					actor VARCHAR(255) NOT NULL DEFAULT '',
+					request_id VARCHAR(100) NOT NULL DEFAULT '',
+					ip VARCHAR(45) NOT NULL DEFAULT '',
+					changes JSONB NOT NULL DEFAULT '{}',
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id, id);
+				CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
+				CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor);
+
+				-- The log is append-only: rows may be purged by retention but never rewritten
+				CREATE OR REPLACE FUNCTION reject_audit_log_update()
+				RETURNS TRIGGER AS $$
+				BEGIN
+					RAISE EXCEPTION 'audit_log is append-only';
+				END;
+				$$ language 'plpgsql';
+
+				CREATE TRIGGER audit_log_append_only
+					BEFORE UPDATE ON audit_log
+					FOR EACH ROW
+					EXECUTE FUNCTION reject_audit_log_update();
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_idempotency_keys_table() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS idempotency_keys (
+					caller VARCHAR(255) NOT NULL,
+					idempotency_key VARCHAR(255) NOT NULL,
+					request_hash CHAR(64) NOT NULL,
+					status VARCHAR(20) NOT NULL DEFAULT 'in_progress'
+						CHECK (status IN ('in_progress', 'completed')),
+					response JSONB,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+					PRIMARY KEY (caller, idempotency_key)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+			`)
+			return err
+		},
+	}
+}
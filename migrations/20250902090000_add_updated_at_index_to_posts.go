@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func add_updated_at_index_to_posts() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				-- Lets the list version (MAX(updated_at)) be read from the index
+				CREATE INDEX IF NOT EXISTS idx_posts_updated_at ON posts(updated_at);
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func add_keyset_indexes_to_posts() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				-- Serves cursor pages ordered by (created_at, id) without a sort
+				CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts(created_at DESC, id DESC);
+				-- Serves the newest published posts of a batch of authors
+				CREATE INDEX IF NOT EXISTS idx_posts_author_published
+					ON posts(author_id, published_at DESC, id DESC) WHERE status = 'published';
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_import_tables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS import_jobs (
+					id SERIAL PRIMARY KEY,
+					source VARCHAR(50) NOT NULL,
+					checksum CHAR(64) NOT NULL,
+					payload BYTEA NOT NULL,
+					options JSONB NOT NULL DEFAULT '{}',
+					status VARCHAR(20) NOT NULL DEFAULT 'pending'
+						CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+					total INTEGER NOT NULL DEFAULT 0,
+					processed INTEGER NOT NULL DEFAULT 0,
+					imported INTEGER NOT NULL DEFAULT 0,
+					skipped INTEGER NOT NULL DEFAULT 0,
+					failed INTEGER NOT NULL DEFAULT 0,
+					last_error TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					completed_at TIMESTAMP WITH TIME ZONE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_import_jobs_unfinished ON import_jobs(checksum)
+					WHERE status IN ('pending', 'running');
+
+				CREATE TABLE IF NOT EXISTS import_items (
+					job_id INTEGER NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+					source_id BIGINT NOT NULL,
+					post_id INTEGER REFERENCES posts(id) ON DELETE SET NULL,
+					status VARCHAR(20) NOT NULL CHECK (status IN ('imported', 'skipped', 'failed')),
+					reason TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (job_id, source_id)
+				);
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_post_translations_table() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS post_translations (
+					id SERIAL PRIMARY KEY,
+					post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+					locale VARCHAR(35) NOT NULL,
+					title VARCHAR(200) NOT NULL,
+					content TEXT NOT NULL,
+					slug VARCHAR(200) NOT NULL,
+					meta_title VARCHAR(200) NOT NULL DEFAULT '',
+					meta_description VARCHAR(300) NOT NULL DEFAULT '',
+					canonical_url TEXT NOT NULL DEFAULT '',
+					og_image TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (post_id, locale),
+					UNIQUE (locale, slug)
+				);
+			`)
+			return err
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_users_and_api_keys_tables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS users (
+					id SERIAL PRIMARY KEY,
+					name VARCHAR(100) NOT NULL,
+					email VARCHAR(254) NOT NULL UNIQUE,
+					role VARCHAR(20) NOT NULL,
+					disabled BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE TABLE IF NOT EXISTS api_keys (
+					id SERIAL PRIMARY KEY,
+					user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					name VARCHAR(100) NOT NULL,
+					prefix VARCHAR(16) NOT NULL,
+					key_hash CHAR(64) NOT NULL UNIQUE,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					last_used_at TIMESTAMP WITH TIME ZONE,
+					revoked_at TIMESTAMP WITH TIME ZONE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
+			`)
+			return err
+		},
+	}
+}
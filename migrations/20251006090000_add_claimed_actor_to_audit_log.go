@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func add_claimed_actor_to_audit_log() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS claimed_actor VARCHAR(255) NOT NULL DEFAULT '';
+			`)
+			return err
+		},
+	}
+}
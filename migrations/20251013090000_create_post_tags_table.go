@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_post_tags_table() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				CREATE TABLE IF NOT EXISTS post_tags (
+					post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+					tag VARCHAR(50) NOT NULL,
+					PRIMARY KEY (post_id, tag)
+				);
+				CREATE INDEX IF NOT EXISTS idx_post_tags_tag ON post_tags (tag, post_id);
+			`)
+			return err
+		},
+	}
+}
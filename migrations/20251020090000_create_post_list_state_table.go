@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+func create_post_list_state_table() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(`
+				-- One row holding the time posts were last deleted, which MAX(updated_at) cannot show
+				CREATE TABLE IF NOT EXISTS post_list_state (
+					id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+					deleted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT TIMESTAMP WITH TIME ZONE 'epoch'
+				);
+				INSERT INTO post_list_state DEFAULT VALUES ON CONFLICT DO NOTHING;
+
+				CREATE OR REPLACE FUNCTION update_post_list_deleted_at()
+				RETURNS TRIGGER AS $$
+				BEGIN
+					UPDATE post_list_state SET deleted_at = CURRENT_TIMESTAMP;
+					RETURN NULL;
+				END;
+				$$ language 'plpgsql';
+
+				DROP TRIGGER IF EXISTS update_post_list_deleted_at ON posts;
+				CREATE TRIGGER update_post_list_deleted_at
+					AFTER DELETE ON posts
+					FOR EACH STATEMENT
+					EXECUTE FUNCTION update_post_list_deleted_at();
+			`)
+			return err
+		},
+	}
+}
@@ -9,5 +9,19 @@ func All() map[int64]migration.Migrate {
 	return map[int64]migration.Migrate {
 	
 		20250714123701: create_posts_table(),
+		20250801120000: add_seo_fields_to_posts(),
+		20250805090000: add_published_at_to_posts(),
+		20250812100000: create_outbox_table(),
+		20250818110000: create_webhook_tables(),
+		20250822090000: create_audit_log_table(),
+		20250826100000: create_idempotency_keys_table(),
+		20250902090000: add_updated_at_index_to_posts(),
+		20250908090000: add_keyset_indexes_to_posts(),
+		20250915090000: create_import_tables(),
+		20250922090000: create_post_translations_table(),
+		20250929090000: create_users_and_api_keys_tables(),
+		20251006090000: add_claimed_actor_to_audit_log(),
+		20251013090000: create_post_tags_table(),
+		20251020090000: create_post_list_state_table(),
 	}
 }
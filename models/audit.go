@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit actions recorded for post mutations
+const (
+	AuditCreate       = "create"
+	AuditUpdate       = "update"
+	AuditDelete       = "delete"
+	AuditStatusChange = "status_change"
+)
+
+// AuditEntityPost is the entity type of audit entries about posts
+const AuditEntityPost = "post"
+
+// AuditEntry is one append-only record of a mutating operation. Actor is the authenticated
+// caller; ClaimedActor is whoever the request said it acted for, which is not verified.
+type AuditEntry struct {
+	ID           int64           `json:"id" db:"id"`
+	Action       string          `json:"action" db:"action"`
+	EntityType   string          `json:"entity_type" db:"entity_type"`
+	EntityID     int             `json:"entity_id" db:"entity_id"`
+	Actor        string          `json:"actor" db:"actor"`
+	ClaimedActor string          `json:"claimed_actor,omitempty" db:"claimed_actor"`
+	RequestID    string          `json:"request_id" db:"request_id"`
+	IP           string          `json:"ip" db:"ip"`
+	Changes      json.RawMessage `json:"changes" db:"changes"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditChange holds the value of one field before and after an operation
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditFilter narrows the audit log; zero values match everything
+type AuditFilter struct {
+	Action     string
+	EntityType string
+	EntityID   int
+	Actor      string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditListResponse represents the response for listing audit entries
+type AuditListResponse struct {
+	Entries    []AuditEntry `json:"entries"`
+	TotalCount int          `json:"total_count"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"page_size"`
+	TotalPages int          `json:"total_pages"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// Restore strategies for archived posts whose slug is already taken
+const (
+	RestoreSkip      = "skip"
+	RestoreOverwrite = "overwrite"
+	RestoreRename    = "rename"
+)
+
+// RestoreReport is the outcome of restoring a backup archive
+type RestoreReport struct {
+	Strategy    string `json:"strategy"`
+	Version     int    `json:"version"`
+	Created     int    `json:"created"`
+	Overwritten int    `json:"overwritten"`
+	Skipped     int    `json:"skipped"`
+	// Remapped maps the archived ids of restored posts to their ids in this database where they differ
+	Remapped map[int]int `json:"remapped"`
+	// Renamed lists the posts restored under a new slug
+	Renamed []SlugChange `json:"renamed"`
+	// Records counts the records of the other tables of the archive by entity
+	Records map[string]*RestoreCount `json:"records"`
+}
+
+// RestoreCount counts the records of one entity restored, and those skipped because they
+// already exist or belong to a post that was skipped
+type RestoreCount struct {
+	Restored int `json:"restored"`
+	Skipped  int `json:"skipped"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "encoding/json"
+
+// Bulk operation types
+const (
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+	BulkStatus = "status"
+	BulkTags   = "tags"
+)
+
+// Bulk modes
+const (
+	// BulkAtomic commits every operation or none of them
+	BulkAtomic = "atomic"
+	// BulkBestEffort commits the operations that succeed and reports the others
+	BulkBestEffort = "best_effort"
+)
+
+// BulkRequest represents the request body of POST /posts/bulk
+type BulkRequest struct {
+	Mode       string          `json:"mode"`
+	Operations []BulkOperation `json:"operations"`
+}
+
+// BulkOperation is one operation of a bulk request.
+// Post holds the create or update body, Status the target status of a status change and Tags
+// the tags replacing those of the post.
+type BulkOperation struct {
+	Op     string          `json:"op"`
+	ID     int             `json:"id,omitempty"`
+	Post   json.RawMessage `json:"post,omitempty"`
+	Status string          `json:"status,omitempty"`
+	Tags   []string        `json:"tags,omitempty"`
+
+	// Index is the position of the operation in the request
+	Index int `json:"-"`
+	// Create and Update hold Post decoded for create and update operations
+	Create *CreatePostRequest `json:"-"`
+	Update *UpdatePostRequest `json:"-"`
+}
+
+// BulkItemResult reports the outcome of one bulk operation
+type BulkItemResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	ID      int    `json:"id,omitempty"`
+	Success bool   `json:"success"`
+	Post    *Post  `json:"post,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResponse represents the response of POST /posts/bulk
+type BulkResponse struct {
+	Mode      string           `json:"mode"`
+	Committed bool             `json:"committed"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
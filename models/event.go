@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// Post event types published when posts change
+const (
+	PostCreated   = "post.created"
+	PostUpdated   = "post.updated"
+	PostPublished = "post.published"
+	PostDeleted   = "post.deleted"
+)
+
+// PostEventVersion is the schema version of PostEvent payloads
+const PostEventVersion = 1
+
+// PostEvent is the payload of a post domain event
+type PostEvent struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Version       int       `json:"version"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	PostID        int       `json:"post_id"`
+	Post          *Post     `json:"post"`
+	ChangedFields []string  `json:"changed_fields,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ExportOptions selects the posts of a static site export and its format
+type ExportOptions struct {
+	// Format is the static site generator the export is laid out for: hugo or jekyll
+	Format string
+	Status string
+	// From and To bound the creation time of the exported posts, inclusive
+	From *time.Time
+	To   *time.Time
+}
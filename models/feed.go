@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// FeedFilter narrows the published posts included in a feed
+type FeedFilter struct {
+	AuthorID int
+	Tag      string
+	Limit    int
+	Offset   int
+}
+
+// FeedVersion identifies the state of a feed or post list for conditional requests
+type FeedVersion struct {
+	LastModified time.Time
+	Count        int
+
+	// Variant tells apart representations of the same version, such as locales
+	Variant string `json:"-"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Idempotency key statuses
+const (
+	IdempotencyInProgress = "in_progress"
+	IdempotencyCompleted  = "completed"
+)
+
+// IdempotencyRecord is a request made with an Idempotency-Key and, once completed, its response
+type IdempotencyRecord struct {
+	Caller      string          `json:"caller" db:"caller"`
+	Key         string          `json:"key" db:"idempotency_key"`
+	RequestHash string          `json:"request_hash" db:"request_hash"`
+	Status      string          `json:"status" db:"status"`
+	Response    json.RawMessage `json:"response" db:"response"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	ExpiresAt   time.Time       `json:"expires_at" db:"expires_at"`
+}
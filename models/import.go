@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+)
+
+// Import job statuses
+const (
+	ImportPending   = "pending"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+)
+
+// Import item outcomes
+const (
+	ImportItemImported = "imported"
+	ImportItemSkipped  = "skipped"
+	ImportItemFailed   = "failed"
+)
+
+// ImportSourceWordPress is the source of imports from WordPress WXR exports
+const ImportSourceWordPress = "wordpress"
+
+// ImportOptions tunes how exported posts are mapped to posts
+type ImportOptions struct {
+	// AuthorMap maps exported author logins to author ids; other authors keep their exported id
+	AuthorMap map[string]int `json:"author_map,omitempty"`
+	// DefaultAuthorID is the author of posts whose author is not in the export
+	DefaultAuthorID int `json:"default_author_id,omitempty"`
+}
+
+// ImportJob is a resumable import of an export, run in batches
+type ImportJob struct {
+	ID          int           `json:"id" db:"id"`
+	Source      string        `json:"source" db:"source"`
+	Checksum    string        `json:"checksum" db:"checksum"`
+	Options     ImportOptions `json:"options" db:"options"`
+	Status      string        `json:"status" db:"status"`
+	Total       int           `json:"total" db:"total"`
+	Processed   int           `json:"processed" db:"processed"`
+	Imported    int           `json:"imported" db:"imported"`
+	Skipped     int           `json:"skipped" db:"skipped"`
+	Failed      int           `json:"failed" db:"failed"`
+	LastError   string        `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time    `json:"completed_at" db:"completed_at"`
+}
+
+// ImportItem is the outcome of one exported item of an import job
+type ImportItem struct {
+	SourceID int    `json:"source_id" db:"source_id"`
+	PostID   *int   `json:"post_id" db:"post_id"`
+	Status   string `json:"status" db:"status"`
+	Reason   string `json:"reason,omitempty" db:"reason"`
+}
+
+// ImportConflict is an exported post that cannot be imported as it is
+type ImportConflict struct {
+	SourceID int    `json:"source_id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	Reason   string `json:"reason"`
+	// PostID is the existing post holding the slug
+	PostID int `json:"post_id,omitempty"`
+}
+
+// ImportReport is the dry-run report of an export
+type ImportReport struct {
+	// Posts counts the posts that would be imported
+	Posts   int `json:"posts"`
+	Authors int `json:"authors"`
+	// Skipped counts the items that are not posts or have a status that is not imported, by reason
+	Skipped map[string]int `json:"skipped"`
+	// Categories and Tags count the terms of the posts, which are imported as tags
+	Categories int `json:"categories"`
+	Tags       int `json:"tags"`
+	// NewTags lists the tags, normalized, that no post has yet
+	NewTags []string `json:"new_tags"`
+	// Comments are counted but not imported: they are not modelled yet
+	Comments  int              `json:"comments"`
+	Conflicts []ImportConflict `json:"conflicts"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// OutboxMessage is an event waiting in the transactional outbox for delivery
+type OutboxMessage struct {
+	ID          int64     `json:"id" db:"id"`
+	AggregateID int       `json:"aggregate_id" db:"aggregate_id"`
+	EventType   string    `json:"event_type" db:"event_type"`
+	Topic       string    `json:"topic" db:"topic"`
+	Payload     []byte    `json:"payload" db:"payload"`
+	Attempts    int       `json:"attempts" db:"attempts"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
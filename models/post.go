@@ -14,6 +14,24 @@ type Post struct {
 	Status    string    `json:"status" db:"status" validate:"required,oneof=draft published archived"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// PublishedAt is set the first time the post moves to published status
+	PublishedAt *time.Time `json:"published_at" db:"published_at"`
+
+	// SEO metadata, empty values fall back to title and excerpt when rendered
+	MetaTitle       string `json:"meta_title" db:"meta_title" validate:"max=200"`
+	MetaDescription string `json:"meta_description" db:"meta_description" validate:"max=300"`
+	CanonicalURL    string `json:"canonical_url" db:"canonical_url" validate:"omitempty,url"`
+	OGImage         string `json:"og_image" db:"og_image" validate:"omitempty,url"`
+	NoIndex         bool   `json:"noindex" db:"noindex"`
+
+	// Locale is the locale the post was rendered in, set when a translation may have been
+	// applied. Alternates lists its translations for hreflang links; neither is stored.
+	Locale     string          `json:"locale,omitempty"`
+	Alternates []PostAlternate `json:"alternates,omitempty"`
+
+	// Tags are set where a response lists them, such as feed items; they are stored in post_tags
+	Tags []string `json:"tags,omitempty"`
 }
 
 // CreatePostRequest represents the request body for creating a post
@@ -23,6 +41,12 @@ type CreatePostRequest struct {
 	Slug     string `json:"slug" validate:"required,min=3,max=200"`
 	AuthorID int    `json:"author_id" validate:"required"`
 	Status   string `json:"status" validate:"required,oneof=draft published archived"`
+
+	MetaTitle       string `json:"meta_title,omitempty" validate:"omitempty,max=200"`
+	MetaDescription string `json:"meta_description,omitempty" validate:"omitempty,max=300"`
+	CanonicalURL    string `json:"canonical_url,omitempty" validate:"omitempty,url"`
+	OGImage         string `json:"og_image,omitempty" validate:"omitempty,url"`
+	NoIndex         bool   `json:"noindex,omitempty"`
 }
 
 // UpdatePostRequest represents the request body for updating a post
@@ -31,6 +55,49 @@ type UpdatePostRequest struct {
 	Content string `json:"content,omitempty" validate:"omitempty,min=10"`
 	Slug    string `json:"slug,omitempty" validate:"omitempty,min=3,max=200"`
 	Status  string `json:"status,omitempty" validate:"omitempty,oneof=draft published archived"`
+
+	MetaTitle       string `json:"meta_title,omitempty" validate:"omitempty,max=200"`
+	MetaDescription string `json:"meta_description,omitempty" validate:"omitempty,max=300"`
+	CanonicalURL    string `json:"canonical_url,omitempty" validate:"omitempty,url"`
+	OGImage         string `json:"og_image,omitempty" validate:"omitempty,url"`
+	NoIndex         *bool  `json:"noindex,omitempty"`
+}
+
+// PostDocument is the JSON document of a post's writable fields that PATCH requests apply to.
+// Every member is always present, so a patch can test, replace or clear any of them.
+type PostDocument struct {
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	Slug     string `json:"slug"`
+	AuthorID int    `json:"author_id"`
+	Status   string `json:"status"`
+
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url"`
+	OGImage         string `json:"og_image"`
+	NoIndex         bool   `json:"noindex"`
+}
+
+// Document returns the writable fields of the post
+func (p *Post) Document() PostDocument {
+	return PostDocument{
+		Title:           p.Title,
+		Content:         p.Content,
+		Slug:            p.Slug,
+		AuthorID:        p.AuthorID,
+		Status:          p.Status,
+		MetaTitle:       p.MetaTitle,
+		MetaDescription: p.MetaDescription,
+		CanonicalURL:    p.CanonicalURL,
+		OGImage:         p.OGImage,
+		NoIndex:         p.NoIndex,
+	}
+}
+
+// CreateRequest returns the document as a create request so it is validated by the same rules
+func (d PostDocument) CreateRequest() CreatePostRequest {
+	return CreatePostRequest(d)
 }
 
 // PostListResponse represents the response for listing posts
@@ -41,3 +108,113 @@ type PostListResponse struct {
 	PageSize   int    `json:"page_size"`
 	TotalPages int    `json:"total_pages"`
 }
+
+// SparsePostListResponse represents a list response limited to a fieldset
+type SparsePostListResponse struct {
+	Posts      []map[string]any `json:"posts"`
+	TotalCount int              `json:"total_count"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// PostFields lists every selectable post field in its canonical order
+var PostFields = []string{
+	"id", "title", "content", "slug", "author_id", "status", "created_at", "updated_at", "published_at",
+	"meta_title", "meta_description", "canonical_url", "og_image", "noindex",
+}
+
+// PostSummaryFields lists the fields returned by list endpoints when no fieldset is requested
+var PostSummaryFields = []string{
+	"id", "title", "slug", "author_id", "status", "created_at", "updated_at", "published_at",
+	"meta_title", "meta_description", "canonical_url", "og_image", "noindex",
+}
+
+// IsPostField reports whether name is a selectable post field
+func IsPostField(name string) bool {
+	for _, field := range PostFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Project returns the post as a map holding only the requested fields
+func (p *Post) Project(fields []string) map[string]any {
+	projected := make(map[string]any, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "id":
+			projected[field] = p.ID
+		case "title":
+			projected[field] = p.Title
+		case "content":
+			projected[field] = p.Content
+		case "slug":
+			projected[field] = p.Slug
+		case "author_id":
+			projected[field] = p.AuthorID
+		case "status":
+			projected[field] = p.Status
+		case "created_at":
+			projected[field] = p.CreatedAt
+		case "updated_at":
+			projected[field] = p.UpdatedAt
+		case "published_at":
+			projected[field] = p.PublishedAt
+		case "meta_title":
+			projected[field] = p.MetaTitle
+		case "meta_description":
+			projected[field] = p.MetaDescription
+		case "canonical_url":
+			projected[field] = p.CanonicalURL
+		case "og_image":
+			projected[field] = p.OGImage
+		case "noindex":
+			projected[field] = p.NoIndex
+		}
+	}
+	return projected
+}
+
+// SEOMetadata represents the resolved SEO metadata of a post, ready to embed in a page
+type SEOMetadata struct {
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	CanonicalURL   string            `json:"canonical_url"`
+	Robots         string            `json:"robots"`
+	OpenGraph      map[string]string `json:"open_graph"`
+	StructuredData map[string]any    `json:"structured_data"`
+}
+
+// ChangedFields lists the fields whose values differ between p and other, ignoring updated_at
+func (p *Post) ChangedFields(other *Post) []string {
+	var changed []string
+	add := func(field string, differs bool) {
+		if differs {
+			changed = append(changed, field)
+		}
+	}
+
+	add("title", p.Title != other.Title)
+	add("content", p.Content != other.Content)
+	add("slug", p.Slug != other.Slug)
+	add("author_id", p.AuthorID != other.AuthorID)
+	add("status", p.Status != other.Status)
+	add("published_at", !timesEqual(p.PublishedAt, other.PublishedAt))
+	add("meta_title", p.MetaTitle != other.MetaTitle)
+	add("meta_description", p.MetaDescription != other.MetaDescription)
+	add("canonical_url", p.CanonicalURL != other.CanonicalURL)
+	add("og_image", p.OGImage != other.OGImage)
+	add("noindex", p.NoIndex != other.NoIndex)
+
+	return changed
+}
+
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
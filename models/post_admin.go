@@ -0,0 +1,9 @@
+package models
+
+// SlugChange is a post slug rewritten from its title
+type SlugChange struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
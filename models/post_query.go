@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// PostFilter narrows the posts of a keyset-paginated query; zero values match every post
+type PostFilter struct {
+	Status   string `json:"status"`
+	AuthorID int    `json:"author_id"`
+	// Tag matches posts with the tag, normalized
+	Tag string `json:"tag"`
+}
+
+// PostCursor is the position of a post in newest-first order, broken by descending id
+type PostCursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// PostPage is a keyset-paginated page of posts
+type PostPage struct {
+	Posts       []Post
+	HasNextPage bool
+}
@@ -0,0 +1,11 @@
+package models
+
+import (
+	"time"
+)
+
+// SitemapChunk describes one child sitemap of a sitemap index
+type SitemapChunk struct {
+	Index        int
+	LastModified time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+// PostTagsRequest represents the request body for replacing the tags of a post
+type PostTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// PostTags lists the tags of a post
+type PostTags struct {
+	PostID int      `json:"post_id"`
+	Tags   []string `json:"tags"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// PostTranslation holds a post's title, content, slug and SEO fields in one locale other than
+// the site's default, which is the locale of the post itself
+type PostTranslation struct {
+	ID     int    `json:"id" db:"id"`
+	PostID int    `json:"post_id" db:"post_id"`
+	Locale string `json:"locale" db:"locale"`
+
+	Title   string `json:"title" db:"title"`
+	Content string `json:"content" db:"content"`
+	Slug    string `json:"slug" db:"slug"`
+
+	MetaTitle       string `json:"meta_title" db:"meta_title"`
+	MetaDescription string `json:"meta_description" db:"meta_description"`
+	CanonicalURL    string `json:"canonical_url" db:"canonical_url"`
+	OGImage         string `json:"og_image" db:"og_image"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TranslationRequest represents the request body for creating or replacing a translation
+type TranslationRequest struct {
+	Title   string `json:"title" validate:"required,min=3,max=200"`
+	Content string `json:"content" validate:"required,min=10"`
+	Slug    string `json:"slug" validate:"required,min=3,max=200"`
+
+	MetaTitle       string `json:"meta_title,omitempty" validate:"omitempty,max=200"`
+	MetaDescription string `json:"meta_description,omitempty" validate:"omitempty,max=300"`
+	CanonicalURL    string `json:"canonical_url,omitempty" validate:"omitempty,url"`
+	OGImage         string `json:"og_image,omitempty" validate:"omitempty,url"`
+}
+
+// PostLocales reports which of the site's locales a post is available in
+type PostLocales struct {
+	PostID    int      `json:"post_id"`
+	Default   string   `json:"default"`
+	Available []string `json:"available"`
+	Missing   []string `json:"missing"`
+}
+
+// PostAlternate is a translation of a post, linked as an hreflang alternate
+type PostAlternate struct {
+	Locale string `json:"locale"`
+	Slug   string `json:"slug"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+)
+
+// User roles. Admins may use the admin endpoints; editors are identified callers otherwise
+// treated like anonymous ones.
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+)
+
+// User represents a person or service that authenticates with API keys
+type User struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Email     string    `json:"email" db:"email"`
+	Role      string    `json:"role" db:"role"`
+	Disabled  bool      `json:"disabled" db:"disabled"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateUserRequest represents the fields of a new user
+type CreateUserRequest struct {
+	Name  string `json:"name" validate:"required,max=100"`
+	Email string `json:"email" validate:"required,email,max=254"`
+	Role  string `json:"role" validate:"required,oneof=admin editor"`
+}
+
+// APIKey represents an API key of a user. Only a hash of the key is stored; Prefix is the
+// start of the key, shown to tell keys apart.
+type APIKey struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at" db:"revoked_at"`
+}
+
+// APIKeyRecord is an API key with the hash of its secret, the form backups keep keys in so
+// that they keep working once restored
+type APIKeyRecord struct {
+	APIKey
+	KeyHash string `json:"key_hash"`
+}
+
+// CreatedAPIKey is a new API key along with its secret, which is only ever returned once
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Principal identifies the authenticated caller of a request. ID is recorded in audit entries
+// and keys rate limits, such as "key:7" for API key 7 or "admin" for the ADMIN_API_KEY.
+type Principal struct {
+	ID     string `json:"id"`
+	UserID int    `json:"user_id,omitempty"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
+
+// IsAdmin reports whether the principal may use the admin endpoints
+func (p *Principal) IsAdmin() bool {
+	return p != nil && p.Role == RoleAdmin
+}
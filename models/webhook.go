@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription represents an endpoint that receives post events over HTTP
+type WebhookSubscription struct {
+	ID         int       `json:"id" db:"id"`
+	URL        string    `json:"url" db:"url"`
+	Secret     string    `json:"secret,omitempty" db:"secret"`
+	EventTypes []string  `json:"event_types" db:"event_types"`
+	Active     bool      `json:"active" db:"active"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateWebhookRequest represents the request body for creating a webhook subscription.
+// A secret is generated when none is given.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+// UpdateWebhookRequest represents the request body for updating a webhook subscription
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url,omitempty" validate:"omitempty,url"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+// WebhookDelivery represents one event sent, or waiting to be sent, to a subscription
+type WebhookDelivery struct {
+	ID             int64           `json:"id" db:"id"`
+	SubscriptionID int             `json:"subscription_id" db:"subscription_id"`
+	EventID        string          `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	LastStatusCode int             `json:"last_status_code" db:"last_status_code"`
+	LastError      string          `json:"last_error" db:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at" db:"delivered_at"`
+}
+
+// WebhookDeliveryListResponse represents the response for listing deliveries of a subscription
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	TotalCount int               `json:"total_count"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// DueWebhookDelivery is a claimed pending delivery with the endpoint it goes to
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
@@ -0,0 +1,327 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Operation is one RFC 6902 JSON Patch operation
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies an RFC 6902 JSON Patch to doc. Operations are applied in order and the
+// patch is all-or-nothing: the first failing operation aborts it.
+func Apply(doc, jsonPatch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+	if err = json.Unmarshal(jsonPatch, &ops); err != nil {
+		return nil, errors.Join(ErrInvalidPatch, err)
+	}
+
+	for i := range ops {
+		if target, err = ops[i].apply(target); err != nil {
+			return nil, errors.Join(err, errors.New("operation "+strconv.Itoa(i)+" ("+ops[i].Op+" "+ops[i].Path+")"))
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+// apply applies the operation to doc and returns the resulting document
+func (o *Operation) apply(doc any) (any, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case "add":
+		value, valueErr := o.value()
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		return add(doc, path, value)
+
+	case "remove":
+		return remove(doc, path)
+
+	case "replace":
+		value, valueErr := o.value()
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		if _, err = get(doc, path); err != nil {
+			return nil, err
+		}
+		return set(doc, path, value)
+
+	case "move", "copy":
+		from, fromErr := parsePointer(o.From)
+		if fromErr != nil {
+			return nil, fromErr
+		}
+		value, getErr := get(doc, from)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if o.Op == "copy" {
+			return add(doc, path, deepCopy(value))
+		}
+		if o.Path == o.From {
+			return doc, nil
+		}
+		if strings.HasPrefix(o.Path, o.From+"/") {
+			return nil, errors.Join(ErrInvalidPatch, errors.New("cannot move a value into itself"))
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+
+	case "test":
+		value, valueErr := o.value()
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		actual, getErr := get(doc, path)
+		if getErr != nil {
+			return nil, errors.Join(ErrTestFailed, getErr)
+		}
+		if !equal(actual, value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	}
+
+	return nil, errors.Join(ErrInvalidPatch, errors.New("unknown op: "+o.Op))
+}
+
+// value decodes the operation value; an explicit null is a valid value
+func (o *Operation) value() (any, error) {
+	if len(o.Value) == 0 {
+		return nil, errors.Join(ErrInvalidPatch, errors.New(o.Op+" requires a value"))
+	}
+	value, err := decode(o.Value)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidPatch, err)
+	}
+	return value, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.Join(ErrInvalidPatch, errors.New("JSON pointer must start with /: "+pointer))
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// get returns the value at path
+func get(doc any, path []string) (any, error) {
+	current := doc
+	for _, token := range path {
+		switch container := current.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, errors.Join(ErrPathNotFound, errors.New(token))
+			}
+			current = value
+		case []any:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = container[index]
+		default:
+			return nil, errors.Join(ErrPathNotFound, errors.New(token))
+		}
+	}
+	return current, nil
+}
+
+// set replaces the existing value at path
+func set(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		container[last] = value
+	case []any:
+		index, indexErr := arrayIndex(last, len(container)-1)
+		if indexErr != nil {
+			return nil, indexErr
+		}
+		container[index] = value
+	default:
+		return nil, errors.Join(ErrPathNotFound, errors.New(last))
+	}
+	return doc, nil
+}
+
+// add inserts value at path, adding an object member or shifting array elements up
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parentPath := path[:len(path)-1]
+	parent, err := get(doc, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		container[last] = value
+		return doc, nil
+	case []any:
+		index := len(container)
+		if last != "-" {
+			if index, err = arrayIndex(last, len(container)); err != nil {
+				return nil, err
+			}
+		}
+		grown := make([]any, 0, len(container)+1)
+		grown = append(grown, container[:index]...)
+		grown = append(grown, value)
+		grown = append(grown, container[index:]...)
+		return set(doc, parentPath, grown)
+	}
+
+	return nil, errors.Join(ErrPathNotFound, errors.New(last))
+}
+
+// remove deletes the value at path, shifting later array elements down
+func remove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, errors.Join(ErrInvalidPatch, errors.New("cannot remove the whole document"))
+	}
+
+	parentPath := path[:len(path)-1]
+	parent, err := get(doc, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		if _, ok := container[last]; !ok {
+			return nil, errors.Join(ErrPathNotFound, errors.New(last))
+		}
+		delete(container, last)
+		return doc, nil
+	case []any:
+		index, indexErr := arrayIndex(last, len(container)-1)
+		if indexErr != nil {
+			return nil, indexErr
+		}
+		shrunk := make([]any, 0, len(container)-1)
+		shrunk = append(shrunk, container[:index]...)
+		shrunk = append(shrunk, container[index+1:]...)
+		return set(doc, parentPath, shrunk)
+	}
+
+	return nil, errors.Join(ErrPathNotFound, errors.New(last))
+}
+
+// arrayIndex parses an array index token, which must be between 0 and upper inclusive
+func arrayIndex(token string, upper int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, errors.Join(ErrInvalidPatch, errors.New("invalid array index: "+token))
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, errors.Join(ErrInvalidPatch, errors.New("invalid array index: "+token))
+	}
+	if index > upper {
+		return 0, errors.Join(ErrPathNotFound, errors.New("array index out of range: "+token))
+	}
+	return index, nil
+}
+
+// equal compares two decoded JSON values, treating numbers by value
+func equal(a, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, aErr := av.Float64()
+		bf, bErr := bv.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, exists := bv[key]
+			if !exists || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// deepCopy copies a decoded JSON value so copies do not share containers
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	}
+	return value
+}
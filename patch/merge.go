@@ -0,0 +1,46 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// MergePatch applies an RFC 7396 merge patch to doc.
+// Object members of the patch replace those of doc recursively, and null members remove them;
+// any other patch value replaces doc entirely.
+func MergePatch(doc, mergePatch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decode(mergePatch)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+// merge applies the merge patch p to target
+func merge(target, p any) any {
+	patchObject, ok := p.(map[string]any)
+	if !ok {
+		return p
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+
+	return targetObject
+}
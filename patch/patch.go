@@ -0,0 +1,37 @@
+// Package patch applies JSON Merge Patch (RFC 7396) and JSON Patch (RFC 6902) documents
+// to JSON values.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Media types of the supported patch formats
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+// Errors returned when a patch cannot be applied
+var (
+	ErrInvalidPatch = errors.New("invalid patch document")
+	ErrPathNotFound = errors.New("patch path does not exist")
+	ErrTestFailed   = errors.New("patch test operation failed")
+)
+
+// decode parses a JSON value, keeping numbers as json.Number so integers survive untouched
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
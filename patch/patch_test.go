@@ -0,0 +1,107 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSON fails the test when got and expected are not the same JSON value
+func assertJSON(t *testing.T, got []byte, expected string) {
+	t.Helper()
+
+	var gotValue, expectedValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("Expected valid JSON, got %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("Invalid expected JSON %s: %v", expected, err)
+	}
+	if !reflect.DeepEqual(gotValue, expectedValue) {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+// TestMergePatch tests the RFC 7396 merge semantics, including null removal and nested objects
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null removes", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"nested", `{"a":{"b":"c","d":1}}`, `{"a":{"d":null,"e":2}}`, `{"a":{"b":"c","e":2}}`},
+		{"arrays replaced", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`},
+		{"non-object patch", `{"a":"b"}`, `["c"]`, `["c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			assertJSON(t, got, tt.expected)
+		})
+	}
+}
+
+// TestApply tests each RFC 6902 operation
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add member", `{"a":1}`, `[{"op":"add","path":"/b","value":2}]`, `{"a":1,"b":2}`},
+		{"add array element", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{"append", `{"a":[1]}`, `[{"op":"add","path":"/a/-","value":2}]`, `{"a":[1,2]}`},
+		{"remove", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"replace with null", `{"a":"x"}`, `[{"op":"replace","path":"/a","value":null}]`, `{"a":null}`},
+		{"move", `{"a":{"b":1}}`, `[{"op":"move","from":"/a/b","path":"/c"}]`, `{"a":{},"c":1}`},
+		{"copy", `{"a":[1]}`, `[{"op":"copy","from":"/a","path":"/b"}]`, `{"a":[1],"b":[1]}`},
+		{"escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/m~0n"}]`, `{}`},
+		{"test then replace", `{"n":1}`, `[{"op":"test","path":"/n","value":1.0},{"op":"replace","path":"/n","value":2}]`, `{"n":2}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			assertJSON(t, got, tt.expected)
+		})
+	}
+}
+
+// TestApply_Errors tests that failing operations abort the patch with a matching error
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected error
+	}{
+		{"failed test", `[{"op":"test","path":"/a","value":2}]`, ErrTestFailed},
+		{"replace missing", `[{"op":"replace","path":"/missing","value":1}]`, ErrPathNotFound},
+		{"remove missing", `[{"op":"remove","path":"/missing"}]`, ErrPathNotFound},
+		{"missing value", `[{"op":"add","path":"/b"}]`, ErrInvalidPatch},
+		{"unknown op", `[{"op":"upsert","path":"/a","value":1}]`, ErrInvalidPatch},
+		{"bad pointer", `[{"op":"remove","path":"a"}]`, ErrInvalidPatch},
+		{"not an array", `{"op":"remove","path":"/a"}`, ErrInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply([]byte(`{"a":1}`), []byte(tt.patch))
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
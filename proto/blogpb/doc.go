@@ -0,0 +1,4 @@
+// Package blogpb holds the protobuf messages and gRPC service of blog.proto.
+package blogpb
+
+//go:generate protoc -I .. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ../blog.proto
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/middleware"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Standard rate limit response headers
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderPolicy     = "RateLimit-Policy"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// limitedError makes GoFr answer 429 Too Many Requests
+type limitedError struct{}
+
+func (limitedError) Error() string   { return ErrLimited.Error() }
+func (limitedError) Unwrap() error   { return ErrLimited }
+func (limitedError) StatusCode() int { return http.StatusTooManyRequests }
+
+// Limiter applies per-route limits to each client
+type Limiter struct {
+	store Store
+}
+
+// NewLimiter creates a limiter keeping its buckets in store
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store}
+}
+
+// Limit wraps next so each client may call it at most limit times; route names the bucket,
+// so routes sharing a name share a limit. A disabled limit returns next unchanged.
+// When the bucket store fails the request is let through.
+func (l *Limiter) Limit(route string, limit Limit, next gofr.Handler) gofr.Handler {
+	if limit.Disabled() {
+		return next
+	}
+
+	return func(ctx *gofr.Context) (any, error) {
+		result, err := l.store.Take(ctx, "ratelimit:"+route+":"+ClientKey(ctx), limit)
+		if err != nil {
+			ctx.Logger.Errorf("Rate limit check for %s failed: %v", route, err)
+			return next(ctx)
+		}
+
+		middleware.SetResponseHeader(ctx, HeaderLimit, strconv.Itoa(limit.Requests))
+		middleware.SetResponseHeader(ctx, HeaderRemaining, strconv.Itoa(result.Remaining))
+		middleware.SetResponseHeader(ctx, HeaderReset, strconv.Itoa(ceilSeconds(result.Reset)))
+		middleware.SetResponseHeader(ctx, HeaderPolicy, limit.String())
+
+		if !result.Allowed {
+			middleware.SetResponseHeader(ctx, HeaderRetryAfter, strconv.Itoa(max(1, ceilSeconds(result.RetryAfter))))
+			return nil, limitedError{}
+		}
+		return next(ctx)
+	}
+}
+
+// FailureLimit limits the failed attempts of each client IP, such as presenting invalid API
+// keys. Failing clients are counted by IP, since their attempts identify no one else.
+type FailureLimit struct {
+	store Store
+	route string
+	limit Limit
+}
+
+// Failures creates a limit of failed attempts at route; route names the bucket
+func (l *Limiter) Failures(route string, limit Limit) *FailureLimit {
+	return &FailureLimit{store: l.store, route: route, limit: limit}
+}
+
+// Check refuses the request with 429 once its client IP has used up its failed attempts, so
+// it can be called before an expensive attempt. When the bucket store fails the request is
+// let through.
+func (f *FailureLimit) Check(ctx *gofr.Context) error {
+	if f.limit.Disabled() {
+		return nil
+	}
+
+	result, err := f.store.Peek(ctx, f.bucketKey(ctx), f.limit)
+	if err != nil {
+		ctx.Logger.Errorf("Failure limit check for %s failed: %v", f.route, err)
+		return nil
+	}
+	if !result.Allowed {
+		middleware.SetResponseHeader(ctx, HeaderRetryAfter, strconv.Itoa(max(1, ceilSeconds(result.RetryAfter))))
+		return limitedError{}
+	}
+	return nil
+}
+
+// Fail counts a failed attempt of the client IP of the request
+func (f *FailureLimit) Fail(ctx *gofr.Context) {
+	if f.limit.Disabled() {
+		return
+	}
+	if _, err := f.store.Take(ctx, f.bucketKey(ctx), f.limit); err != nil {
+		ctx.Logger.Errorf("Failed to count a failed attempt for %s: %v", f.route, err)
+	}
+}
+
+// bucketKey returns the key of the bucket of the client IP of the request
+func (f *FailureLimit) bucketKey(ctx *gofr.Context) string {
+	return "ratelimit:" + f.route + ":ip:" + middleware.ClientIP(ctx)
+}
+
+// ClientKey identifies the client of a request: the principal of its API key, the API key or
+// JWT subject authenticated by GoFr, or else the client IP. GoFr API keys are hashed so they are
+// never stored in the bucket key. Unauthenticated credentials are ignored, since clients could
+// rotate them to dodge limits.
+func ClientKey(ctx *gofr.Context) string {
+	if principal := middleware.Principal(ctx); principal != nil {
+		return "principal:" + principal.ID
+	}
+	if auth := ctx.GetAuthInfo(); auth != nil {
+		if apiKey := auth.GetAPIKey(); apiKey != "" {
+			sum := sha256.Sum256([]byte(apiKey))
+			return "key:" + hex.EncodeToString(sum[:8])
+		}
+		if subject, ok := auth.GetClaims()["sub"].(string); ok && subject != "" {
+			return "sub:" + subject
+		}
+	}
+	return "ip:" + middleware.ClientIP(ctx)
+}
+
+// ceilSeconds rounds d up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
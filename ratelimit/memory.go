@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// sweepInterval is how often the memory store drops buckets that have refilled completely
+const sweepInterval = time.Minute
+
+// Memory keeps token buckets in process memory, limiting each instance separately
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// memoryBucket is a bucket with the time at which it is full again and can be dropped
+type memoryBucket struct {
+	bucket
+	full time.Time
+}
+
+// NewMemory creates an in-memory bucket store
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]memoryBucket),
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from the bucket of key
+func (m *Memory) Take(_ *gofr.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	b, result := take(m.buckets[key].bucket, limit, now)
+	m.buckets[key] = memoryBucket{bucket: b, full: now.Add(result.Reset)}
+	return result, nil
+}
+
+// Peek returns the result Take would return, without taking a token
+func (m *Memory) Peek(_ *gofr.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, result := take(m.buckets[key].bucket, limit, m.now())
+	return result, nil
+}
+
+// sweep drops full buckets, which behave like missing ones. The caller holds the lock.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package ratelimit limits requests per client and route with token buckets kept in GoFr's
+// Redis datasource, or in process memory when Redis is not configured.
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var (
+	// ErrLimited is returned to clients that exceeded the limit of a route
+	ErrLimited = errors.New("rate limit exceeded")
+
+	errInvalidLimit = errors.New("invalid rate limit")
+)
+
+// Limit allows Requests per Period with bursts of up to Requests.
+// Tokens refill evenly over the period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Disabled reports whether the limit lets every request through
+func (l Limit) Disabled() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// refillInterval returns the time it takes to refill one token, at least a millisecond
+func (l Limit) refillInterval() time.Duration {
+	return max(l.Period/time.Duration(l.Requests), time.Millisecond)
+}
+
+// String formats the limit as a RateLimit-Policy value, e.g. "10;w=60"
+func (l Limit) String() string {
+	return strconv.Itoa(l.Requests) + ";w=" + strconv.Itoa(int(math.Ceil(l.Period.Seconds())))
+}
+
+var periods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// ParseLimit parses limits written as requests/period, where period is s, m, h or d,
+// optionally preceded by a count, e.g. "10/m" or "100/15m". Empty, "0" and "off" disable the limit.
+func ParseLimit(value string) (Limit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" || value == "off" {
+		return Limit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return Limit{}, errors.Join(errInvalidLimit, errors.New("expected requests/period: "+value))
+	}
+
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 0 {
+		return Limit{}, errors.Join(errInvalidLimit, errors.New("invalid request count: "+requests))
+	}
+
+	errPeriod := errors.Join(errInvalidLimit, errors.New("invalid period: "+period))
+	if period == "" {
+		return Limit{}, errPeriod
+	}
+
+	unit, found := periods[period[len(period)-1:]]
+	if !found {
+		return Limit{}, errPeriod
+	}
+	count := 1
+	if prefix := period[:len(period)-1]; prefix != "" {
+		if count, err = strconv.Atoi(prefix); err != nil || count <= 0 {
+			return Limit{}, errPeriod
+		}
+	}
+
+	return Limit{Requests: n, Period: time.Duration(count) * unit}, nil
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token, set when the request was not allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets
+type Store interface {
+	// Take takes a token from the bucket of key
+	Take(ctx *gofr.Context, key string, limit Limit) (Result, error)
+	// Peek returns the result Take would return, without taking a token
+	Peek(ctx *gofr.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket is the state of a token bucket
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take refills b up to now and takes a token when one is available
+func take(b bucket, limit Limit, now time.Time) (bucket, Result) {
+	capacity := float64(limit.Requests)
+	refill := limit.refillInterval()
+
+	if b.updated.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)/float64(refill))
+	}
+	b.updated = now
+
+	var result Result
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(refill))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) * float64(refill))
+
+	return b, result
+}
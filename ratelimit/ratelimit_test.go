@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gofr-blog-service/middleware"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// TestParseLimit tests the requests/period syntax of configured limits
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Limit
+		wantErr  bool
+	}{
+		{"", Limit{}, false},
+		{"off", Limit{}, false},
+		{"10/m", Limit{Requests: 10, Period: time.Minute}, false},
+		{"100/15m", Limit{Requests: 100, Period: 15 * time.Minute}, false},
+		{" 5/s ", Limit{Requests: 5, Period: time.Second}, false},
+		{"10", Limit{}, true},
+		{"ten/m", Limit{}, true},
+		{"10/", Limit{}, true},
+		{"10/w", Limit{}, true},
+		{"10/0m", Limit{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.value)
+		if tt.wantErr {
+			if !errors.Is(err, errInvalidLimit) {
+				t.Errorf("ParseLimit(%q): expected errInvalidLimit, got %v", tt.value, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseLimit(%q): expected %+v, got %+v (%v)", tt.value, tt.expected, got, err)
+		}
+	}
+}
+
+// TestMemory_Take tests bursts, refills and the reported headers of a token bucket
+func TestMemory_Take(t *testing.T) {
+	m := NewMemory()
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+
+	for i := 0; i < 3; i++ {
+		result, _ := m.Take(nil, "client", limit)
+		if !result.Allowed || result.Remaining != 2-i {
+			t.Fatalf("Request %d: expected allowed with %d remaining, got %+v", i, 2-i, result)
+		}
+	}
+
+	result, _ := m.Take(nil, "client", limit)
+	if result.Allowed || result.RetryAfter != time.Second || result.Reset != 3*time.Second {
+		t.Errorf("Expected a limited request retryable after 1s, got %+v", result)
+	}
+
+	if other, _ := m.Take(nil, "other", limit); !other.Allowed {
+		t.Error("Expected buckets of other clients to be independent")
+	}
+
+	now = now.Add(time.Second)
+	if result, _ = m.Take(nil, "client", limit); !result.Allowed || result.Remaining != 0 {
+		t.Errorf("Expected one refilled token after 1s, got %+v", result)
+	}
+}
+
+// TestLimit_String tests the RateLimit-Policy value of a limit
+func TestLimit_String(t *testing.T) {
+	if got := (Limit{Requests: 10, Period: time.Minute}).String(); got != "10;w=60" {
+		t.Errorf("Expected 10;w=60, got %s", got)
+	}
+}
+
+// TestFailureLimit tests that only failed attempts count and that a client IP that used them
+// up is refused before its next attempt
+func TestFailureLimit(t *testing.T) {
+	m := NewMemory()
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	failures := NewLimiter(m).Failures("auth_failures", Limit{Requests: 2, Period: 2 * time.Minute})
+
+	client := &gofr.Context{Context: middleware.WithRequestHeaders(context.Background(), nil, "192.0.2.1:4000")}
+	other := &gofr.Context{Context: middleware.WithRequestHeaders(context.Background(), nil, "192.0.2.2:4000")}
+
+	for i := 0; i < 2; i++ {
+		if err := failures.Check(client); err != nil {
+			t.Fatalf("Attempt %d: expected to be allowed, got %v", i, err)
+		}
+		failures.Fail(client)
+	}
+	if err := failures.Check(client); !errors.Is(err, ErrLimited) {
+		t.Errorf("Expected the client to be refused after 2 failures, got %v", err)
+	}
+	if err := failures.Check(other); err != nil {
+		t.Errorf("Expected other client IPs to be allowed, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := failures.Check(client); err != nil {
+		t.Errorf("Expected an attempt to be allowed after a refill, got %v", err)
+	}
+}
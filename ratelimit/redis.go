@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"errors"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var errUnexpectedReply = errors.New("unexpected rate limit script reply")
+
+// takeScript refills and takes from a token bucket atomically, using the Redis clock so
+// instances with skewed clocks share buckets correctly. It mirrors take and returns whether
+// the request is allowed and the tokens left, in thousandths of a token. With ARGV[3] "0" it
+// only peeks, leaving the bucket as it is.
+const takeScript = `
+local capacity = tonumber(ARGV[1])
+local refill = tonumber(ARGV[2])
+local consume = ARGV[3] ~= '0'
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = capacity
+elseif now > updated then
+	tokens = math.min(capacity, tokens + (now - updated) / refill)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+if consume then
+	redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+	redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) * refill) + 1000)
+end
+return {allowed, math.floor(tokens * 1000)}
+`
+
+// Redis keeps token buckets in GoFr's Redis datasource so every instance shares them.
+// It uses a memory store when no Redis connection is configured or Redis fails.
+type Redis struct {
+	fallback *Memory
+}
+
+// NewRedis creates a Redis bucket store that falls back to fallback
+func NewRedis(fallback *Memory) *Redis {
+	return &Redis{fallback: fallback}
+}
+
+// Take takes a token from the bucket of key
+func (r *Redis) Take(ctx *gofr.Context, key string, limit Limit) (Result, error) {
+	return r.run(ctx, key, limit, true)
+}
+
+// Peek returns the result Take would return, without taking a token
+func (r *Redis) Peek(ctx *gofr.Context, key string, limit Limit) (Result, error) {
+	return r.run(ctx, key, limit, false)
+}
+
+// run runs takeScript on the bucket of key, taking a token when consume is set
+func (r *Redis) run(ctx *gofr.Context, key string, limit Limit, consume bool) (Result, error) {
+	fallback := r.fallback.Peek
+	if consume {
+		fallback = r.fallback.Take
+	}
+	if ctx.Redis == nil {
+		return fallback(ctx, key, limit)
+	}
+
+	take := 0
+	if consume {
+		take = 1
+	}
+	refill := limit.refillInterval()
+	reply, err := ctx.Redis.Eval(ctx, takeScript, []string{key}, limit.Requests, refill.Milliseconds(), take).Int64Slice()
+	if err == nil && len(reply) != 2 {
+		err = errUnexpectedReply
+	}
+	if err != nil {
+		ctx.Logger.Errorf("Rate limit check in Redis failed, limiting this instance only: %v", err)
+		return fallback(ctx, key, limit)
+	}
+
+	tokens := float64(reply[1]) / 1000
+	result := Result{
+		Allowed:   reply[0] == 1,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(limit.Requests) - tokens) * float64(refill)),
+	}
+	if !result.Allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(refill))
+	}
+	return result, nil
+}
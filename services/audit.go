@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+)
+
+// ActorHeader names whoever a request claims to act for. It is not verified, so it is only
+// recorded beside the authenticated actor of audit entries.
+const ActorHeader = "X-Actor"
+
+// anonymousActor is recorded when a request is not authenticated
+const anonymousActor = "anonymous"
+
+// PostAuditEntries builds the audit entries for a post mutation.
+// before is nil for creates and after is nil for deletes. An update that changes the
+// status is recorded both as an update and as a status change.
+func PostAuditEntries(before, after *models.Post) ([]models.AuditEntry, error) {
+	switch {
+	case before == nil:
+		entry, err := postAuditEntry(models.AuditCreate, after.ID, diffPosts(models.PostFields, nil, after))
+		if err != nil {
+			return nil, err
+		}
+		return []models.AuditEntry{entry}, nil
+
+	case after == nil:
+		entry, err := postAuditEntry(models.AuditDelete, before.ID, diffPosts(models.PostFields, before, nil))
+		if err != nil {
+			return nil, err
+		}
+		return []models.AuditEntry{entry}, nil
+	}
+
+	changed := before.ChangedFields(after)
+	entry, err := postAuditEntry(models.AuditUpdate, after.ID, diffPosts(changed, before, after))
+	if err != nil {
+		return nil, err
+	}
+	entries := []models.AuditEntry{entry}
+
+	if before.Status != after.Status {
+		statusEntry, statusErr := postAuditEntry(models.AuditStatusChange, after.ID,
+			diffPosts([]string{"status", "published_at"}, before, after))
+		if statusErr != nil {
+			return nil, statusErr
+		}
+		entries = append(entries, statusEntry)
+	}
+
+	return entries, nil
+}
+
+// stampAuditEntries records the actor, claimed actor, request id and client IP of ctx on entries
+func stampAuditEntries(ctx context.Context, entries []models.AuditEntry) {
+	actor := callerID(ctx)
+	claimedActor := middleware.RequestHeader(ctx, ActorHeader)
+	requestID := middleware.RequestID(ctx)
+	ip := middleware.ClientIP(ctx)
+
+	for i := range entries {
+		entries[i].Actor = actor
+		entries[i].ClaimedActor = claimedActor
+		entries[i].RequestID = requestID
+		entries[i].IP = ip
+	}
+}
+
+// postAuditEntry builds an audit entry about a post with JSON-encoded changes
+func postAuditEntry(action string, postID int, changes map[string]models.AuditChange) (models.AuditEntry, error) {
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return models.AuditEntry{}, err
+	}
+
+	return models.AuditEntry{
+		Action:     action,
+		EntityType: models.AuditEntityPost,
+		EntityID:   postID,
+		Changes:    encoded,
+	}, nil
+}
+
+// diffPosts returns the before and after values of fields; a nil post contributes null values
+func diffPosts(fields []string, before, after *models.Post) map[string]models.AuditChange {
+	var beforeValues, afterValues map[string]any
+	if before != nil {
+		beforeValues = before.Project(fields)
+	}
+	if after != nil {
+		afterValues = after.Project(fields)
+	}
+
+	changes := make(map[string]models.AuditChange, len(fields))
+	for _, field := range fields {
+		changes[field] = models.AuditChange{Before: beforeValues[field], After: afterValues[field]}
+	}
+	return changes
+}
+
+// callerID identifies the authenticated caller of ctx, such as "key:7" for an API key
+func callerID(ctx context.Context) string {
+	if principal := middleware.Principal(ctx); principal != nil {
+		return principal.ID
+	}
+	return anonymousActor
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// AuditService handles reading and retention of the audit log
+type AuditService struct {
+	auditStore *store.AuditStore
+	retention  time.Duration
+}
+
+// NewAuditService creates a new audit service instance.
+// Entries older than retention are purged by PurgeExpired; zero keeps them forever.
+func NewAuditService(auditStore *store.AuditStore, retention time.Duration) *AuditService {
+	return &AuditService{
+		auditStore: auditStore,
+		retention:  retention,
+	}
+}
+
+// ListEntries retrieves audit entries matching filter with pagination
+func (as *AuditService) ListEntries(ctx *gofr.Context, filter models.AuditFilter,
+	page, pageSize int) (*models.AuditListResponse, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	totalCount, err := as.auditStore.Count(ctx, filter)
+	if err != nil {
+		return nil, errors.Join(ErrAuditFailed, err)
+	}
+
+	entries, err := as.auditStore.List(ctx, filter)
+	if err != nil {
+		return nil, errors.Join(ErrAuditFailed, err)
+	}
+
+	return &models.AuditListResponse{
+		Entries:    entries,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalCount + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// PurgeExpired deletes entries older than the retention period.
+// It is registered as a GoFr cron job.
+func (as *AuditService) PurgeExpired(ctx *gofr.Context) {
+	if as.retention <= 0 {
+		return
+	}
+
+	purged, err := as.auditStore.Purge(ctx, time.Now().Add(-as.retention))
+	if err != nil {
+		ctx.Logger.Errorf("Failed to purge audit log: %v", err)
+		return
+	}
+
+	if purged > 0 {
+		ctx.Logger.Infof("Purged %d audit log entries older than %s", purged, as.retention)
+	}
+}
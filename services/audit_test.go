@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+)
+
+// TestPostAuditEntries_Update tests that updates record only changed fields and add a status change entry
+func TestPostAuditEntries_Update(t *testing.T) {
+	before := &models.Post{ID: 7, Title: "Draft title", Content: "Body", Status: "draft"}
+	after := &models.Post{ID: 7, Title: "Final title", Content: "Body", Status: "published"}
+
+	entries, err := PostAuditEntries(before, after)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected update and status_change entries, got %d", len(entries))
+	}
+	if entries[0].Action != models.AuditUpdate || entries[1].Action != models.AuditStatusChange {
+		t.Errorf("Unexpected actions %q, %q", entries[0].Action, entries[1].Action)
+	}
+	if entries[0].EntityType != models.AuditEntityPost || entries[0].EntityID != 7 {
+		t.Errorf("Expected post 7, got %s %d", entries[0].EntityType, entries[0].EntityID)
+	}
+
+	var changes map[string]models.AuditChange
+	if err := json.Unmarshal(entries[0].Changes, &changes); err != nil {
+		t.Fatalf("Expected JSON changes, got %v", err)
+	}
+	if _, ok := changes["content"]; ok {
+		t.Error("Expected unchanged content to be left out of the diff")
+	}
+	if changes["title"].Before != "Draft title" || changes["title"].After != "Final title" {
+		t.Errorf("Unexpected title change %+v", changes["title"])
+	}
+}
+
+// TestPostAuditEntries_CreateAndDelete tests that creates and deletes snapshot every field against null
+func TestPostAuditEntries_CreateAndDelete(t *testing.T) {
+	post := &models.Post{ID: 3, Title: "Hello", Status: "draft"}
+
+	created, err := PostAuditEntries(nil, post)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	deleted, err := PostAuditEntries(post, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(created) != 1 || created[0].Action != models.AuditCreate {
+		t.Fatalf("Expected one create entry, got %+v", created)
+	}
+	if len(deleted) != 1 || deleted[0].Action != models.AuditDelete {
+		t.Fatalf("Expected one delete entry, got %+v", deleted)
+	}
+
+	var changes map[string]models.AuditChange
+	if err := json.Unmarshal(created[0].Changes, &changes); err != nil {
+		t.Fatalf("Expected JSON changes, got %v", err)
+	}
+	if len(changes) != len(models.PostFields) {
+		t.Errorf("Expected %d fields, got %d", len(models.PostFields), len(changes))
+	}
+	if changes["title"].Before != nil || changes["title"].After != "Hello" {
+		t.Errorf("Unexpected title change %+v", changes["title"])
+	}
+}
+
+// TestStampAuditEntries tests that the actor is the authenticated caller and X-Actor only a claim
+func TestStampAuditEntries(t *testing.T) {
+	ctx := middleware.WithRequestHeaders(context.Background(), http.Header{ActorHeader: {"alice"}}, "198.51.100.1:4000")
+
+	entries := []models.AuditEntry{{Action: models.AuditCreate}}
+	stampAuditEntries(ctx, entries)
+	if entries[0].Actor != anonymousActor || entries[0].ClaimedActor != "alice" || entries[0].IP != "198.51.100.1" {
+		t.Errorf("Unexpected anonymous entry %+v", entries[0])
+	}
+
+	ctx = middleware.WithPrincipal(ctx, &models.Principal{ID: "key:7", Role: models.RoleEditor})
+	stampAuditEntries(ctx, entries)
+	if entries[0].Actor != "key:7" || entries[0].ClaimedActor != "alice" {
+		t.Errorf("Expected actor key:7 claiming alice, got %q claiming %q", entries[0].Actor, entries[0].ClaimedActor)
+	}
+}
@@ -0,0 +1,537 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/backup"
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ErrInvalidStrategy is returned for restore strategies other than skip, overwrite and rename
+var ErrInvalidStrategy = errors.New("invalid restore strategy, use skip, overwrite or rename")
+
+// BackupService writes the posts and the other tables the service owns to portable backup
+// archives and restores them: tags, translations, users and their API keys, webhook
+// subscriptions and the audit log. Restored posts are validated and audited and drop the
+// cache, but publish no events.
+type BackupService struct {
+	postService   *PostService
+	validate      func(models.CreatePostRequest) error
+	webhookSender *WebhookSender
+}
+
+// NewBackupService creates a backup service validating restored posts with validate
+func NewBackupService(postService *PostService, validate func(models.CreatePostRequest) error) *BackupService {
+	return &BackupService{
+		postService: postService,
+		validate:    validate,
+	}
+}
+
+// SetWebhookSender checks the URLs of restored webhook subscriptions like those of new ones
+func (bs *BackupService) SetWebhookSender(sender *WebhookSender) {
+	bs.webhookSender = sender
+}
+
+// Backup writes every post and the records of the other tables to w as a backup archive, read
+// from one repeatable-read snapshot. Posts and audit entries are read a page at a time. It
+// returns the number of posts written, including on failure, when the archive is left incomplete.
+func (bs *BackupService) Backup(ctx *gofr.Context, w io.Writer) (int, error) {
+	archive, err := backup.NewWriter(w)
+	if err != nil {
+		return 0, errors.Join(ErrBackupFailed, err)
+	}
+
+	count := 0
+	err = store.RunInTx(ctx, store.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true},
+		func(uow *store.UnitOfWork) error {
+			if err := bs.backupPosts(ctx, uow, archive, &count); err != nil {
+				return err
+			}
+			return bs.backupRecords(ctx, uow, archive)
+		})
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		return count, errors.Join(ErrBackupFailed, err)
+	}
+	return count, nil
+}
+
+// backupPosts writes every post to archive, counting them in count
+func (bs *BackupService) backupPosts(ctx *gofr.Context, uow *store.UnitOfWork, archive *backup.Writer, count *int) error {
+	var after *models.PostCursor
+	for {
+		posts, err := uow.Posts.GetPostsPage(ctx, models.PostFilter{}, after, MaxPostPageSize)
+		if err != nil {
+			return err
+		}
+		for i := range posts {
+			if err = archive.AddPost(&posts[i]); err != nil {
+				return err
+			}
+			*count++
+		}
+		if len(posts) < MaxPostPageSize {
+			return nil
+		}
+		last := posts[len(posts)-1]
+		after = &models.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// backupRecords writes the records of the tables besides posts to archive
+func (bs *BackupService) backupRecords(ctx *gofr.Context, uow *store.UnitOfWork, archive *backup.Writer) error {
+	tags, err := uow.Tags.All(ctx)
+	if err == nil {
+		err = addRecords(archive, backup.EntityTags, tags)
+	}
+	if err != nil {
+		return err
+	}
+
+	translations, err := uow.Translations.All(ctx)
+	if err == nil {
+		err = addRecords(archive, backup.EntityTranslations, translations)
+	}
+	if err != nil {
+		return err
+	}
+
+	users, err := uow.Users.ListUsers(ctx)
+	if err == nil {
+		err = addRecords(archive, backup.EntityUsers, users)
+	}
+	if err != nil {
+		return err
+	}
+
+	keys, err := uow.Users.ListAPIKeyRecords(ctx)
+	if err == nil {
+		err = addRecords(archive, backup.EntityAPIKeys, keys)
+	}
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := uow.Webhooks.ListSubscriptions(ctx)
+	if err == nil {
+		err = addRecords(archive, backup.EntityWebhooks, webhooks)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = archive.Begin(backup.EntityAudit); err != nil {
+		return err
+	}
+	var after int64
+	for {
+		entries, err := uow.Audit.Page(ctx, after, MaxPostPageSize)
+		if err != nil {
+			return err
+		}
+		for i := range entries {
+			if err = archive.Add(&entries[i]); err != nil {
+				return err
+			}
+		}
+		if len(entries) < MaxPostPageSize {
+			return nil
+		}
+		after = entries[len(entries)-1].ID
+	}
+}
+
+// addRecords writes records to archive as the file of entity
+func addRecords[T any](archive *backup.Writer, entity string, records []T) error {
+	if err := archive.Begin(entity); err != nil {
+		return err
+	}
+	for i := range records {
+		if err := archive.Add(&records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore restores a backup archive in one transaction, so a failure restores nothing. Posts
+// keep their archived id when it is free and are given a new one otherwise. Posts whose slug is
+// taken are skipped, overwrite the post holding it, or are restored under the slug with the
+// first free numeric suffix, as strategy says. The records of other tables follow their post or
+// user to its restored id; those of skipped posts are skipped, as are users whose email, API
+// keys whose hash and webhook subscriptions whose URL already exist. Audit entries are appended.
+func (bs *BackupService) Restore(ctx *gofr.Context, data []byte, strategy string) (*models.RestoreReport, error) {
+	switch strategy {
+	case models.RestoreSkip, models.RestoreOverwrite, models.RestoreRename:
+	default:
+		return nil, ErrInvalidStrategy
+	}
+
+	manifest, contents, err := backup.Read(data)
+	if err != nil {
+		return nil, errors.Join(ErrRestoreFailed, err)
+	}
+	if err = bs.validateContents(ctx, contents); err != nil {
+		return nil, errors.Join(ErrRestoreFailed, err)
+	}
+
+	report := &models.RestoreReport{
+		Strategy: strategy,
+		Version:  manifest.Version,
+		Remapped: map[int]int{},
+		Renamed:  []models.SlugChange{},
+		Records:  map[string]*models.RestoreCount{},
+	}
+	for _, entity := range []string{backup.EntityTags, backup.EntityTranslations, backup.EntityUsers,
+		backup.EntityAPIKeys, backup.EntityWebhooks, backup.EntityAudit} {
+		report.Records[entity] = &models.RestoreCount{}
+	}
+
+	var postIDs map[int]int
+	err = store.RunInTx(ctx, store.TxOptions{}, func(uow *store.UnitOfWork) error {
+		if postIDs, err = bs.restorePosts(ctx, uow, contents.Posts, strategy, report); err != nil {
+			return err
+		}
+		return bs.restoreRecords(ctx, uow, contents, postIDs, report)
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRestoreFailed, err)
+	}
+
+	if len(postIDs) > 0 {
+		restored := make([]int, 0, len(postIDs))
+		for _, id := range postIDs {
+			restored = append(restored, id)
+		}
+		bs.postService.afterCommit(ctx, restored...)
+	}
+	return report, nil
+}
+
+// validateContents checks the records of an archive before any is restored. Tags are
+// normalized in place, and webhook URLs are checked when a webhook sender is set.
+func (bs *BackupService) validateContents(ctx *gofr.Context, contents *backup.Contents) error {
+	for i := range contents.Posts {
+		if err := bs.validatePost(&contents.Posts[i]); err != nil {
+			return errors.Join(errors.New("post "+strconv.Itoa(contents.Posts[i].ID)), err)
+		}
+	}
+
+	for i := range contents.Tags {
+		tags, err := NormalizeTags(contents.Tags[i].Tags)
+		if err != nil {
+			return errors.Join(errors.New("tags of post "+strconv.Itoa(contents.Tags[i].PostID)), err)
+		}
+		contents.Tags[i].Tags = tags
+	}
+
+	for i := range contents.Users {
+		user := &contents.Users[i]
+		if err := ValidateUser(models.CreateUserRequest{Name: user.Name, Email: user.Email, Role: user.Role}); err != nil {
+			return errors.Join(errors.New("user "+strconv.Itoa(user.ID)), err)
+		}
+	}
+
+	if bs.webhookSender != nil {
+		for i := range contents.Webhooks {
+			if err := bs.webhookSender.CheckURL(ctx, contents.Webhooks[i].URL); err != nil {
+				return errors.Join(errors.New("webhook subscription "+strconv.Itoa(contents.Webhooks[i].ID)), err)
+			}
+		}
+	}
+	return nil
+}
+
+// restorePosts restores posts inside uow, filling report, and returns the ids the posts
+// created or overwritten have in this database, keyed by their archived id
+func (bs *BackupService) restorePosts(ctx *gofr.Context, uow *store.UnitOfWork, posts []models.Post, strategy string,
+	report *models.RestoreReport) (map[int]int, error) {
+	restored := make(map[int]int)
+	for i := range posts {
+		post := &posts[i]
+
+		holder, err := uow.Posts.GetPostBySlug(ctx, post.Slug)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			holder = nil
+		case err != nil:
+			return nil, err
+		}
+
+		var result *models.Post
+		switch {
+		case holder != nil && strategy == models.RestoreSkip:
+			report.Skipped++
+			continue
+		case holder != nil && strategy == models.RestoreOverwrite:
+			if result, err = uow.Posts.OverwritePost(ctx, holder.ID, post); err != nil {
+				return nil, err
+			}
+			if err = bs.postService.recordAudit(ctx, uow, holder, result); err != nil {
+				return nil, err
+			}
+			report.Overwritten++
+		default:
+			if holder != nil {
+				slug, err := bs.freeSlug(ctx, uow, post.Slug)
+				if err != nil {
+					return nil, err
+				}
+				report.Renamed = append(report.Renamed, models.SlugChange{ID: post.ID, Title: post.Title,
+					From: post.Slug, To: slug})
+				post.Slug = slug
+			}
+			if result, err = bs.insertPost(ctx, uow, post); err != nil {
+				return nil, err
+			}
+			report.Created++
+		}
+
+		if result.ID != post.ID {
+			report.Remapped[post.ID] = result.ID
+		}
+		restored[post.ID] = result.ID
+	}
+
+	if report.Created > 0 {
+		if err := uow.Posts.ResetIDSequence(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return restored, nil
+}
+
+// restoreRecords restores the records of the tables besides posts inside uow, filling report.
+// postIDs maps the archived ids of the restored posts to their ids in this database.
+func (bs *BackupService) restoreRecords(ctx *gofr.Context, uow *store.UnitOfWork, contents *backup.Contents,
+	postIDs map[int]int, report *models.RestoreReport) error {
+	if err := bs.restoreTags(ctx, uow, contents.Tags, postIDs, report.Records[backup.EntityTags]); err != nil {
+		return err
+	}
+	err := bs.restoreTranslations(ctx, uow, contents.Translations, postIDs, report.Records[backup.EntityTranslations])
+	if err != nil {
+		return err
+	}
+
+	userIDs, err := bs.restoreUsers(ctx, uow, contents.Users, report.Records[backup.EntityUsers])
+	if err != nil {
+		return err
+	}
+	if err = bs.restoreAPIKeys(ctx, uow, contents.APIKeys, userIDs, report.Records[backup.EntityAPIKeys]); err != nil {
+		return err
+	}
+
+	if err = bs.restoreWebhooks(ctx, uow, contents.Webhooks, report.Records[backup.EntityWebhooks]); err != nil {
+		return err
+	}
+	return bs.restoreAudit(ctx, uow, contents.Audit, postIDs, report.Records[backup.EntityAudit])
+}
+
+// restoreTags replaces the tags of the restored posts with their archived tags
+func (bs *BackupService) restoreTags(ctx *gofr.Context, uow *store.UnitOfWork, records []models.PostTags,
+	postIDs map[int]int, count *models.RestoreCount) error {
+	for i := range records {
+		postID, ok := postIDs[records[i].PostID]
+		if !ok {
+			count.Skipped++
+			continue
+		}
+		if err := uow.Tags.SetPostTags(ctx, postID, records[i].Tags); err != nil {
+			return err
+		}
+		count.Restored++
+	}
+	return nil
+}
+
+// restoreTranslations restores the translations of the restored posts, skipping those whose
+// slug another post's translation holds in the same locale
+func (bs *BackupService) restoreTranslations(ctx *gofr.Context, uow *store.UnitOfWork, translations []models.PostTranslation,
+	postIDs map[int]int, count *models.RestoreCount) error {
+	for i := range translations {
+		translation := &translations[i]
+		postID, ok := postIDs[translation.PostID]
+		if !ok {
+			count.Skipped++
+			continue
+		}
+
+		holder, err := uow.Translations.GetBySlug(ctx, translation.Locale, translation.Slug)
+		switch {
+		case err == nil && holder.PostID != postID:
+			count.Skipped++
+			continue
+		case err != nil && !errors.Is(err, store.ErrNotFound):
+			return err
+		}
+
+		if _, err = uow.Translations.Restore(ctx, postID, translation); err != nil {
+			return err
+		}
+		count.Restored++
+	}
+	return nil
+}
+
+// restoreUsers restores the users whose email is not taken and returns the ids the archived
+// users have in this database, including those matched by email, keyed by their archived id
+func (bs *BackupService) restoreUsers(ctx *gofr.Context, uow *store.UnitOfWork, users []models.User,
+	count *models.RestoreCount) (map[int]int, error) {
+	userIDs := make(map[int]int, len(users))
+	for i := range users {
+		user := &users[i]
+		existing, err := uow.Users.GetUserByEmail(ctx, user.Email)
+		switch {
+		case err == nil:
+			userIDs[user.ID] = existing.ID
+			count.Skipped++
+			continue
+		case !errors.Is(err, store.ErrNotFound):
+			return nil, err
+		}
+
+		restored, err := uow.Users.RestoreUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		userIDs[user.ID] = restored.ID
+		count.Restored++
+	}
+	return userIDs, nil
+}
+
+// restoreAPIKeys restores the API keys of the archived users whose hash is not taken
+func (bs *BackupService) restoreAPIKeys(ctx *gofr.Context, uow *store.UnitOfWork, keys []models.APIKeyRecord,
+	userIDs map[int]int, count *models.RestoreCount) error {
+	for i := range keys {
+		userID, ok := userIDs[keys[i].UserID]
+		if !ok {
+			count.Skipped++
+			continue
+		}
+
+		_, err := uow.Users.RestoreAPIKey(ctx, userID, &keys[i])
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			count.Skipped++
+		case err != nil:
+			return err
+		default:
+			count.Restored++
+		}
+	}
+	return nil
+}
+
+// restoreWebhooks restores the webhook subscriptions whose URL no subscription has
+func (bs *BackupService) restoreWebhooks(ctx *gofr.Context, uow *store.UnitOfWork, subs []models.WebhookSubscription,
+	count *models.RestoreCount) error {
+	for i := range subs {
+		_, err := uow.Webhooks.RestoreSubscription(ctx, &subs[i])
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			count.Skipped++
+		case err != nil:
+			return err
+		default:
+			count.Restored++
+		}
+	}
+	return nil
+}
+
+// restoreAudit appends the archived audit entries, pointing those about posts at the restored
+// posts and skipping those of posts that were not restored
+func (bs *BackupService) restoreAudit(ctx *gofr.Context, uow *store.UnitOfWork, entries []models.AuditEntry,
+	postIDs map[int]int, count *models.RestoreCount) error {
+	for i := range entries {
+		entry := &entries[i]
+		if entry.EntityType == models.AuditEntityPost {
+			postID, ok := postIDs[entry.EntityID]
+			if !ok {
+				count.Skipped++
+				continue
+			}
+			entry.EntityID = postID
+		}
+		if err := uow.Audit.Restore(ctx, *entry); err != nil {
+			return err
+		}
+		count.Restored++
+	}
+	return nil
+}
+
+// insertPost inserts a restored post, keeping its archived id when no post holds it
+func (bs *BackupService) insertPost(ctx *gofr.Context, uow *store.UnitOfWork, post *models.Post) (*models.Post, error) {
+	id := post.ID
+	if id > 0 {
+		_, err := uow.Posts.GetPostByID(ctx, id, nil)
+		switch {
+		case err == nil:
+			id = 0
+		case !errors.Is(err, store.ErrNotFound):
+			return nil, err
+		}
+	}
+
+	created, err := uow.Posts.RestorePost(ctx, post, id)
+	if err != nil {
+		return nil, err
+	}
+	return created, bs.postService.recordAudit(ctx, uow, nil, created)
+}
+
+// freeSlug returns slug with the first numeric suffix that no post holds
+func (bs *BackupService) freeSlug(ctx *gofr.Context, uow *store.UnitOfWork, slug string) (string, error) {
+	for n := 2; ; n++ {
+		candidate := suffixedSlug(slug, n)
+		_, err := uow.Posts.GetPostBySlug(ctx, candidate)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return candidate, nil
+		case err != nil:
+			return "", err
+		}
+	}
+}
+
+// validatePost checks an archived post against the rules of created posts and fills in the
+// times an archive may lack
+func (bs *BackupService) validatePost(post *models.Post) error {
+	if bs.validate != nil {
+		err := bs.validate(models.CreatePostRequest{
+			Title:           post.Title,
+			Content:         post.Content,
+			Slug:            post.Slug,
+			AuthorID:        post.AuthorID,
+			Status:          post.Status,
+			MetaTitle:       post.MetaTitle,
+			MetaDescription: post.MetaDescription,
+			CanonicalURL:    post.CanonicalURL,
+			OGImage:         post.OGImage,
+			NoIndex:         post.NoIndex,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now().UTC()
+	}
+	if post.UpdatedAt.IsZero() {
+		post.UpdatedAt = post.CreatedAt
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// MaxBulkOperations caps the number of operations in one bulk request
+const MaxBulkOperations = 100
+
+var (
+	errBulkAborted     = errors.New("not applied: another operation in the atomic batch failed")
+	errBulkRolledBack  = errors.New("rolled back: another operation in the atomic batch failed")
+	errUnknownBulkOp   = errors.New("unknown bulk operation")
+	errBulkTagsOff     = errors.New("tags cannot be set in bulk requests")
+	errBulkItemFailure = errors.New("bulk item failed")
+)
+
+// BulkPosts runs post operations in one transaction.
+// In atomic mode the first failure rolls every operation back; in best-effort mode each
+// operation runs under a savepoint so only the failed ones are undone.
+// results has one entry per operation, in order.
+func (ps *PostService) BulkPosts(ctx *gofr.Context, mode string,
+	ops []models.BulkOperation) (results []models.BulkItemResult, committed bool, err error) {
+	results = make([]models.BulkItemResult, len(ops))
+	for i := range ops {
+		results[i] = models.BulkItemResult{Index: ops[i].Index, Op: ops[i].Op, ID: ops[i].ID}
+	}
+
+	atomic := mode == models.BulkAtomic
+	failedAt := -1
+
+	err = ps.inTx(ctx, OpBulkPosts, func(uow *store.UnitOfWork) error {
+		for i := range ops {
+			if !atomic {
+				if spErr := uow.Savepoint("bulk_item"); spErr != nil {
+					return spErr
+				}
+			}
+
+			post, opErr := ps.runBulkOperation(ctx, uow, &ops[i])
+			if opErr != nil {
+				results[i].Error = opErr.Error()
+				if atomic {
+					failedAt = i
+					return errBulkItemFailure
+				}
+				if spErr := uow.RollbackTo("bulk_item"); spErr != nil {
+					return spErr
+				}
+				continue
+			}
+
+			if !atomic {
+				if spErr := uow.Release("bulk_item"); spErr != nil {
+					return spErr
+				}
+			}
+			results[i].Success = true
+			results[i].Post = post
+			if post != nil {
+				results[i].ID = post.ID
+			}
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errBulkItemFailure):
+		markAtomicFailure(results, failedAt)
+		return results, false, nil
+	case err != nil:
+		return nil, false, errors.Join(ErrBulkFailed, err)
+	}
+
+	ps.afterCommit(ctx, bulkPostIDs(results)...)
+
+	ctx.Logger.Infof("Bulk operation committed %d operations", len(ops))
+	return results, true, nil
+}
+
+// runBulkOperation applies one bulk operation and returns the resulting post, or nil after a delete
+func (ps *PostService) runBulkOperation(ctx *gofr.Context, uow *store.UnitOfWork, op *models.BulkOperation) (*models.Post, error) {
+	switch op.Op {
+	case models.BulkCreate:
+		return ps.createPost(ctx, uow, *op.Create)
+	case models.BulkUpdate:
+		return ps.updatePost(ctx, uow, op.ID, *op.Update)
+	case models.BulkStatus:
+		return ps.updatePost(ctx, uow, op.ID, models.UpdatePostRequest{Status: op.Status})
+	case models.BulkDelete:
+		return nil, ps.deletePost(ctx, uow, op.ID)
+	case models.BulkTags:
+		if ps.tags == nil {
+			return nil, errBulkTagsOff
+		}
+		return ps.tags.replace(ctx, uow, op.ID, op.Tags)
+	}
+	return nil, errUnknownBulkOp
+}
+
+// bulkPostIDs returns the ids of the posts changed by the successful operations of a batch
+func bulkPostIDs(results []models.BulkItemResult) []int {
+	ids := make([]int, 0, len(results))
+	for i := range results {
+		if results[i].Success && results[i].ID > 0 {
+			ids = append(ids, results[i].ID)
+		}
+	}
+	return ids
+}
+
+// markAtomicFailure reports every operation of a rolled back atomic batch as failed.
+// Operations before the failing one were rolled back and the ones after it never ran.
+func markAtomicFailure(results []models.BulkItemResult, failedAt int) {
+	for i := range results {
+		switch {
+		case i < failedAt:
+			results[i].Error = errBulkRolledBack.Error()
+		case i > failedAt:
+			results[i].Error = errBulkAborted.Error()
+		}
+		if results[i].Op == models.BulkCreate {
+			results[i].ID = 0
+		}
+		results[i].Success = false
+		results[i].Post = nil
+	}
+}
+
+// AbortedBulkResults reports every operation of an atomic batch that was rejected before it ran
+func AbortedBulkResults(ops []models.BulkOperation) []models.BulkItemResult {
+	results := make([]models.BulkItemResult, len(ops))
+	for i := range ops {
+		results[i] = models.BulkItemResult{Index: ops[i].Index, Op: ops[i].Op, ID: ops[i].ID, Error: errBulkAborted.Error()}
+	}
+	return results
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/sqltest"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// TestMarkAtomicFailure tests that a failed atomic batch reports rolled back, failing and skipped operations
+func TestMarkAtomicFailure(t *testing.T) {
+	results := []models.BulkItemResult{
+		{Index: 0, Op: models.BulkCreate, ID: 10, Success: true, Post: &models.Post{ID: 10}},
+		{Index: 1, Op: models.BulkUpdate, ID: 4, Error: "record not found"},
+		{Index: 2, Op: models.BulkDelete, ID: 5},
+	}
+
+	markAtomicFailure(results, 1)
+
+	for _, result := range results {
+		if result.Success || result.Post != nil {
+			t.Errorf("Expected operation %d to be reported as failed, got %+v", result.Index, result)
+		}
+	}
+	if results[0].Error != errBulkRolledBack.Error() || results[0].ID != 0 {
+		t.Errorf("Expected rolled back create without an ID, got %+v", results[0])
+	}
+	if results[1].Error != "record not found" {
+		t.Errorf("Expected the failing operation to keep its error, got %q", results[1].Error)
+	}
+	if results[2].Error != errBulkAborted.Error() {
+		t.Errorf("Expected later operations to be skipped, got %q", results[2].Error)
+	}
+}
+
+// bulkTestPost returns the row of a post as the posts table would
+func bulkTestPost(id int64, status string) sqltest.Result {
+	now := time.Now()
+	return sqltest.Result{
+		Columns: models.PostFields,
+		Rows: [][]driver.Value{{id, "Post", "Body", "post-" + strconv.FormatInt(id, 10), int64(1), status,
+			now, now, nil, "", "", "", "", false}},
+	}
+}
+
+// newBulkTestService returns a post service whose transactions run on a recording database.
+// Posts are drafts, missingID does not exist and fanning out events of failingID fails.
+func newBulkTestService(missingID, failingID int64) (*PostService, *sqltest.DB, *PostWatch) {
+	db := sqltest.Open(func(query string, args []driver.Value) sqltest.Result {
+		switch {
+		case strings.Contains(query, "FOR UPDATE"):
+			if args[0] == missingID {
+				return sqltest.Result{Columns: models.PostFields}
+			}
+			return bulkTestPost(args[0].(int64), "draft")
+		case strings.HasPrefix(query, "UPDATE posts") && len(args) == 1:
+			return bulkTestPost(args[0].(int64), "draft")
+		case strings.HasPrefix(query, "UPDATE posts"):
+			return bulkTestPost(args[len(args)-1].(int64), args[0].(string))
+		case strings.Contains(query, "webhook_deliveries") &&
+			bytes.Contains(args[2].([]byte), []byte(`"post_id":`+strconv.FormatInt(failingID, 10)+",")):
+			return sqltest.Result{Err: errors.New("deliveries unavailable")}
+		}
+		return sqltest.Result{}
+	})
+
+	ps := NewPostService(nil, nil, store.NewWebhookStore(), nil, NewPostEvents(DefaultEventTopics()))
+	ps.begin = func(*gofr.Context) (store.Tx, error) { return db.Begin() }
+	ps.SetTags(NewTagService(nil, ps))
+	watch := NewPostWatch(0, 10)
+	ps.SetWatch(watch)
+	return ps, db, watch
+}
+
+// transactionControl returns the statements of queries that begin, end or mark transactions
+func transactionControl(queries []string) []string {
+	var control []string
+	for _, query := range queries {
+		if query == sqltest.Begin || query == sqltest.Commit || query == sqltest.Rollback ||
+			strings.Contains(query, "SAVEPOINT") {
+			control = append(control, query)
+		}
+	}
+	return control
+}
+
+// TestBulkPosts_AtomicRollsBack tests that a failure in an atomic batch rolls the whole
+// transaction back, runs no later operation and publishes no event
+func TestBulkPosts_AtomicRollsBack(t *testing.T) {
+	ps, db, watch := newBulkTestService(404, 0)
+	ops := []models.BulkOperation{
+		{Index: 0, Op: models.BulkStatus, ID: 1, Status: "published"},
+		{Index: 1, Op: models.BulkStatus, ID: 404, Status: "published"},
+		{Index: 2, Op: models.BulkStatus, ID: 3, Status: "published"},
+	}
+
+	results, committed, err := ps.BulkPosts(newRelayTestContext(), models.BulkAtomic, ops)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if committed {
+		t.Error("Expected the batch not to commit")
+	}
+	for _, result := range results {
+		if result.Success {
+			t.Errorf("Expected operation %d to be reported as failed, got %+v", result.Index, result)
+		}
+	}
+	if control := transactionControl(db.Queries()); !slices.Equal(control, []string{sqltest.Begin, sqltest.Rollback}) {
+		t.Errorf("Expected the transaction to be rolled back without savepoints, got %v", control)
+	}
+	for _, statement := range db.Statements() {
+		if len(statement.Args) > 0 && statement.Args[len(statement.Args)-1] == int64(3) {
+			t.Errorf("Expected the operation after the failure not to run, got %q", statement.Query)
+		}
+	}
+	if events, _ := watch.Since(""); len(events) != 0 {
+		t.Errorf("Expected no events from a rolled back batch, got %d", len(events))
+	}
+}
+
+// TestBulkPosts_BestEffortRollsBackFailedItem tests that a failure in a best-effort batch rolls
+// back only its savepoint, drops the after-commit hooks it registered and commits the others
+func TestBulkPosts_BestEffortRollsBackFailedItem(t *testing.T) {
+	ps, db, watch := newBulkTestService(0, 2)
+	ops := []models.BulkOperation{
+		{Index: 0, Op: models.BulkStatus, ID: 1, Status: "published"},
+		{Index: 1, Op: models.BulkStatus, ID: 2, Status: "published"},
+		{Index: 2, Op: models.BulkTags, ID: 3, Tags: []string{"go"}},
+	}
+
+	results, committed, err := ps.BulkPosts(newRelayTestContext(), models.BulkBestEffort, ops)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !committed {
+		t.Error("Expected the batch to commit")
+	}
+	if !results[0].Success || results[1].Success || !results[2].Success {
+		t.Errorf("Expected only the second operation to fail, got %+v", results)
+	}
+	if results[2].Post == nil || !slices.Equal(results[2].Post.Tags, []string{"go"}) {
+		t.Errorf("Expected the tagged post, got %+v", results[2].Post)
+	}
+
+	expected := []string{
+		sqltest.Begin,
+		"SAVEPOINT bulk_item", "RELEASE SAVEPOINT bulk_item",
+		"SAVEPOINT bulk_item", "ROLLBACK TO SAVEPOINT bulk_item",
+		"SAVEPOINT bulk_item", "RELEASE SAVEPOINT bulk_item",
+		sqltest.Commit,
+	}
+	if control := transactionControl(db.Queries()); !slices.Equal(control, expected) {
+		t.Errorf("Expected statements %v, got %v", expected, control)
+	}
+
+	events, _ := watch.Since("")
+	var published []string
+	for _, event := range events {
+		published = append(published, strconv.Itoa(event.PostID)+" "+event.Type)
+	}
+	if !slices.Equal(published, []string{"1 post.updated", "1 post.published", "3 post.updated"}) {
+		t.Errorf("Expected the events of the committed operations only, got %v", published)
+	}
+}
@@ -11,4 +11,24 @@ var (
 	ErrUpdateFailed     = errors.New("failed to update post")
 	ErrDeleteFailed     = errors.New("failed to delete post")
 	ErrValidationFailed = errors.New("validation failed")
+	ErrUnsupportedPatch = errors.New("unsupported patch media type")
+	ErrBulkFailed       = errors.New("bulk operation failed")
+	ErrSEOFailed        = errors.New("failed to build SEO metadata")
+	ErrFeedFailed       = errors.New("failed to build feed")
+	ErrSitemapFailed    = errors.New("failed to build sitemap")
+	ErrSitemapNotFound  = errors.New("sitemap not found")
+	ErrPublishFailed    = errors.New("failed to publish event")
+	ErrWebhookFailed    = errors.New("webhook operation failed")
+	ErrAuditFailed      = errors.New("audit log operation failed")
+	ErrImportFailed     = errors.New("import operation failed")
+	ErrExportFailed     = errors.New("failed to export posts")
+	ErrBackupFailed     = errors.New("failed to back up posts")
+	ErrRestoreFailed    = errors.New("failed to restore backup")
+	ErrTranslateFailed  = errors.New("translation operation failed")
+	ErrTagFailed        = errors.New("tag operation failed")
+	ErrUserFailed       = errors.New("user operation failed")
+
+	ErrIdempotencyFailed     = errors.New("idempotency key operation failed")
+	ErrIdempotencyMismatch   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still in progress")
 )
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+// EventTopics maps each post event type to the pub/sub topic it is published on
+type EventTopics map[string]string
+
+// DefaultEventTopics publishes every event on a topic named after its type
+func DefaultEventTopics() EventTopics {
+	return EventTopics{
+		models.PostCreated:   models.PostCreated,
+		models.PostUpdated:   models.PostUpdated,
+		models.PostPublished: models.PostPublished,
+		models.PostDeleted:   models.PostDeleted,
+	}
+}
+
+// PostEvents turns post changes into domain events addressed to their pub/sub topics
+type PostEvents struct {
+	topics EventTopics
+}
+
+// NewPostEvents creates a new post event builder for the given topics
+func NewPostEvents(topics EventTopics) *PostEvents {
+	return &PostEvents{
+		topics: topics,
+	}
+}
+
+// Created returns post.created, and post.published when the post starts out published
+func (pe *PostEvents) Created(post *models.Post) []*models.PostEvent {
+	events := []*models.PostEvent{NewPostEvent(models.PostCreated, post, nil)}
+	if post.Status == "published" {
+		events = append(events, NewPostEvent(models.PostPublished, post, nil))
+	}
+	return events
+}
+
+// Updated returns post.updated with the changed fields, and post.published on a transition to published
+func (pe *PostEvents) Updated(before, after *models.Post) []*models.PostEvent {
+	changed := after.ChangedFields(before)
+	events := []*models.PostEvent{NewPostEvent(models.PostUpdated, after, changed)}
+	if before.Status != "published" && after.Status == "published" {
+		events = append(events, NewPostEvent(models.PostPublished, after, changed))
+	}
+	return events
+}
+
+// Deleted returns post.deleted with the last snapshot of the post
+func (pe *PostEvents) Deleted(post *models.Post) []*models.PostEvent {
+	return []*models.PostEvent{NewPostEvent(models.PostDeleted, post, nil)}
+}
+
+// OutboxMessages serializes events into outbox messages addressed to their configured topics
+func (pe *PostEvents) OutboxMessages(events []*models.PostEvent) ([]models.OutboxMessage, error) {
+	messages := make([]models.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		topic, ok := pe.topics[event.Type]
+		if !ok || topic == "" {
+			return nil, errors.Join(ErrPublishFailed, errors.New("no topic configured for "+event.Type))
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, errors.Join(ErrPublishFailed, err)
+		}
+
+		messages = append(messages, models.OutboxMessage{
+			AggregateID: event.PostID,
+			EventType:   event.Type,
+			Topic:       topic,
+			Payload:     payload,
+		})
+	}
+	return messages, nil
+}
+
+// NewPostEvent creates a versioned post event with a unique ID
+func NewPostEvent(eventType string, post *models.Post, changed []string) *models.PostEvent {
+	return &models.PostEvent{
+		ID:            newEventID(),
+		Type:          eventType,
+		Version:       models.PostEventVersion,
+		OccurredAt:    time.Now().UTC(),
+		PostID:        post.ID,
+		Post:          post,
+		ChangedFields: changed,
+	}
+}
+
+// newEventID returns a random 128-bit hex identifier
+func newEventID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+// TestPostEvents_OutboxMessages tests that events become versioned JSON addressed to their configured topic
+func TestPostEvents_OutboxMessages(t *testing.T) {
+	topics := DefaultEventTopics()
+	topics[models.PostUpdated] = "blog-post-updates"
+	events := NewPostEvents(topics)
+
+	before := &models.Post{ID: 3, Title: "Old title", Status: "draft"}
+	after := &models.Post{ID: 3, Title: "New title", Status: "published"}
+
+	messages, err := events.OutboxMessages(events.Updated(before, after))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("Expected post.updated and post.published messages, got %d", len(messages))
+	}
+	if messages[0].Topic != "blog-post-updates" || messages[1].Topic != models.PostPublished {
+		t.Errorf("Unexpected topics %q, %q", messages[0].Topic, messages[1].Topic)
+	}
+	if messages[0].AggregateID != 3 {
+		t.Errorf("Expected aggregate ID 3, got %d", messages[0].AggregateID)
+	}
+
+	var payload models.PostEvent
+	if err := json.Unmarshal(messages[0].Payload, &payload); err != nil {
+		t.Fatalf("Expected JSON payload, got %v", err)
+	}
+	if payload.Type != models.PostUpdated || payload.Version != models.PostEventVersion || payload.PostID != 3 {
+		t.Errorf("Unexpected event envelope %+v", payload)
+	}
+	if !reflect.DeepEqual(payload.ChangedFields, []string{"title", "status"}) {
+		t.Errorf("Unexpected changed fields %v", payload.ChangedFields)
+	}
+	if payload.Post == nil || payload.Post.Title != "New title" {
+		t.Errorf("Expected post snapshot in payload, got %+v", payload.Post)
+	}
+}
+
+// TestPostEvents_MissingTopic tests that an unconfigured topic is an error
+func TestPostEvents_MissingTopic(t *testing.T) {
+	events := NewPostEvents(EventTopics{})
+
+	_, err := events.OutboxMessages(events.Deleted(&models.Post{ID: 1}))
+	if !errors.Is(err, ErrPublishFailed) {
+		t.Errorf("Expected ErrPublishFailed for a missing topic, got %v", err)
+	}
+}
+
+// TestRetryBackoff tests exponential backoff with a cap
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{30, maxOutboxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := RetryBackoff(time.Second, tt.attempts, maxOutboxBackoff); got != tt.expected {
+			t.Errorf("Attempts %d: expected %v, got %v", tt.attempts, tt.expected, got)
+		}
+	}
+}
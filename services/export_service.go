@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/staticsite"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ExportService exports posts as the Markdown content of static sites
+type ExportService struct {
+	postStore *store.PostStore
+	site      SiteConfig
+	tags      *TagService
+}
+
+// NewExportService creates a new export service instance
+func NewExportService(postStore *store.PostStore, site SiteConfig) *ExportService {
+	return &ExportService{
+		postStore: postStore,
+		site:      site,
+	}
+}
+
+// SetTags writes the tags of posts into their front matter
+func (es *ExportService) SetTags(tags *TagService) {
+	es.tags = tags
+}
+
+// ExportSite writes the posts matching options to w as a zip archive of Markdown files, newest
+// first, a page at a time so the archive streams without holding every post. It returns the
+// number of posts written, including on failure, when the archive is left incomplete.
+func (es *ExportService) ExportSite(ctx *gofr.Context, w io.Writer, options models.ExportOptions) (int, error) {
+	archive, err := staticsite.NewArchive(w, options.Format, es.site.BaseURL)
+	if err != nil {
+		return 0, errors.Join(ErrExportFailed, err)
+	}
+
+	// Pages start just past To, so posts created at To are included
+	var after *models.PostCursor
+	if options.To != nil {
+		after = &models.PostCursor{CreatedAt: *options.To, ID: math.MaxInt32}
+	}
+
+	count := 0
+	filter := models.PostFilter{Status: options.Status}
+	for {
+		posts, err := es.postStore.GetPostsPage(ctx, filter, after, MaxPostPageSize)
+		if err != nil {
+			return count, errors.Join(ErrExportFailed, err)
+		}
+		if es.tags != nil {
+			if err = es.tags.Attach(ctx, posts); err != nil {
+				return count, errors.Join(ErrExportFailed, err)
+			}
+		}
+
+		for i := range posts {
+			if options.From != nil && posts[i].CreatedAt.Before(*options.From) {
+				return count, es.close(archive)
+			}
+			if err = archive.Add(&posts[i]); err != nil {
+				return count, errors.Join(ErrExportFailed, err)
+			}
+			count++
+		}
+
+		if len(posts) < MaxPostPageSize {
+			return count, es.close(archive)
+		}
+		last := posts[len(posts)-1]
+		after = &models.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// close finishes an archive
+func (es *ExportService) close(archive *staticsite.Archive) error {
+	if err := archive.Close(); err != nil {
+		return errors.Join(ErrExportFailed, err)
+	}
+	return nil
+}
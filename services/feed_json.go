@@ -0,0 +1,116 @@
+package services
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/markdown"
+	"gofr-blog-service/models"
+)
+
+// jsonFeedVersion is the JSON Feed specification implemented by BuildJSONFeed
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// jsonFeed is a JSON Feed 1.1 document
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description,omitempty"`
+	NextURL     string           `json:"next_url,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Language      string           `json:"language,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+
+	// Alternates is an extension listing the language versions of the post
+	Alternates []jsonFeedAlternate `json:"_alternates,omitempty"`
+}
+
+// jsonFeedAlternate is a language version of an item, in the _alternates extension
+type jsonFeedAlternate struct {
+	HrefLang string `json:"hreflang"`
+	URL      string `json:"url"`
+}
+
+// BuildJSONFeed renders one page of posts as a JSON Feed 1.1 document.
+// total is the number of posts matching the filter and decides whether next_url is set.
+func BuildJSONFeed(posts []models.Post, site SiteConfig, filter models.FeedFilter, total int) ([]byte, error) {
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       feedTitle(site, filter),
+		HomePageURL: site.URL("/"),
+		FeedURL:     jsonFeedURL(site, filter),
+		Description: "Latest posts from " + site.Name,
+		Authors:     []jsonFeedAuthor{{Name: site.Name}},
+		Items:       make([]jsonFeedItem, 0, len(posts)),
+	}
+
+	if filter.Offset+len(posts) < total {
+		next := filter
+		next.Offset += filter.Limit
+		feed.NextURL = jsonFeedURL(site, next)
+	}
+
+	for i := range posts {
+		post := &posts[i]
+		link := site.PostURL(post)
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            strconv.Itoa(post.ID),
+			URL:           link,
+			Title:         post.Title,
+			ContentHTML:   markdown.ToHTML(post.Content),
+			Summary:       Excerpt(post.Content, excerptLength),
+			Image:         post.OGImage,
+			DatePublished: publishedAt(post).UTC().Format(time.RFC3339),
+			DateModified:  post.UpdatedAt.UTC().Format(time.RFC3339),
+			Authors:       []jsonFeedAuthor{{Name: authorName(post.AuthorID)}},
+			Language:      site.Locale,
+			Tags:          post.Tags,
+			Alternates:    jsonFeedAlternates(site, post),
+		})
+	}
+
+	return json.Marshal(feed)
+}
+
+// jsonFeedAlternates returns the language versions of a post for the _alternates extension
+func jsonFeedAlternates(site SiteConfig, post *models.Post) []jsonFeedAlternate {
+	var alternates []jsonFeedAlternate
+	for _, version := range site.hreflangLinks(post) {
+		alternates = append(alternates, jsonFeedAlternate{HrefLang: version.HrefLang, URL: version.URL})
+	}
+	return alternates
+}
+
+// jsonFeedURL returns the public URL of a JSON feed page including its filter
+func jsonFeedURL(site SiteConfig, filter models.FeedFilter) string {
+	query := feedQuery(filter)
+	if filter.Limit > 0 && filter.Offset > 0 {
+		query.Set("page", strconv.Itoa(filter.Offset/filter.Limit+1))
+	}
+
+	feed := site.URL("/feed.json")
+	if len(query) > 0 {
+		feed += "?" + query.Encode()
+	}
+	return feed
+}
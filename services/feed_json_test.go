@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gofr-blog-service/models"
+)
+
+// TestBuildJSONFeed tests the JSON Feed 1.1 document and its next_url pagination
+func TestBuildJSONFeed(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://blog.example.com/", Name: "Example"}
+	filter := models.FeedFilter{AuthorID: 7, Limit: 1}
+
+	body, err := BuildJSONFeed(feedTestPosts(), site, filter, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var feed map[string]any
+	if err := json.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	if feed["version"] != "https://jsonfeed.org/version/1.1" {
+		t.Errorf("Unexpected version %v", feed["version"])
+	}
+	if feed["next_url"] != "https://blog.example.com/feed.json?author_id=7&page=2" {
+		t.Errorf("Unexpected next_url %v", feed["next_url"])
+	}
+
+	items, _ := feed["items"].([]any)
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(items))
+	}
+	if item := items[0].(map[string]any); item["content_html"] != "<p>Some <strong>bold</strong> content for the feed</p>\n" {
+		t.Errorf("Unexpected content_html %v", item["content_html"])
+	}
+}
+
+// TestBuildJSONFeed_TagFeed tests that tag feeds keep their tag across pages and items list their tags
+func TestBuildJSONFeed_TagFeed(t *testing.T) {
+	posts := feedTestPosts()
+	posts[0].Tags = []string{"go"}
+
+	body, err := BuildJSONFeed(posts, SiteConfig{BaseURL: "https://blog.example.com"},
+		models.FeedFilter{Tag: "go", Limit: 1}, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var feed struct {
+		NextURL string `json:"next_url"`
+		Items   []struct {
+			Tags []string `json:"tags"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if feed.NextURL != "https://blog.example.com/feed.json?page=2&tag=go" {
+		t.Errorf("Unexpected next_url %v", feed.NextURL)
+	}
+	if len(feed.Items) != 1 || len(feed.Items[0].Tags) != 1 || feed.Items[0].Tags[0] != "go" {
+		t.Errorf("Unexpected item tags %+v", feed.Items)
+	}
+}
+
+// TestBuildJSONFeed_LastPage tests that the last page has no next_url
+func TestBuildJSONFeed_LastPage(t *testing.T) {
+	body, err := BuildJSONFeed(feedTestPosts(), SiteConfig{BaseURL: "https://blog.example.com"},
+		models.FeedFilter{Limit: 1, Offset: 2}, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var feed map[string]any
+	if err := json.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if _, ok := feed["next_url"]; ok {
+		t.Errorf("Expected no next_url on the last page, got %v", feed["next_url"])
+	}
+	if feed["feed_url"] != "https://blog.example.com/feed.json?page=3" {
+		t.Errorf("Unexpected feed_url %v", feed["feed_url"])
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"errors"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// DefaultFeedSize is the number of posts included in a feed when no limit is given
+const DefaultFeedSize = 20
+
+// FeedService builds syndication feeds from published posts
+type FeedService struct {
+	postStore    *store.PostStore
+	translations *TranslationService
+	tags         *TagService
+	site         SiteConfig
+}
+
+// NewFeedService creates a new feed service instance
+func NewFeedService(postStore *store.PostStore, site SiteConfig) *FeedService {
+	return &FeedService{
+		postStore: postStore,
+		site:      site,
+	}
+}
+
+// SetTranslations links the translations of posts as hreflang alternates of feed items
+func (fs *FeedService) SetTranslations(translations *TranslationService) {
+	fs.translations = translations
+}
+
+// SetTags lists the tags of posts as categories of feed items
+func (fs *FeedService) SetTags(tags *TagService) {
+	fs.tags = tags
+}
+
+// GetFeedVersion returns the newest update time and post count behind a feed
+func (fs *FeedService) GetFeedVersion(ctx *gofr.Context, filter models.FeedFilter) (*models.FeedVersion, error) {
+	version, err := fs.postStore.GetPublishedPostsVersion(ctx, filter)
+	if err != nil {
+		return nil, errors.Join(ErrFeedFailed, err)
+	}
+	return version, nil
+}
+
+// RSS renders the RSS 2.0 feed of published posts matching the filter
+func (fs *FeedService) RSS(ctx *gofr.Context, filter models.FeedFilter, version *models.FeedVersion) ([]byte, error) {
+	posts, err := fs.publishedPosts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := BuildRSS(posts, fs.site, filter, version.LastModified)
+	if err != nil {
+		return nil, errors.Join(ErrFeedFailed, err)
+	}
+	return feed, nil
+}
+
+// Atom renders the Atom 1.0 feed of published posts matching the filter
+func (fs *FeedService) Atom(ctx *gofr.Context, filter models.FeedFilter, version *models.FeedVersion) ([]byte, error) {
+	posts, err := fs.publishedPosts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := BuildAtom(posts, fs.site, filter, version.LastModified)
+	if err != nil {
+		return nil, errors.Join(ErrFeedFailed, err)
+	}
+	return feed, nil
+}
+
+// JSONFeed renders one page of the JSON Feed 1.1 document of published posts matching the filter
+func (fs *FeedService) JSONFeed(ctx *gofr.Context, filter models.FeedFilter, version *models.FeedVersion) ([]byte, error) {
+	filter = normalizeFeedFilter(filter)
+
+	posts, err := fs.publishedPosts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := BuildJSONFeed(posts, fs.site, filter, version.Count)
+	if err != nil {
+		return nil, errors.Join(ErrFeedFailed, err)
+	}
+	return feed, nil
+}
+
+// publishedPosts loads the posts for a feed, applying the default feed size
+func (fs *FeedService) publishedPosts(ctx *gofr.Context, filter models.FeedFilter) ([]models.Post, error) {
+	posts, err := fs.postStore.GetPublishedPosts(ctx, normalizeFeedFilter(filter))
+	if err != nil {
+		return nil, errors.Join(ErrFeedFailed, err)
+	}
+
+	if fs.translations != nil {
+		if err = fs.translations.AttachAlternates(ctx, posts); err != nil {
+			return nil, errors.Join(ErrFeedFailed, err)
+		}
+	}
+	if fs.tags != nil {
+		if err = fs.tags.Attach(ctx, posts); err != nil {
+			return nil, errors.Join(ErrFeedFailed, err)
+		}
+	}
+	return posts, nil
+}
+
+// normalizeFeedFilter applies the default feed size to a filter
+func normalizeFeedFilter(filter models.FeedFilter) models.FeedFilter {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = DefaultFeedSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return filter
+}
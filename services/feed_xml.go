@@ -0,0 +1,241 @@
+package services
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/markdown"
+	"gofr-blog-service/models"
+)
+
+// rssDocument is the RSS 2.0 root element with the content module namespace
+type rssDocument struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description"`
+	Content     cdata    `xml:"content:encoded"`
+	Categories  []string `xml:"category"`
+
+	// Alternates link the language versions of the post through the Atom namespace
+	Alternates []atomLink `xml:"atom:link"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+// atomFeed is the Atom 1.0 root element
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Author    atomAuthor  `xml:"author"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Type     string `xml:"type,attr,omitempty"`
+	HrefLang string `xml:"hreflang,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+const feedGenerator = "gofr-blog-service"
+
+// BuildRSS renders posts as an RSS 2.0 document
+func BuildRSS(posts []models.Post, site SiteConfig, filter models.FeedFilter, lastModified time.Time) ([]byte, error) {
+	channel := rssChannel{
+		Title:         feedTitle(site, filter),
+		Link:          site.URL("/"),
+		Description:   "Latest posts from " + site.Name,
+		SelfLink:      atomLink{Href: feedURL(site, "/feed.rss", filter), Rel: "self", Type: "application/rss+xml"},
+		LastBuildDate: lastModified.UTC().Format(time.RFC1123Z),
+		Generator:     feedGenerator,
+	}
+
+	for i := range posts {
+		post := &posts[i]
+		link := site.PostURL(post)
+		channel.Items = append(channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        link,
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			PubDate:     publishedAt(post).UTC().Format(time.RFC1123Z),
+			Description: Excerpt(post.Content, excerptLength),
+			Content:     cdata{Value: markdown.ToHTML(post.Content)},
+			Categories:  post.Tags,
+			Alternates:  alternateLinks(site, post),
+		})
+	}
+
+	doc := rssDocument{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		Channel:   channel,
+	}
+
+	return marshalXML(doc)
+}
+
+// BuildAtom renders posts as an Atom 1.0 document
+func BuildAtom(posts []models.Post, site SiteConfig, filter models.FeedFilter, lastModified time.Time) ([]byte, error) {
+	selfURL := feedURL(site, "/feed.atom", filter)
+	feed := atomFeed{
+		ID:      selfURL,
+		Title:   feedTitle(site, filter),
+		Updated: lastModified.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: site.URL("/"), Rel: "alternate", Type: "text/html"},
+		},
+		Author:    atomAuthor{Name: site.Name},
+		Generator: feedGenerator,
+	}
+
+	for i := range posts {
+		post := &posts[i]
+		link := site.PostURL(post)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:         link,
+			Title:      post.Title,
+			Links:      append([]atomLink{{Href: link, Rel: "alternate", Type: "text/html"}}, alternateLinks(site, post)...),
+			Published:  publishedAt(post).UTC().Format(time.RFC3339),
+			Updated:    post.UpdatedAt.UTC().Format(time.RFC3339),
+			Author:     atomAuthor{Name: authorName(post.AuthorID)},
+			Categories: atomCategories(post.Tags),
+			Summary:    Excerpt(post.Content, excerptLength),
+			Content:    atomContent{Type: "html", Value: markdown.ToHTML(post.Content)},
+		})
+	}
+
+	return marshalXML(feed)
+}
+
+// alternateLinks returns the hreflang alternate links of the language versions of a post
+func alternateLinks(site SiteConfig, post *models.Post) []atomLink {
+	var links []atomLink
+	for _, version := range site.hreflangLinks(post) {
+		links = append(links, atomLink{Href: version.URL, Rel: "alternate", Type: "text/html", HrefLang: version.HrefLang})
+	}
+	return links
+}
+
+// atomCategories returns the Atom categories of tags
+func atomCategories(tags []string) []atomCategory {
+	var categories []atomCategory
+	for _, tag := range tags {
+		categories = append(categories, atomCategory{Term: tag})
+	}
+	return categories
+}
+
+// marshalXML renders v as an indented XML document with declaration
+func marshalXML(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// feedTitle names a feed after the site and, for author and tag feeds, the author and tag
+func feedTitle(site SiteConfig, filter models.FeedFilter) string {
+	title := site.Name
+	if filter.AuthorID > 0 {
+		title += " - " + authorName(filter.AuthorID)
+	}
+	if filter.Tag != "" {
+		title += " - #" + filter.Tag
+	}
+	return title
+}
+
+// feedURL returns the public URL of a feed including its filter
+func feedURL(site SiteConfig, path string, filter models.FeedFilter) string {
+	feed := site.URL(path)
+	if query := feedQuery(filter); len(query) > 0 {
+		feed += "?" + query.Encode()
+	}
+	return feed
+}
+
+// feedQuery returns the query parameters selecting the author and tag of a feed
+func feedQuery(filter models.FeedFilter) url.Values {
+	query := url.Values{}
+	if filter.AuthorID > 0 {
+		query.Set("author_id", strconv.Itoa(filter.AuthorID))
+	}
+	if filter.Tag != "" {
+		query.Set("tag", filter.Tag)
+	}
+	return query
+}
+
+// authorName returns the display name of an author; authors are referenced by ID only for now
+func authorName(authorID int) string {
+	return "Author " + strconv.Itoa(authorID)
+}
+
+// publishedAt returns the publication time of a post, falling back to its creation time
+func publishedAt(post *models.Post) time.Time {
+	if post.PublishedAt != nil {
+		return *post.PublishedAt
+	}
+	return post.CreatedAt
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+func feedTestPosts() []models.Post {
+	published := time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)
+	return []models.Post{{
+		ID:          1,
+		Title:       "Hello <Feeds>",
+		Content:     "Some **bold** content for the feed",
+		Slug:        "hello-feeds",
+		AuthorID:    7,
+		Status:      "published",
+		CreatedAt:   published.Add(-time.Hour),
+		UpdatedAt:   published.Add(time.Hour),
+		PublishedAt: &published,
+	}}
+}
+
+// TestBuildRSS tests that the RSS feed is well-formed and carries rendered content
+func TestBuildRSS(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://blog.example.com", Name: "Example"}
+	feed, err := BuildRSS(feedTestPosts(), site, models.FeedFilter{AuthorID: 7}, time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc struct {
+		Channel struct {
+			Items []struct {
+				Link    string `xml:"link"`
+				PubDate string `xml:"pubDate"`
+				Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(feed, &doc); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+
+	if len(doc.Channel.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(doc.Channel.Items))
+	}
+	item := doc.Channel.Items[0]
+	if item.Link != "https://blog.example.com/posts/hello-feeds" {
+		t.Errorf("Unexpected item link %q", item.Link)
+	}
+	if item.PubDate != "Sat, 01 Feb 2025 09:00:00 +0000" {
+		t.Errorf("Unexpected pubDate %q", item.PubDate)
+	}
+	if !strings.Contains(item.Content, "<strong>bold</strong>") {
+		t.Errorf("Expected rendered HTML content, got %q", item.Content)
+	}
+	if !strings.Contains(string(feed), "https://blog.example.com/feed.rss?author_id=7") {
+		t.Errorf("Expected author self link in feed")
+	}
+}
+
+// TestBuildAtom tests that the Atom feed is well-formed and uses the Atom namespace
+func TestBuildAtom(t *testing.T) {
+	site := SiteConfig{BaseURL: "https://blog.example.com", Name: "Example"}
+	feed, err := BuildAtom(feedTestPosts(), site, models.FeedFilter{}, time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Entries []struct {
+			Title     string `xml:"title"`
+			Published string `xml:"published"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(feed, &doc); err != nil {
+		t.Fatalf("Expected well-formed Atom XML, got %v", err)
+	}
+
+	if len(doc.Entries) != 1 || doc.Entries[0].Title != "Hello <Feeds>" {
+		t.Errorf("Unexpected entries %+v", doc.Entries)
+	}
+	if doc.Entries[0].Published != "2025-02-01T09:00:00Z" {
+		t.Errorf("Unexpected published date %q", doc.Entries[0].Published)
+	}
+}
+
+// TestBuildAtom_Alternates tests that entries of translated posts link their language versions
+func TestBuildAtom_Alternates(t *testing.T) {
+	posts := feedTestPosts()
+	posts[0].Alternates = []models.PostAlternate{{Locale: "ja", Slug: "hello-ja"}}
+	site := SiteConfig{BaseURL: "https://blog.example.com", Name: "Example", Locale: "en"}
+
+	feed, err := BuildAtom(posts, site, models.FeedFilter{}, time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc struct {
+		Entries []struct {
+			Links []struct {
+				Href     string `xml:"href,attr"`
+				HrefLang string `xml:"hreflang,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(feed, &doc); err != nil {
+		t.Fatalf("Expected well-formed Atom XML, got %v", err)
+	}
+
+	links := doc.Entries[0].Links
+	if len(links) != 3 || links[0].HrefLang != "" || links[1].HrefLang != "en" ||
+		links[2].HrefLang != "ja" || links[2].Href != "https://blog.example.com/ja/posts/hello-ja" {
+		t.Errorf("Unexpected entry links %+v", links)
+	}
+}
+
+// TestBuildRSS_TagFeed tests that a tag feed names and links its tag and items list their tags as categories
+func TestBuildRSS_TagFeed(t *testing.T) {
+	posts := feedTestPosts()
+	posts[0].Tags = []string{"go", "web-feeds"}
+	site := SiteConfig{BaseURL: "https://blog.example.com", Name: "Example"}
+
+	feed, err := BuildRSS(posts, site, models.FeedFilter{AuthorID: 7, Tag: "go"}, time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Categories []string `xml:"category"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(feed, &doc); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+
+	if doc.Channel.Title != "Example - Author 7 - #go" {
+		t.Errorf("Unexpected feed title %q", doc.Channel.Title)
+	}
+	if !strings.Contains(string(feed), "https://blog.example.com/feed.rss?author_id=7&amp;tag=go") {
+		t.Errorf("Expected author and tag self link in feed")
+	}
+	if categories := doc.Channel.Items[0].Categories; len(categories) != 2 || categories[1] != "web-feeds" {
+		t.Errorf("Unexpected item categories %v", categories)
+	}
+}
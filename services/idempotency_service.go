@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// idempotencyLockTimeout is how long an unfinished request holds its key before a retry may take it over
+const idempotencyLockTimeout = time.Minute
+
+// IdempotencyService records responses of requests made with an Idempotency-Key so retries
+// replay the original response instead of repeating the operation. Keys are scoped to the
+// authenticated caller, or to the client IP of anonymous requests.
+type IdempotencyService struct {
+	idempotencyStore *store.IdempotencyStore
+	ttl              time.Duration
+}
+
+// NewIdempotencyService creates a new idempotency service that keeps responses for ttl
+func NewIdempotencyService(idempotencyStore *store.IdempotencyStore, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		idempotencyStore: idempotencyStore,
+		ttl:              ttl,
+	}
+}
+
+// Begin claims key for the caller of ctx before operation runs with request.
+// It returns the stored response when the same request already completed, and nil when the
+// caller should go ahead, completing the key within the operation's transaction through
+// Completion, or releasing it when the operation fails.
+func (is *IdempotencyService) Begin(ctx *gofr.Context, key, operation string, request any) (json.RawMessage, error) {
+	hash, err := RequestHash(operation, request)
+	if err != nil {
+		return nil, errors.Join(ErrIdempotencyFailed, err)
+	}
+
+	claimed, record, err := is.idempotencyStore.Claim(ctx, idempotencyCaller(ctx), key, hash, is.ttl, idempotencyLockTimeout)
+	if err != nil {
+		return nil, errors.Join(ErrIdempotencyFailed, err)
+	}
+
+	switch {
+	case claimed:
+		return nil, nil
+	case record == nil:
+		return nil, ErrIdempotencyInProgress
+	case record.RequestHash != hash:
+		return nil, ErrIdempotencyMismatch
+	case record.Status != models.IdempotencyCompleted:
+		return nil, ErrIdempotencyInProgress
+	}
+
+	return record.Response, nil
+}
+
+// Completion returns a hook storing the response respond builds for the created post under
+// key, in the transaction creating the post. The key completes exactly when the post commits,
+// so a crash in between leaves neither and the retry creates the post once.
+func (is *IdempotencyService) Completion(ctx *gofr.Context, key, operation string, request any,
+	respond func(post *models.Post) any) PostHook {
+	return func(uow *store.UnitOfWork, post *models.Post) error {
+		hash, err := RequestHash(operation, request)
+		if err != nil {
+			return errors.Join(ErrIdempotencyFailed, err)
+		}
+		encoded, err := json.Marshal(respond(post))
+		if err != nil {
+			return errors.Join(ErrIdempotencyFailed, err)
+		}
+
+		if err = uow.Idempotency.Complete(ctx, idempotencyCaller(ctx), key, hash, encoded); err != nil {
+			return errors.Join(ErrIdempotencyFailed, err)
+		}
+		return nil
+	}
+}
+
+// Release gives up key after a failed request so a retry runs the operation again
+func (is *IdempotencyService) Release(ctx *gofr.Context, key string) {
+	if err := is.idempotencyStore.Release(ctx, idempotencyCaller(ctx), key); err != nil {
+		ctx.Logger.Errorf("Failed to release idempotency key: %v", err)
+	}
+}
+
+// PurgeExpired deletes keys past their TTL.
+// It is registered as a GoFr cron job.
+func (is *IdempotencyService) PurgeExpired(ctx *gofr.Context) {
+	purged, err := is.idempotencyStore.Purge(ctx)
+	if err != nil {
+		ctx.Logger.Errorf("Failed to purge idempotency keys: %v", err)
+		return
+	}
+
+	if purged > 0 {
+		ctx.Logger.Infof("Purged %d expired idempotency keys", purged)
+	}
+}
+
+// idempotencyCaller returns the scope of the keys of ctx: the authenticated caller, or else
+// the client IP. Anonymous clients behind one address share a scope, but cannot claim to be
+// anyone else.
+func idempotencyCaller(ctx context.Context) string {
+	if principal := middleware.Principal(ctx); principal != nil {
+		return principal.ID
+	}
+	return "ip:" + middleware.ClientIP(ctx)
+}
+
+// RequestHash fingerprints an operation and its decoded request body.
+// Hashing the decoded request makes retries match regardless of whitespace or key order.
+func RequestHash(operation string, request any) (string, error) {
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(append([]byte(operation+"\n"), encoded...))
+	return hex.EncodeToString(sum[:]), nil
+}
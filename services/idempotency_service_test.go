@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"gofr-blog-service/middleware"
+	"gofr-blog-service/models"
+)
+
+// TestRequestHash tests that request fingerprints depend on the operation and the decoded body only
+func TestRequestHash(t *testing.T) {
+	req := models.CreatePostRequest{Title: "Hello", Content: "World", AuthorID: 1}
+
+	first, err := RequestHash("POST /posts", req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	retry, _ := RequestHash("POST /posts", req)
+	if first != retry {
+		t.Errorf("Expected identical requests to hash equally, got %s and %s", first, retry)
+	}
+
+	req.Title = "Hello again"
+	changed, _ := RequestHash("POST /posts", req)
+	if changed == first {
+		t.Error("Expected a different body to change the hash")
+	}
+
+	otherOperation, _ := RequestHash("PUT /posts/1", models.CreatePostRequest{Title: "Hello", Content: "World", AuthorID: 1})
+	if otherOperation == first {
+		t.Error("Expected a different operation to change the hash")
+	}
+}
+
+// TestIdempotencyCaller tests that keys are scoped to the authenticated caller, not to X-Actor
+func TestIdempotencyCaller(t *testing.T) {
+	ctx := middleware.WithRequestHeaders(context.Background(), http.Header{ActorHeader: {"alice"}}, "198.51.100.1:4000")
+	if caller := idempotencyCaller(ctx); caller != "ip:198.51.100.1" {
+		t.Errorf("Expected the client IP for anonymous requests, got %q", caller)
+	}
+
+	ctx = middleware.WithPrincipal(ctx, &models.Principal{ID: "key:7", Role: models.RoleEditor})
+	if caller := idempotencyCaller(ctx); caller != "key:7" {
+		t.Errorf("Expected the authenticated key, got %q", caller)
+	}
+}
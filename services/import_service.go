@@ -0,0 +1,358 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+	"gofr-blog-service/wxr"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// DefaultImportBatchSize is the number of posts imported per batch
+const DefaultImportBatchSize = 100
+
+// importSavepoint isolates the import of one post inside a batch
+const importSavepoint = "import_item"
+
+// ImportConfig tunes the import service
+type ImportConfig struct {
+	BatchSize int
+}
+
+// ImportService imports posts from WordPress exports as resumable batch jobs. The export is
+// stored with its job, and each batch imports up to BatchSize posts in one transaction that
+// also records the outcome of every item, so an interrupted job continues where it stopped.
+// Imported posts are audited and invalidate the cache but publish no events.
+type ImportService struct {
+	importStore *store.ImportStore
+	postService *PostService
+	tags        *TagService
+	validate    func(models.CreatePostRequest) error
+	config      ImportConfig
+	running     atomic.Bool
+}
+
+// NewImportService creates an import service validating imported posts with validate
+func NewImportService(importStore *store.ImportStore, postService *PostService,
+	validate func(models.CreatePostRequest) error, config ImportConfig) *ImportService {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultImportBatchSize
+	}
+
+	return &ImportService{
+		importStore: importStore,
+		postService: postService,
+		validate:    validate,
+		config:      config,
+	}
+}
+
+// SetTags tags imported posts with their WordPress categories and tags
+func (is *ImportService) SetTags(tags *TagService) {
+	is.tags = tags
+}
+
+// DryRunWordPress reports what importing a WXR export would do without writing anything:
+// the posts it would import, the items it would skip, the conflicts it would hit and the
+// tags it would create
+func (is *ImportService) DryRunWordPress(ctx *gofr.Context, payload []byte,
+	options models.ImportOptions) (*models.ImportReport, error) {
+	export, err := wxr.Parse(bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Join(ErrImportFailed, err)
+	}
+
+	plan := newWordPressPlan(export, options)
+	report := &models.ImportReport{
+		Authors:   len(export.Authors),
+		Skipped:   map[string]int{},
+		Conflicts: []models.ImportConflict{},
+	}
+	categories := make(map[string]bool)
+	tags := make(map[string]bool)
+	postTags := make(map[string]bool)
+
+	for i := range export.Items {
+		item := &export.Items[i]
+		if reason := plan.skipReason(item); reason != "" {
+			report.Skipped[reason]++
+			continue
+		}
+
+		for _, name := range item.TermNames(wxr.DomainCategory) {
+			categories[name] = true
+		}
+		for _, name := range item.TermNames(wxr.DomainTag) {
+			tags[name] = true
+		}
+		report.Comments += len(item.Comments)
+
+		post, conflict, holderID, err := is.mapPost(ctx, is.postService.postStore.Primary(), plan, item)
+		if err != nil {
+			return nil, errors.Join(ErrImportFailed, err)
+		}
+		if conflict != "" {
+			report.Conflicts = append(report.Conflicts, models.ImportConflict{
+				SourceID: item.PostID,
+				Title:    item.Title,
+				Slug:     wordPressSlug(item),
+				Reason:   conflict,
+				PostID:   holderID,
+			})
+			continue
+		}
+		for _, tag := range post.tags {
+			postTags[tag] = true
+		}
+		report.Posts++
+	}
+
+	report.Categories = len(categories)
+	report.Tags = len(tags)
+	report.NewTags = []string{}
+	if is.tags != nil {
+		report.NewTags, err = is.tags.New(ctx, slices.Sorted(maps.Keys(postTags)))
+		if err != nil {
+			return nil, errors.Join(ErrImportFailed, err)
+		}
+	}
+	return report, nil
+}
+
+// StartWordPress queues the import of a WXR export. Submitting an export whose import is still
+// unfinished returns that job, which resumes with its original options.
+func (is *ImportService) StartWordPress(ctx *gofr.Context, payload []byte,
+	options models.ImportOptions) (*models.ImportJob, error) {
+	export, err := wxr.Parse(bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Join(ErrImportFailed, err)
+	}
+
+	sum := sha256.Sum256(payload)
+	checksum := hex.EncodeToString(sum[:])
+
+	job, err := is.importStore.GetUnfinishedJob(ctx, models.ImportSourceWordPress, checksum)
+	switch {
+	case err == nil:
+		return job, nil
+	case !errors.Is(err, store.ErrNotFound):
+		return nil, errors.Join(ErrImportFailed, err)
+	}
+
+	total := 0
+	for i := range export.Items {
+		if export.Items[i].PostID > 0 {
+			total++
+		}
+	}
+
+	job, err = is.importStore.CreateJob(ctx, models.ImportSourceWordPress, checksum, payload, options, total)
+	if err != nil {
+		return nil, errors.Join(ErrImportFailed, err)
+	}
+	return job, nil
+}
+
+// GetJob retrieves the import job with id
+func (is *ImportService) GetJob(ctx *gofr.Context, id int) (*models.ImportJob, error) {
+	job, err := is.importStore.GetJob(ctx, id)
+	if err != nil {
+		return nil, errors.Join(ErrImportFailed, err)
+	}
+	return job, nil
+}
+
+// RunBatch imports the next batch of the job with id, waiting for a batch of it running elsewhere
+func (is *ImportService) RunBatch(ctx *gofr.Context, id int) (*models.ImportJob, error) {
+	return is.runBatch(ctx, func(*store.UnitOfWork) (int, error) { return id, nil })
+}
+
+// Run imports one batch of the oldest unfinished job that no other instance is running.
+// It is registered as a GoFr cron job; overlapping runs in one process are skipped.
+func (is *ImportService) Run(ctx *gofr.Context) {
+	if !is.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer is.running.Store(false)
+
+	job, err := is.runBatch(ctx, func(uow *store.UnitOfWork) (int, error) { return uow.Imports.NextJobID(ctx) })
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+	case err != nil:
+		ctx.Logger.Errorf("Failed to run import batch: %v", err)
+	default:
+		ctx.Logger.Infof("Import job %d is %s: %d of %d items processed", job.ID, job.Status, job.Processed, job.Total)
+	}
+}
+
+// runBatch imports the next batch of the job picked by pick in one transaction, then drops the
+// cached lists and imported posts
+func (is *ImportService) runBatch(ctx *gofr.Context, pick func(uow *store.UnitOfWork) (int, error)) (*models.ImportJob, error) {
+	var (
+		job      *models.ImportJob
+		imported []int
+	)
+	err := store.RunInTx(ctx, store.TxOptions{}, func(uow *store.UnitOfWork) error {
+		id, err := pick(uow)
+		if err != nil {
+			return err
+		}
+		job, imported, err = is.importBatch(ctx, uow, id)
+		return err
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Join(ErrImportFailed, err)
+	}
+
+	if len(imported) > 0 {
+		is.postService.afterCommit(ctx, imported...)
+	}
+	return job, nil
+}
+
+// importBatch imports the next posts of the job with id that no earlier batch processed,
+// returning the updated job and the ids of the posts imported
+func (is *ImportService) importBatch(ctx *gofr.Context, uow *store.UnitOfWork, id int) (*models.ImportJob, []int, error) {
+	job, payload, err := uow.Imports.LockJob(ctx, id)
+	if err != nil || job.Status == models.ImportCompleted || job.Status == models.ImportFailed {
+		return job, nil, err
+	}
+
+	export, err := wxr.Parse(bytes.NewReader(payload))
+	if err != nil {
+		job, err = uow.Imports.UpdateProgress(ctx, id, models.ImportFailed, 0, 0, 0, err.Error())
+		return job, nil, err
+	}
+
+	done, err := uow.Imports.ItemIDs(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan := newWordPressPlan(export, job.Options)
+	var (
+		postIDs                   []int
+		imported, skipped, failed int
+		lastError                 string
+		attempted                 int
+	)
+	status := models.ImportCompleted
+
+	for i := range export.Items {
+		item := &export.Items[i]
+		if item.PostID <= 0 || done[item.PostID] {
+			continue
+		}
+		if plan.skipReason(item) == "" {
+			if attempted == is.config.BatchSize {
+				status = models.ImportRunning
+				break
+			}
+			attempted++
+		}
+
+		result, err := is.importItem(ctx, uow, plan, item)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = uow.Imports.RecordItem(ctx, id, result); err != nil {
+			return nil, nil, err
+		}
+
+		switch result.Status {
+		case models.ImportItemImported:
+			imported++
+			postIDs = append(postIDs, *result.PostID)
+		case models.ImportItemSkipped:
+			skipped++
+		default:
+			failed++
+			lastError = result.Reason
+		}
+	}
+
+	job, err = uow.Imports.UpdateProgress(ctx, id, status, imported, skipped, failed, lastError)
+	return job, postIDs, err
+}
+
+// importItem imports one exported item under a savepoint. Items that are skipped, conflict or
+// fail to insert are reported as such; only database failures around them end the batch.
+func (is *ImportService) importItem(ctx *gofr.Context, uow *store.UnitOfWork, plan *wordPressPlan,
+	item *wxr.Item) (models.ImportItem, error) {
+	result := models.ImportItem{SourceID: item.PostID, Status: models.ImportItemSkipped}
+	if reason := plan.skipReason(item); reason != "" {
+		result.Reason = reason
+		return result, nil
+	}
+
+	post, conflict, _, err := is.mapPost(ctx, uow.Posts, plan, item)
+	if err != nil {
+		return result, err
+	}
+	if conflict != "" {
+		result.Reason = conflict
+		return result, nil
+	}
+
+	if err = uow.Savepoint(importSavepoint); err != nil {
+		return result, err
+	}
+	created, err := uow.Posts.ImportPost(ctx, post.req, post.createdAt, post.publishedAt)
+	if err == nil {
+		err = is.postService.recordAudit(ctx, uow, nil, created)
+	}
+	if err == nil && is.tags != nil && len(post.tags) > 0 {
+		err = is.tags.setTags(ctx, uow, created.ID, post.tags)
+	}
+	if err != nil {
+		if rollbackErr := uow.RollbackTo(importSavepoint); rollbackErr != nil {
+			return result, rollbackErr
+		}
+		result.Status, result.Reason = models.ImportItemFailed, err.Error()
+		return result, nil
+	}
+	if err = uow.Release(importSavepoint); err != nil {
+		return result, err
+	}
+
+	result.Status, result.PostID = models.ImportItemImported, &created.ID
+	return result, nil
+}
+
+// mapPost maps a post item and checks it against the posts of postStore and the validation
+// rules. It returns the conflict keeping the item from being imported, with the id of the post
+// holding its slug when that is the conflict.
+func (is *ImportService) mapPost(ctx *gofr.Context, postStore *store.PostStore, plan *wordPressPlan,
+	item *wxr.Item) (*wordPressPost, string, int, error) {
+	post, conflict := plan.post(item)
+	if conflict != "" {
+		return nil, conflict, 0, nil
+	}
+
+	holder, err := postStore.GetPostBySlug(ctx, post.req.Slug)
+	switch {
+	case err == nil:
+		return nil, ImportSlugTaken, holder.ID, nil
+	case !errors.Is(err, store.ErrNotFound):
+		return nil, "", 0, err
+	}
+
+	if is.validate != nil {
+		if err = is.validate(post.req); err != nil {
+			return nil, ImportInvalidPost + ": " + strings.ReplaceAll(err.Error(), "\n", ": "), 0, nil
+		}
+	}
+	return post, "", 0, nil
+}
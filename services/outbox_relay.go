@@ -0,0 +1,123 @@
+package services
+
+import (
+	"sync/atomic"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// maxOutboxBackoff caps the delay between delivery attempts of one message
+const maxOutboxBackoff = 10 * time.Minute
+
+// OutboxRelayConfig tunes the outbox relay. Lease is how long a batch of messages is claimed
+// for; messages not published within it are left to a later run.
+type OutboxRelayConfig struct {
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	Lease       time.Duration
+}
+
+// outboxQueue is the part of the outbox store the relay works through
+type outboxQueue interface {
+	ClaimDue(ctx *gofr.Context, limit int, lease time.Duration) ([]models.OutboxMessage, error)
+	MarkSent(ctx *gofr.Context, id int64) error
+	Reschedule(ctx *gofr.Context, id int64, lastError string, nextAttempt time.Time) error
+	Fail(ctx *gofr.Context, id int64, lastError string) error
+}
+
+// OutboxRelay delivers outbox messages to the pub/sub publisher.
+// It is registered as a GoFr cron job; overlapping runs in one process are skipped, and
+// messages are claimed with a lease so concurrent instances do not deliver the same one.
+// Messages are published outside any transaction, and each outcome is recorded on its own.
+type OutboxRelay struct {
+	outboxStore *store.OutboxStore
+	config      OutboxRelayConfig
+	running     atomic.Bool
+}
+
+// NewOutboxRelay creates a new outbox relay instance
+func NewOutboxRelay(outboxStore *store.OutboxStore, config OutboxRelayConfig) *OutboxRelay {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 10
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = time.Second
+	}
+	if config.Lease <= 0 {
+		config.Lease = time.Minute
+	}
+
+	return &OutboxRelay{
+		outboxStore: outboxStore,
+		config:      config,
+	}
+}
+
+// Run delivers one batch of due outbox messages
+func (rl *OutboxRelay) Run(ctx *gofr.Context) {
+	if !rl.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer rl.running.Store(false)
+
+	publisher := ctx.GetPublisher()
+	if publisher == nil {
+		ctx.Logger.Debug("No publisher configured, outbox messages stay pending")
+		return
+	}
+
+	if err := rl.relay(ctx, rl.outboxStore, publisher); err != nil {
+		ctx.Logger.Errorf("Outbox relay failed to claim messages: %v", err)
+	}
+}
+
+// relay claims a batch of due messages in queue, publishes each one and records the outcome
+func (rl *OutboxRelay) relay(ctx *gofr.Context, queue outboxQueue, publisher pubsub.Publisher) error {
+	claimedAt := time.Now()
+	messages, err := queue.ClaimDue(ctx, rl.config.BatchSize, rl.config.Lease)
+	if err != nil {
+		return err
+	}
+
+	deadline := claimedAt.Add(rl.config.Lease)
+	for i := range messages {
+		if time.Now().After(deadline) {
+			ctx.Logger.Warnf("Outbox relay lease ran out; %d messages wait for a later run", len(messages)-i)
+			return nil
+		}
+
+		msg := &messages[i]
+		publishErr := publisher.Publish(ctx, msg.Topic, msg.Payload)
+		if err = rl.record(ctx, queue, msg, publishErr); err != nil {
+			// The message stays claimed and is published again once the lease ends
+			ctx.Logger.Errorf("Failed to record outbox message %d: %v", msg.ID, err)
+		}
+	}
+	return nil
+}
+
+// record stores the outcome of one delivery attempt
+func (rl *OutboxRelay) record(ctx *gofr.Context, outbox outboxQueue, msg *models.OutboxMessage, publishErr error) error {
+	if publishErr == nil {
+		return outbox.MarkSent(ctx, msg.ID)
+	}
+
+	attempts := msg.Attempts + 1
+	if attempts >= rl.config.MaxAttempts {
+		ctx.Logger.Errorf("Outbox message %d (%s) dead-lettered after %d attempts: %v",
+			msg.ID, msg.EventType, attempts, publishErr)
+		return outbox.Fail(ctx, msg.ID, publishErr.Error())
+	}
+
+	ctx.Logger.Warnf("Outbox message %d (%s) delivery attempt %d failed: %v", msg.ID, msg.EventType, attempts, publishErr)
+	return outbox.Reschedule(ctx, msg.ID, publishErr.Error(), time.Now().Add(RetryBackoff(rl.config.BaseBackoff, attempts, maxOutboxBackoff)))
+}
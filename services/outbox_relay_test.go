@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// recordingOutbox hands out claimed messages and records the relay's calls, in order, in a log
+// shared with recordingPublisher. Which messages are due is decided by the claim statement,
+// which the outbox store tests cover.
+type recordingOutbox struct {
+	claimed []models.OutboxMessage
+	log     *[]string
+	markErr error
+}
+
+func (o *recordingOutbox) ClaimDue(_ *gofr.Context, limit int, lease time.Duration) ([]models.OutboxMessage, error) {
+	*o.log = append(*o.log, "claim "+strconv.Itoa(limit)+" for "+lease.String())
+	return o.claimed, nil
+}
+
+func (o *recordingOutbox) MarkSent(_ *gofr.Context, id int64) error {
+	*o.log = append(*o.log, "sent "+strconv.FormatInt(id, 10))
+	return o.markErr
+}
+
+func (o *recordingOutbox) Reschedule(_ *gofr.Context, id int64, lastError string, _ time.Time) error {
+	*o.log = append(*o.log, "reschedule "+strconv.FormatInt(id, 10)+": "+lastError)
+	return nil
+}
+
+func (o *recordingOutbox) Fail(_ *gofr.Context, id int64, lastError string) error {
+	*o.log = append(*o.log, "fail "+strconv.FormatInt(id, 10)+": "+lastError)
+	return nil
+}
+
+// recordingPublisher records published topics in the shared log and fails failTopic
+type recordingPublisher struct {
+	log       *[]string
+	failTopic string
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, _ []byte) error {
+	if topic == p.failTopic {
+		return errors.New("broker unavailable")
+	}
+	*p.log = append(*p.log, "publish "+topic)
+	return nil
+}
+
+func newRelayTestContext() *gofr.Context {
+	return &gofr.Context{
+		Context:   context.Background(),
+		Container: &container.Container{Logger: logging.NewMockLogger(logging.ERROR)},
+	}
+}
+
+// relayTestMessage returns a claimed message of a post
+func relayTestMessage(id int64, postID, attempts int, topic string) models.OutboxMessage {
+	return models.OutboxMessage{ID: id, AggregateID: postID, Topic: topic, Payload: []byte(topic), Attempts: attempts}
+}
+
+// TestOutboxRelay_PublishesAndMarksSent tests that claimed messages are published in order and
+// each is marked sent only after it was published
+func TestOutboxRelay_PublishesAndMarksSent(t *testing.T) {
+	var log []string
+	outbox := &recordingOutbox{log: &log, claimed: []models.OutboxMessage{
+		relayTestMessage(1, 10, 0, "post-10-created"),
+		relayTestMessage(2, 20, 0, "post-20-created"),
+	}}
+	relay := NewOutboxRelay(nil, OutboxRelayConfig{BatchSize: 5, Lease: 30 * time.Second})
+
+	if err := relay.relay(newRelayTestContext(), outbox, &recordingPublisher{log: &log}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"claim 5 for 30s", "publish post-10-created", "sent 1", "publish post-20-created", "sent 2"}
+	if !reflect.DeepEqual(log, expected) {
+		t.Errorf("Expected %v, got %v", expected, log)
+	}
+}
+
+// TestOutboxRelay_RecordsFailures tests that failed publishes are rescheduled, or dead-lettered on
+// the last attempt, without holding up the other messages of the batch
+func TestOutboxRelay_RecordsFailures(t *testing.T) {
+	var log []string
+	outbox := &recordingOutbox{log: &log, claimed: []models.OutboxMessage{
+		relayTestMessage(1, 10, 0, "down"),
+		relayTestMessage(2, 20, 2, "down"),
+		relayTestMessage(3, 30, 0, "post-30-created"),
+	}}
+	relay := NewOutboxRelay(nil, OutboxRelayConfig{MaxAttempts: 3})
+
+	if err := relay.relay(newRelayTestContext(), outbox, &recordingPublisher{log: &log, failTopic: "down"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"claim 100 for 1m0s", "reschedule 1: broker unavailable", "fail 2: broker unavailable",
+		"publish post-30-created", "sent 3"}
+	if !reflect.DeepEqual(log, expected) {
+		t.Errorf("Expected %v, got %v", expected, log)
+	}
+}
+
+// TestOutboxRelay_ContinuesAfterRecordFailure tests that a message whose outcome could not be
+// recorded is left claimed while the rest of the batch is delivered
+func TestOutboxRelay_ContinuesAfterRecordFailure(t *testing.T) {
+	var log []string
+	outbox := &recordingOutbox{log: &log, markErr: errors.New("connection reset"), claimed: []models.OutboxMessage{
+		relayTestMessage(1, 10, 0, "post-10-created"),
+		relayTestMessage(2, 20, 0, "post-20-created"),
+	}}
+	relay := NewOutboxRelay(nil, OutboxRelayConfig{})
+
+	if err := relay.relay(newRelayTestContext(), outbox, &recordingPublisher{log: &log}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"claim 100 for 1m0s", "publish post-10-created", "sent 1", "publish post-20-created", "sent 2"}
+	if !reflect.DeepEqual(log, expected) {
+		t.Errorf("Expected %v, got %v", expected, log)
+	}
+}
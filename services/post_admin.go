@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ErrRegenerateFailed reports a failed regeneration of derived post data
+var ErrRegenerateFailed = errors.New("failed to regenerate derived post data")
+
+// RegenerateSlugs derives the slugs of the posts with ids, or of every post when ids is empty,
+// from their titles. A slug taken by another post gets a -2, -3, ... suffix. Changed posts are
+// updated one by one through UpdatePost, so events, audit entries and cache invalidation follow;
+// with dryRun the changes are only reported.
+func (ps *PostService) RegenerateSlugs(ctx *gofr.Context, ids []int, dryRun bool) ([]models.SlugChange, error) {
+	// Slugs assigned (to a post id) or released (to 0) earlier in the run, which the database
+	// does not reflect yet on a dry run
+	assigned := make(map[string]int)
+	var changes []models.SlugChange
+
+	err := ps.eachPost(ctx, ids, func(post *models.Post) error {
+		slug, err := ps.availableSlug(ctx, Slugify(post.Title), post.ID, assigned)
+		if err != nil {
+			return err
+		}
+		assigned[slug] = post.ID
+		if slug == post.Slug || slug == "" {
+			return nil
+		}
+
+		if _, ok := assigned[post.Slug]; !ok {
+			assigned[post.Slug] = 0
+		}
+		if !dryRun {
+			if _, err = ps.UpdatePost(ctx, post.ID, models.UpdatePostRequest{Slug: slug}); err != nil {
+				return err
+			}
+		}
+		changes = append(changes, models.SlugChange{ID: post.ID, Title: post.Title, From: post.Slug, To: slug})
+		return nil
+	})
+	if err != nil {
+		return changes, errors.Join(ErrRegenerateFailed, err)
+	}
+	return changes, nil
+}
+
+// RefreshCache drops every cached post and list page, returning the number of posts dropped
+func (ps *PostService) RefreshCache(ctx *gofr.Context) (int, error) {
+	if ps.cache == nil {
+		return 0, nil
+	}
+
+	var ids []int
+	err := ps.eachPost(ctx, nil, func(post *models.Post) error {
+		ids = append(ids, post.ID)
+		if len(ids) == MaxPostPageSize {
+			ps.cache.Invalidate(ctx, ids...)
+			ids = ids[:0]
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(ErrRegenerateFailed, err)
+	}
+	ps.cache.Invalidate(ctx, ids...)
+
+	count, err := ps.postStore.Primary().CountPosts(ctx, models.PostFilter{})
+	if err != nil {
+		return 0, errors.Join(ErrRegenerateFailed, err)
+	}
+	return count, nil
+}
+
+// eachPost calls fn with the posts with ids, or with every post newest first when ids is
+// empty, read from the primary
+func (ps *PostService) eachPost(ctx *gofr.Context, ids []int, fn func(post *models.Post) error) error {
+	primary := ps.postStore.Primary()
+
+	for _, id := range ids {
+		post, err := primary.GetPostByID(ctx, id, nil)
+		if err != nil {
+			return errors.Join(errors.New("post "+strconv.Itoa(id)), err)
+		}
+		if err = fn(post); err != nil {
+			return err
+		}
+	}
+	if len(ids) > 0 {
+		return nil
+	}
+
+	var after *models.PostCursor
+	for {
+		posts, err := primary.GetPostsPage(ctx, models.PostFilter{}, after, MaxPostPageSize)
+		if err != nil {
+			return err
+		}
+		for i := range posts {
+			if err = fn(&posts[i]); err != nil {
+				return err
+			}
+		}
+		if len(posts) < MaxPostPageSize {
+			return nil
+		}
+		last := posts[len(posts)-1]
+		after = &models.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// availableSlug returns slug, or slug with the first free numeric suffix, that no post other
+// than id holds once the assignments of the run are applied
+func (ps *PostService) availableSlug(ctx *gofr.Context, slug string, id int, assigned map[string]int) (string, error) {
+	if slug == "" {
+		return "", nil
+	}
+
+	candidate := slug
+	for n := 2; ; n++ {
+		owner, known := assigned[candidate]
+		taken := known && owner != 0
+		if !known {
+			post, err := ps.postStore.Primary().GetPostBySlug(ctx, candidate)
+			switch {
+			case errors.Is(err, store.ErrNotFound):
+			case err != nil:
+				return "", err
+			default:
+				owner, taken = post.ID, true
+			}
+		}
+		if !taken || owner == id {
+			return candidate, nil
+		}
+
+		candidate = suffixedSlug(slug, n)
+	}
+}
+
+// suffixedSlug returns slug with the numeric suffix -n, shortening slug to keep it within the
+// length limit
+func suffixedSlug(slug string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(slug)+len(suffix) > maxSlugLength {
+		slug = strings.ToValidUTF8(slug[:maxSlugLength-len(suffix)], "")
+	}
+	return slug + suffix
+}
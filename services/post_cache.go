@@ -0,0 +1,178 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr-blog-service/cache"
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Cache keys of posts.
+// Post keys embed a generation counter of the post that every mutation of it bumps, and list
+// keys one that every mutation bumps, so a fill that read before a mutation committed is
+// stored under a key no later read uses. Slug keys hold the post id, so an update only has
+// to bump the post's generation.
+const (
+	postCacheKeyPrefix      = "posts:id:"
+	postGenerationKeyPrefix = "posts:gen:"
+	slugCacheKeyPrefix      = "posts:slug:"
+	listCacheKeyPrefix      = "posts:list:"
+	listGenerationKey       = "posts:list:gen"
+	defaultCacheGeneration  = "0"
+)
+
+// PostCache reads posts and list pages through a cache with stampede protection
+type PostCache struct {
+	cache  cache.Cache
+	loader *cache.Loader
+}
+
+// NewPostCache creates a post cache whose entries live for about ttl
+func NewPostCache(c cache.Cache, ttl time.Duration) *PostCache {
+	return &PostCache{
+		cache:  c,
+		loader: cache.NewLoader(c, ttl),
+	}
+}
+
+// Post returns the post with id, calling load on a miss
+func (pc *PostCache) Post(ctx *gofr.Context, id int, load func() (*models.Post, error)) (*models.Post, error) {
+	key := postCacheKey(id, pc.generation(ctx, postGenerationKey(id)))
+	value, err := pc.loader.Load(ctx, key, func() ([]byte, error) {
+		post, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(post)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var post models.Post
+	if err = json.Unmarshal(value, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// PostIDBySlug returns the id of the post with slug, calling load on a miss
+func (pc *PostCache) PostIDBySlug(ctx *gofr.Context, slug string, load func() (int, error)) (int, error) {
+	value, err := pc.loader.Load(ctx, slugCacheKeyPrefix+slug, func() ([]byte, error) {
+		id, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(id)), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(value))
+}
+
+// List returns a page of posts, calling load on a miss
+func (pc *PostCache) List(ctx *gofr.Context, page, pageSize int, fields []string,
+	load func() (*models.PostListResponse, error)) (*models.PostListResponse, error) {
+	value, err := pc.loader.Load(ctx, pc.listCacheKey(ctx, page, pageSize, fields), func() ([]byte, error) {
+		list, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(list)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var list models.PostListResponse
+	if err = json.Unmarshal(value, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListVersion returns the version of post lists, calling load on a miss
+func (pc *PostCache) ListVersion(ctx *gofr.Context, load func() (*models.FeedVersion, error)) (*models.FeedVersion, error) {
+	key := listCacheKeyPrefix + pc.generation(ctx, listGenerationKey) + ":version"
+	value, err := pc.loader.Load(ctx, key, func() ([]byte, error) {
+		version, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var version models.FeedVersion
+	if err = json.Unmarshal(value, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// ForgetSlug drops a cached slug lookup that no longer matches its post
+func (pc *PostCache) ForgetSlug(ctx *gofr.Context, slug string) {
+	if err := pc.cache.Delete(ctx, slugCacheKeyPrefix+slug); err != nil {
+		ctx.Logger.Errorf("Failed to invalidate cached slug %s: %v", slug, err)
+	}
+}
+
+// Invalidate drops the cached posts with ids and every cached list page by starting new
+// generations of them. Failures are logged; the entries then expire with their TTL.
+func (pc *PostCache) Invalidate(ctx *gofr.Context, ids ...int) {
+	for _, id := range ids {
+		if _, err := pc.cache.Incr(ctx, postGenerationKey(id)); err != nil {
+			ctx.Logger.Errorf("Failed to invalidate cached post %d: %v", id, err)
+		}
+	}
+
+	if _, err := pc.cache.Incr(ctx, listGenerationKey); err != nil {
+		ctx.Logger.Errorf("Failed to invalidate cached post lists: %v", err)
+	}
+}
+
+// listCacheKey returns the key of a list page in the current list generation
+func (pc *PostCache) listCacheKey(ctx *gofr.Context, page, pageSize int, fields []string) string {
+	return ListCacheKey(pc.generation(ctx, listGenerationKey), page, pageSize, fields)
+}
+
+// generation returns the current value of the generation counter at key
+func (pc *PostCache) generation(ctx *gofr.Context, key string) string {
+	value, err := pc.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		return string(value)
+	case !errors.Is(err, cache.ErrMiss):
+		ctx.Logger.Errorf("Failed to read the cache generation %s: %v", key, err)
+	}
+	return defaultCacheGeneration
+}
+
+// ListCacheKey returns the cache key of a list page. An empty fieldset, which selects every
+// field, is keyed as "*".
+func ListCacheKey(generation string, page, pageSize int, fields []string) string {
+	fieldset := "*"
+	if len(fields) > 0 {
+		fieldset = strings.Join(fields, ",")
+	}
+	return listCacheKeyPrefix + generation + ":" + strconv.Itoa(page) + ":" + strconv.Itoa(pageSize) + ":" + fieldset
+}
+
+// postCacheKey returns the cache key of the post with id in a generation of it
+func postCacheKey(id int, generation string) string {
+	return postCacheKeyPrefix + strconv.Itoa(id) + ":" + generation
+}
+
+// postGenerationKey returns the key of the generation counter of the post with id
+func postGenerationKey(id int) string {
+	return postGenerationKeyPrefix + strconv.Itoa(id)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"gofr-blog-service/cache"
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// TestPostCache_Invalidate tests that invalidation reloads posts and starts a new list generation
+func TestPostCache_Invalidate(t *testing.T) {
+	ctx := &gofr.Context{}
+	pc := NewPostCache(cache.NewMemory(0), time.Minute)
+
+	loads := 0
+	loadPost := func() (*models.Post, error) {
+		loads++
+		return &models.Post{ID: 7, Title: "Cached"}, nil
+	}
+	loadList := func() (*models.PostListResponse, error) {
+		loads++
+		return &models.PostListResponse{TotalCount: 1, Page: 1, PageSize: 10}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if post, err := pc.Post(ctx, 7, loadPost); err != nil || post.Title != "Cached" {
+			t.Fatalf("Expected the cached post, got %+v (%v)", post, err)
+		}
+		if _, err := pc.List(ctx, 1, 10, nil, loadList); err != nil {
+			t.Fatalf("Expected the cached list, got %v", err)
+		}
+	}
+	if loads != 2 {
+		t.Errorf("Expected 2 loads before invalidation, got %d", loads)
+	}
+
+	pc.Invalidate(ctx, 7)
+	_, _ = pc.Post(ctx, 7, loadPost)
+	_, _ = pc.List(ctx, 1, 10, nil, loadList)
+	if loads != 4 {
+		t.Errorf("Expected 4 loads after invalidation, got %d", loads)
+	}
+}
+
+// TestPostCache_InvalidateDuringFill tests that a fill that read a post before a concurrent
+// update committed is not served after the update's invalidation
+func TestPostCache_InvalidateDuringFill(t *testing.T) {
+	ctx := &gofr.Context{}
+	pc := NewPostCache(cache.NewMemory(0), time.Minute)
+
+	stale := func() (*models.Post, error) {
+		// The update commits and invalidates while the fill still holds the old post
+		pc.Invalidate(ctx, 7)
+		return &models.Post{ID: 7, Title: "Old"}, nil
+	}
+	if post, err := pc.Post(ctx, 7, stale); err != nil || post.Title != "Old" {
+		t.Fatalf("Expected the loaded post, got %+v (%v)", post, err)
+	}
+
+	fresh := func() (*models.Post, error) {
+		return &models.Post{ID: 7, Title: "New"}, nil
+	}
+	if post, err := pc.Post(ctx, 7, fresh); err != nil || post.Title != "New" {
+		t.Errorf("Expected the updated post, got %+v (%v)", post, err)
+	}
+}
+
+// TestListCacheKey tests that list keys separate generations, pages and fieldsets
+func TestListCacheKey(t *testing.T) {
+	tests := []struct {
+		generation string
+		fields     []string
+		expected   string
+	}{
+		{"0", nil, "posts:list:0:2:10:*"},
+		{"3", []string{"id", "title"}, "posts:list:3:2:10:id,title"},
+	}
+
+	for _, tt := range tests {
+		if key := ListCacheKey(tt.generation, 2, 10, tt.fields); key != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, key)
+		}
+	}
+}
+
+// TestBulkPostIDs tests that only successful operations with an id are invalidated
+func TestBulkPostIDs(t *testing.T) {
+	ids := bulkPostIDs([]models.BulkItemResult{
+		{ID: 1, Success: true},
+		{ID: 2, Success: false},
+		{ID: 0, Success: true},
+		{ID: 4, Success: true},
+	})
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 4 {
+		t.Errorf("Expected [1 4], got %v", ids)
+	}
+}
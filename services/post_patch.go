@@ -0,0 +1,87 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/patch"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// PatchPost applies a JSON Merge Patch or JSON Patch document to a post.
+// The post is locked while the patch is applied, and the patched fields must pass validate
+// before they replace the stored ones, so a patch is applied entirely or not at all.
+func (ps *PostService) PatchPost(ctx *gofr.Context, id int, mediaType string, body []byte,
+	validate func(models.CreatePostRequest) error) (*models.Post, error) {
+	var post *models.Post
+	err := ps.inTx(ctx, OpPatchPost, func(uow *store.UnitOfWork) error {
+		before, err := uow.Posts.GetPostForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		req, err := ApplyPostPatch(before, mediaType, body)
+		if err != nil {
+			return err
+		}
+		if err = validate(req); err != nil {
+			return err
+		}
+
+		if post, err = uow.Posts.ReplacePost(ctx, id, req); err != nil {
+			return err
+		}
+		if err = ps.recordAudit(ctx, uow, before, post); err != nil {
+			return err
+		}
+		return ps.enqueueEvents(ctx, uow, func(pe *PostEvents) []*models.PostEvent { return pe.Updated(before, post) })
+	})
+	if err != nil {
+		return nil, errors.Join(ErrUpdateFailed, err)
+	}
+	ps.afterCommit(ctx, id)
+
+	ctx.Logger.Infof("Post patched successfully: %d", post.ID)
+	return post, nil
+}
+
+// ApplyPostPatch applies a patch document of mediaType to the writable fields of post.
+// Removing a member or setting it to null clears the field; an empty status falls back to
+// draft as it does on create.
+func ApplyPostPatch(post *models.Post, mediaType string, body []byte) (models.CreatePostRequest, error) {
+	doc, err := json.Marshal(post.Document())
+	if err != nil {
+		return models.CreatePostRequest{}, err
+	}
+
+	var patched []byte
+	switch mediaType {
+	case patch.MergePatchMediaType:
+		patched, err = patch.MergePatch(doc, body)
+	case patch.JSONPatchMediaType:
+		patched, err = patch.Apply(doc, body)
+	default:
+		return models.CreatePostRequest{}, ErrUnsupportedPatch
+	}
+	if err != nil {
+		return models.CreatePostRequest{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+
+	var result models.PostDocument
+	if err = decoder.Decode(&result); err != nil {
+		return models.CreatePostRequest{}, errors.Join(patch.ErrInvalidPatch, err)
+	}
+
+	req := result.CreateRequest()
+	if req.Status == "" {
+		req.Status = "draft"
+	}
+	return req, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/patch"
+)
+
+// TestApplyPostPatch tests merge and JSON patches against a post, including clearing fields with null
+func TestApplyPostPatch(t *testing.T) {
+	post := &models.Post{ID: 1, Title: "Hello", Content: "Hello world", Slug: "hello", AuthorID: 2,
+		Status: "draft", MetaTitle: "SEO title", CanonicalURL: "https://example.com/hello"}
+
+	req, err := ApplyPostPatch(post, patch.MergePatchMediaType,
+		[]byte(`{"title":"Hello again","meta_title":null,"noindex":true}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if req.Title != "Hello again" || req.MetaTitle != "" || !req.NoIndex {
+		t.Errorf("Unexpected merge patch result %+v", req)
+	}
+	if req.CanonicalURL != post.CanonicalURL || req.Content != post.Content {
+		t.Errorf("Expected untouched fields to be kept, got %+v", req)
+	}
+
+	req, err = ApplyPostPatch(post, patch.JSONPatchMediaType,
+		[]byte(`[{"op":"test","path":"/status","value":"draft"},{"op":"replace","path":"/status","value":"published"},`+
+			`{"op":"remove","path":"/canonical_url"}]`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if req.Status != "published" || req.CanonicalURL != "" {
+		t.Errorf("Unexpected JSON patch result %+v", req)
+	}
+}
+
+// TestApplyPostPatch_Errors tests that unknown fields, failed tests and unknown media types are rejected
+func TestApplyPostPatch_Errors(t *testing.T) {
+	post := &models.Post{ID: 1, Title: "Hello", Status: "draft"}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		body      string
+		expected  error
+	}{
+		{"unknown field", patch.MergePatchMediaType, `{"id":5}`, patch.ErrInvalidPatch},
+		{"wrong type", patch.MergePatchMediaType, `{"author_id":"two"}`, patch.ErrInvalidPatch},
+		{"failed test", patch.JSONPatchMediaType, `[{"op":"test","path":"/status","value":"published"}]`, patch.ErrTestFailed},
+		{"unsupported media type", "application/json", `{}`, ErrUnsupportedPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyPostPatch(post, tt.mediaType, []byte(tt.body))
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// MaxPostPageSize caps the size of keyset-paginated post pages
+const MaxPostPageSize = 100
+
+// ListPostsPage retrieves up to first posts matching filter after the cursor, newest first.
+// One extra post is read to tell whether another page follows.
+func (ps *PostService) ListPostsPage(ctx *gofr.Context, filter models.PostFilter, after *models.PostCursor,
+	first int) (*models.PostPage, error) {
+	if first <= 0 || first > MaxPostPageSize {
+		first = MaxPostPageSize
+	}
+
+	posts, err := ps.readStore(ctx).GetPostsPage(ctx, filter, after, first+1)
+	if err != nil {
+		return nil, errors.Join(ErrListFailed, err)
+	}
+
+	page := &models.PostPage{Posts: posts}
+	if len(posts) > first {
+		page.Posts = posts[:first]
+		page.HasNextPage = true
+	}
+	return page, nil
+}
+
+// CountPosts counts the posts matching filter
+func (ps *PostService) CountPosts(ctx *gofr.Context, filter models.PostFilter) (int, error) {
+	count, err := ps.readStore(ctx).CountPosts(ctx, filter)
+	if err != nil {
+		return 0, errors.Join(ErrCountFailed, err)
+	}
+	return count, nil
+}
+
+// PublishedPostsByAuthors retrieves the newest published posts of several authors in one
+// query, at most perAuthor each, grouped by author id
+func (ps *PostService) PublishedPostsByAuthors(ctx *gofr.Context, authorIDs []int,
+	perAuthor int) (map[int][]models.Post, error) {
+	byAuthor := make(map[int][]models.Post, len(authorIDs))
+	if len(authorIDs) == 0 {
+		return byAuthor, nil
+	}
+
+	posts, err := ps.readStore(ctx).GetPublishedPostsByAuthors(ctx, authorIDs, perAuthor)
+	if err != nil {
+		return nil, errors.Join(ErrListFailed, err)
+	}
+
+	for i := range posts {
+		byAuthor[posts[i].AuthorID] = append(byAuthor[posts[i].AuthorID], posts[i])
+	}
+	return byAuthor, nil
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"errors"
+	"sync/atomic"
+	"time"
 
+	"gofr-blog-service/middleware"
 	"gofr-blog-service/models"
 	"gofr-blog-service/store"
 
@@ -11,31 +14,121 @@ import (
 
 // PostService handles business logic for posts
 type PostService struct {
-	postStore *store.PostStore
+	postStore    *store.PostStore
+	outboxStore  *store.OutboxStore
+	webhookStore *store.WebhookStore
+	auditStore   *store.AuditStore
+	events       *PostEvents
+	txOptions    map[string]store.TxOptions
+	cache        *PostCache
+	watch        *PostWatch
+	tags         *TagService
+
+	// begin starts the transactions of mutations; nil begins them on the context's database
+	begin func(ctx *gofr.Context) (store.Tx, error)
+
+	// readYourWrites is how long reads go to the primary after a mutation
+	readYourWrites time.Duration
+	lastWrite      atomic.Int64
 }
 
-// NewPostService creates a new post service instance
-func NewPostService(postStore *store.PostStore) *PostService {
+// Post mutations whose transaction options can be configured with SetTxOptions
+const (
+	OpCreatePost = "create"
+	OpUpdatePost = "update"
+	OpPatchPost  = "patch"
+	OpDeletePost = "delete"
+	OpBulkPosts  = "bulk"
+)
+
+// NewPostService creates a new post service instance.
+// Domain events are written to the outbox and fanned out to webhook subscriptions, and
+// audit entries are appended, in the same transaction as each mutation; a nil events
+// builder or audit store disables them.
+func NewPostService(postStore *store.PostStore, outboxStore *store.OutboxStore, webhookStore *store.WebhookStore,
+	auditStore *store.AuditStore, events *PostEvents) *PostService {
 	return &PostService{
-		postStore: postStore,
+		postStore:    postStore,
+		outboxStore:  outboxStore,
+		webhookStore: webhookStore,
+		auditStore:   auditStore,
+		events:       events,
+		txOptions:    map[string]store.TxOptions{},
 	}
 }
 
+// SetTxOptions sets the transaction options, such as the isolation level, used by one post mutation
+func (ps *PostService) SetTxOptions(operation string, opts store.TxOptions) {
+	ps.txOptions[operation] = opts
+}
+
+// SetCache reads posts and list pages through pc; mutations invalidate the entries they change
+func (ps *PostService) SetCache(pc *PostCache) {
+	ps.cache = pc
+}
+
+// SetWatch publishes the events of every committed mutation to pw
+func (ps *PostService) SetWatch(pw *PostWatch) {
+	ps.watch = pw
+}
+
+// SetTags lets bulk requests replace the tags of posts through tags
+func (ps *PostService) SetTags(tags *TagService) {
+	ps.tags = tags
+}
+
+// SetReadYourWrites sends reads to the primary for window after a mutation: for the
+// mutating client through a cookie, and for cache fills of this instance so replica lag is
+// never cached. Clients can also send X-Read-Primary: true on any read.
+func (ps *PostService) SetReadYourWrites(window time.Duration) {
+	ps.readYourWrites = window
+}
+
+// PostHook runs inside the transaction of a post mutation with the resulting post, so what it
+// writes commits or rolls back along with the post
+type PostHook func(uow *store.UnitOfWork, post *models.Post) error
+
 // CreatePost creates a new blog post
 func (ps *PostService) CreatePost(ctx *gofr.Context, req models.CreatePostRequest) (*models.Post, error) {
+	return ps.CreatePostWith(ctx, req, nil)
+}
+
+// CreatePostWith creates a new blog post, running hook on it inside the creating transaction
+// when hook is not nil
+func (ps *PostService) CreatePostWith(ctx *gofr.Context, req models.CreatePostRequest,
+	hook PostHook) (*models.Post, error) {
 	// Let the handler handle validation
-	post, err := ps.postStore.CreatePost(ctx, req)
+	var post *models.Post
+	err := ps.inTx(ctx, OpCreatePost, func(uow *store.UnitOfWork) error {
+		var err error
+		if post, err = ps.createPost(ctx, uow, req); err != nil || hook == nil {
+			return err
+		}
+		return hook(uow, post)
+	})
 	if err != nil {
 		return nil, errors.Join(ErrCreateFailed, err)
 	}
+	ps.afterCommit(ctx)
 
 	ctx.Logger.Infof("Post created successfully with ID: %d", post.ID)
 	return post, nil
 }
 
-// GetPost retrieves a single post by ID
-func (ps *PostService) GetPost(ctx *gofr.Context, id int) (*models.Post, error) {
-	post, err := ps.postStore.GetPostByID(ctx, id)
+// GetPost retrieves a single post by ID, limited to fields when any are given.
+// With a cache the whole post is returned and callers project the fields they need.
+func (ps *PostService) GetPost(ctx *gofr.Context, id int, fields []string) (*models.Post, error) {
+	var (
+		post *models.Post
+		err  error
+	)
+	if ps.cache != nil {
+		post, err = ps.cache.Post(ctx, id, func() (*models.Post, error) {
+			return ps.readStore(ctx).GetPostByID(ctx, id, nil)
+		})
+	} else {
+		post, err = ps.readStore(ctx).GetPostByID(ctx, id, fields)
+	}
 	if err != nil {
 		return nil, errors.Join(ErrGetFailed, err)
 	}
@@ -43,8 +136,44 @@ func (ps *PostService) GetPost(ctx *gofr.Context, id int) (*models.Post, error)
 	return post, nil
 }
 
-// ListPosts retrieves posts with pagination
-func (ps *PostService) ListPosts(ctx *gofr.Context, page, pageSize int) (*models.PostListResponse, error) {
+// GetPostBySlug retrieves a single post by slug
+func (ps *PostService) GetPostBySlug(ctx *gofr.Context, slug string) (*models.Post, error) {
+	if ps.cache == nil {
+		post, err := ps.readStore(ctx).GetPostBySlug(ctx, slug)
+		if err != nil {
+			return nil, errors.Join(ErrGetFailed, err)
+		}
+		return post, nil
+	}
+
+	loadID := func() (int, error) {
+		post, err := ps.readStore(ctx).GetPostBySlug(ctx, slug)
+		if err != nil {
+			return 0, err
+		}
+		return post.ID, nil
+	}
+
+	id, err := ps.cache.PostIDBySlug(ctx, slug, loadID)
+	if err != nil {
+		return nil, errors.Join(ErrGetFailed, err)
+	}
+
+	post, err := ps.GetPost(ctx, id, nil)
+	if err == nil && post.Slug == slug {
+		return post, nil
+	}
+
+	// The slug moved to another post or its post is gone since the lookup was cached
+	ps.cache.ForgetSlug(ctx, slug)
+	if id, err = ps.cache.PostIDBySlug(ctx, slug, loadID); err != nil {
+		return nil, errors.Join(ErrGetFailed, err)
+	}
+	return ps.GetPost(ctx, id, nil)
+}
+
+// ListPosts retrieves posts with pagination, limited to fields when any are given
+func (ps *PostService) ListPosts(ctx *gofr.Context, page, pageSize int, fields []string) (*models.PostListResponse, error) {
 	// Adjust pagination values if needed
 	if page <= 0 {
 		page = 1
@@ -53,16 +182,49 @@ func (ps *PostService) ListPosts(ctx *gofr.Context, page, pageSize int) (*models
 		pageSize = 10
 	}
 
+	if ps.cache != nil {
+		return ps.cache.List(ctx, page, pageSize, fields, func() (*models.PostListResponse, error) {
+			return ps.listPosts(ctx, page, pageSize, fields)
+		})
+	}
+
+	return ps.listPosts(ctx, page, pageSize, fields)
+}
+
+// GetListVersion returns the newest update time and count of posts, which changes whenever
+// any list page may have changed
+func (ps *PostService) GetListVersion(ctx *gofr.Context) (*models.FeedVersion, error) {
+	load := func() (*models.FeedVersion, error) {
+		return ps.readStore(ctx).GetPostsVersion(ctx)
+	}
+
+	var (
+		version *models.FeedVersion
+		err     error
+	)
+	if ps.cache != nil {
+		version, err = ps.cache.ListVersion(ctx, load)
+	} else {
+		version, err = load()
+	}
+	if err != nil {
+		return nil, errors.Join(ErrListFailed, err)
+	}
+	return version, nil
+}
+
+// listPosts reads a page of posts and the total count from the store
+func (ps *PostService) listPosts(ctx *gofr.Context, page, pageSize int, fields []string) (*models.PostListResponse, error) {
 	offset := (page - 1) * pageSize
 
 	// Get total count from store
-	totalCount, err := ps.postStore.GetTotalPostCount(ctx)
+	totalCount, err := ps.readStore(ctx).GetTotalPostCount(ctx)
 	if err != nil {
 		return nil, errors.Join(ErrCountFailed, err)
 	}
 
 	// Get posts from store
-	posts, err := ps.postStore.GetPosts(ctx, pageSize, offset)
+	posts, err := ps.readStore(ctx).GetPosts(ctx, pageSize, offset, fields)
 	if err != nil {
 		return nil, errors.Join(ErrListFailed, err)
 	}
@@ -82,10 +244,16 @@ func (ps *PostService) ListPosts(ctx *gofr.Context, page, pageSize int) (*models
 // UpdatePost updates an existing post
 func (ps *PostService) UpdatePost(ctx *gofr.Context, id int, req models.UpdatePostRequest) (*models.Post, error) {
 	// Let the handler handle validation of id
-	post, err := ps.postStore.UpdatePost(ctx, id, req)
+	var post *models.Post
+	err := ps.inTx(ctx, OpUpdatePost, func(uow *store.UnitOfWork) error {
+		var err error
+		post, err = ps.updatePost(ctx, uow, id, req)
+		return err
+	})
 	if err != nil {
 		return nil, errors.Join(ErrUpdateFailed, err)
 	}
+	ps.afterCommit(ctx, id)
 
 	ctx.Logger.Infof("Post updated successfully: %d", post.ID)
 	return post, nil
@@ -94,11 +262,196 @@ func (ps *PostService) UpdatePost(ctx *gofr.Context, id int, req models.UpdatePo
 // DeletePost removes a post by ID
 func (ps *PostService) DeletePost(ctx *gofr.Context, id int) error {
 	// Let the handler handle validation of id
-	err := ps.postStore.DeletePost(ctx, id)
+	err := ps.inTx(ctx, OpDeletePost, func(uow *store.UnitOfWork) error {
+		return ps.deletePost(ctx, uow, id)
+	})
 	if err != nil {
 		return errors.Join(ErrDeleteFailed, err)
 	}
+	ps.afterCommit(ctx, id)
 
 	ctx.Logger.Infof("Post deleted successfully: %d", id)
 	return nil
 }
+
+// createPost creates a post with its audit entries and events inside a transaction
+func (ps *PostService) createPost(ctx *gofr.Context, uow *store.UnitOfWork, req models.CreatePostRequest) (*models.Post, error) {
+	post, err := uow.Posts.CreatePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = ps.recordAudit(ctx, uow, nil, post); err != nil {
+		return nil, err
+	}
+	err = ps.enqueueEvents(ctx, uow, func(pe *PostEvents) []*models.PostEvent { return pe.Created(post) })
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// updatePost updates a post with its audit entries and events inside a transaction.
+// The row is locked before it is read, so the diffs are computed against the row updated.
+func (ps *PostService) updatePost(ctx *gofr.Context, uow *store.UnitOfWork, id int,
+	req models.UpdatePostRequest) (*models.Post, error) {
+	before, err := uow.Posts.GetPostForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	post, err := uow.Posts.UpdatePost(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = ps.recordAudit(ctx, uow, before, post); err != nil {
+		return nil, err
+	}
+	err = ps.enqueueEvents(ctx, uow, func(pe *PostEvents) []*models.PostEvent { return pe.Updated(before, post) })
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// deletePost deletes a post with its audit entries and events inside a transaction,
+// locking the row before the snapshot recorded for it is read
+func (ps *PostService) deletePost(ctx *gofr.Context, uow *store.UnitOfWork, id int) error {
+	post, err := uow.Posts.GetPostForUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+	// The tags go with the post, so the event's are read first
+	var tags map[int][]string
+	if ps.events != nil {
+		if tags, err = uow.Tags.ForPosts(ctx, []int{id}); err != nil {
+			return err
+		}
+	}
+	if err = uow.Posts.DeletePost(ctx, id); err != nil {
+		return err
+	}
+	if err = ps.recordAudit(ctx, uow, post, nil); err != nil {
+		return err
+	}
+	return ps.enqueueEvents(ctx, uow, func(pe *PostEvents) []*models.PostEvent {
+		post.Tags = tags[id]
+		return pe.Deleted(post)
+	})
+}
+
+// afterCommit drops the cached posts with ids and every cached list page once a mutation
+// commits, and starts the read-your-writes window
+func (ps *PostService) afterCommit(ctx *gofr.Context, ids ...int) {
+	if ps.cache != nil {
+		ps.cache.Invalidate(ctx, ids...)
+	}
+
+	now := time.Now()
+	ps.lastWrite.Store(now.UnixNano())
+	middleware.MarkWrite(ctx, ps.readYourWrites, now)
+}
+
+// readStore returns the post store for reads, bound to the primary inside a read-your-writes
+// window. With a cache every read is a cache fill, so fills after a recent mutation of this
+// instance read from the primary as well.
+func (ps *PostService) readStore(ctx *gofr.Context) *store.PostStore {
+	now := time.Now()
+	if middleware.ReadPrimary(ctx, ps.readYourWrites, now) {
+		return ps.postStore.Primary()
+	}
+	if ps.cache != nil && now.Sub(time.Unix(0, ps.lastWrite.Load())) < ps.readYourWrites {
+		return ps.postStore.Primary()
+	}
+	return ps.postStore
+}
+
+// inTx runs fn in a unit of work with the transaction options configured for operation
+func (ps *PostService) inTx(ctx *gofr.Context, operation string, fn func(uow *store.UnitOfWork) error) error {
+	if ps.begin == nil {
+		return store.RunInTx(ctx, ps.txOptions[operation], fn)
+	}
+
+	tx, err := ps.begin(ctx)
+	if err != nil {
+		return err
+	}
+	return store.RunTx(ctx, tx, ps.txOptions[operation], fn)
+}
+
+// enqueueEvents writes the events built by build to the outbox and to matching webhook
+// subscriptions when events are enabled, and publishes them to watchers once the unit of
+// work commits. Posts of the events without tags get their tags as of the unit of work.
+func (ps *PostService) enqueueEvents(ctx *gofr.Context, uow *store.UnitOfWork,
+	build func(pe *PostEvents) []*models.PostEvent) error {
+	if ps.events == nil {
+		return nil
+	}
+
+	events := build(ps.events)
+	if err := ps.attachEventTags(ctx, uow, events); err != nil {
+		return err
+	}
+	messages, err := ps.events.OutboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	if ps.outboxStore != nil {
+		if err = uow.Outbox.Enqueue(ctx, messages...); err != nil {
+			return err
+		}
+	}
+
+	if ps.watch != nil {
+		uow.AfterCommit(func() { ps.watch.Publish(events...) })
+	}
+
+	if ps.webhookStore != nil {
+		// messages[i] holds the serialized payload of events[i]
+		for i, event := range events {
+			if err = uow.Webhooks.EnqueueDeliveries(ctx, event.ID, event.Type, messages[i].Payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// attachEventTags sets the tags of the posts of events that have none
+func (ps *PostService) attachEventTags(ctx *gofr.Context, uow *store.UnitOfWork, events []*models.PostEvent) error {
+	var ids []int
+	for _, event := range events {
+		if event.Post != nil && event.Post.Tags == nil {
+			ids = append(ids, event.PostID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tags, err := uow.Tags.ForPosts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if event.Post != nil && event.Post.Tags == nil {
+			event.Post.Tags = tags[event.PostID]
+		}
+	}
+	return nil
+}
+
+// recordAudit appends the audit entries of a post mutation, attributed to the caller of ctx
+func (ps *PostService) recordAudit(ctx *gofr.Context, uow *store.UnitOfWork, before, after *models.Post) error {
+	if ps.auditStore == nil {
+		return nil
+	}
+
+	entries, err := PostAuditEntries(before, after)
+	if err != nil {
+		return err
+	}
+	stampAuditEntries(ctx, entries)
+
+	return uow.Audit.Append(ctx, entries...)
+}
@@ -12,7 +12,7 @@ func TestNewPostService(t *testing.T) {
 	mockStore := &store.PostStore{}
 
 	// Create a new service
-	service := NewPostService(mockStore)
+	service := NewPostService(mockStore, nil, nil, nil, nil)
 
 	// Check that the service has the correct store
 	if service.postStore != mockStore {
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"slices"
+	"sync"
+
+	"gofr-blog-service/models"
+)
+
+// Defaults of NewPostWatch
+const (
+	DefaultWatchBuffer  = 64
+	DefaultWatchHistory = 1000
+)
+
+// PostEventFilter selects the events a watcher receives; zero values match every event
+type PostEventFilter struct {
+	AuthorID int
+	// Status matches the post status after the change, or before it for deletions
+	Status string
+	Types  []string
+	// Tags matches posts with any of the tags
+	Tags []string
+}
+
+// Matches reports whether event passes the filter
+func (f PostEventFilter) Matches(event *models.PostEvent) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+	if f.AuthorID == 0 && f.Status == "" && len(f.Tags) == 0 {
+		return true
+	}
+	if event.Post == nil {
+		return false
+	}
+	if len(f.Tags) > 0 && !slices.ContainsFunc(event.Post.Tags, func(tag string) bool { return slices.Contains(f.Tags, tag) }) {
+		return false
+	}
+	return (f.AuthorID == 0 || event.Post.AuthorID == f.AuthorID) && (f.Status == "" || event.Post.Status == f.Status)
+}
+
+// Subscription is a live feed of post events
+type Subscription struct {
+	// Replay holds the buffered events published after the event the watcher resumes from
+	Replay []*models.PostEvent
+	// Gap reports that the event to resume from is no longer buffered, so events may have been missed
+	Gap bool
+	// Events receives the events published after subscribing. It is closed when the
+	// subscription is cancelled or falls behind.
+	Events <-chan *models.PostEvent
+
+	cancel func()
+}
+
+// Cancel ends the subscription
+func (s *Subscription) Cancel() {
+	s.cancel()
+}
+
+// PostWatch fans out the post events committed through this instance to live watchers and
+// keeps the latest events so watchers can resume after a disconnect. Watchers never block a
+// mutation: one that falls a full buffer behind is dropped and its channel closed.
+type PostWatch struct {
+	buffer      int
+	historySize int
+
+	mu       sync.Mutex
+	history  []*models.PostEvent
+	watchers map[chan *models.PostEvent]struct{}
+}
+
+// NewPostWatch creates a post watch queueing up to buffer events per watcher and keeping the
+// last history events for resumption
+func NewPostWatch(buffer, history int) *PostWatch {
+	if buffer <= 0 {
+		buffer = DefaultWatchBuffer
+	}
+	if history < 0 {
+		history = 0
+	}
+
+	return &PostWatch{
+		buffer:      buffer,
+		historySize: history,
+		watchers:    make(map[chan *models.PostEvent]struct{}),
+	}
+}
+
+// Subscribe starts a subscription. When lastEventID is set, the buffered events published
+// after it are replayed first; replay and live events never overlap or leave a gap between them.
+func (pw *PostWatch) Subscribe(lastEventID string) *Subscription {
+	events := make(chan *models.PostEvent, pw.buffer)
+
+	pw.mu.Lock()
+	replay, found := pw.since(lastEventID)
+	pw.watchers[events] = struct{}{}
+	pw.mu.Unlock()
+
+	return &Subscription{
+		Replay: replay,
+		Gap:    lastEventID != "" && !found,
+		Events: events,
+		cancel: func() {
+			pw.mu.Lock()
+			defer pw.mu.Unlock()
+			pw.drop(events)
+		},
+	}
+}
+
+// Since returns the buffered events published after the event lastEventID, and whether that
+// event is still buffered. An empty lastEventID returns every buffered event.
+func (pw *PostWatch) Since(lastEventID string) ([]*models.PostEvent, bool) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if lastEventID == "" {
+		return slices.Clone(pw.history), true
+	}
+	return pw.since(lastEventID)
+}
+
+// Publish buffers events and delivers them to every watcher
+func (pw *PostWatch) Publish(events ...*models.PostEvent) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if pw.historySize > 0 {
+		pw.history = append(pw.history, events...)
+		if excess := len(pw.history) - pw.historySize; excess > 0 {
+			pw.history = slices.Clone(pw.history[excess:])
+		}
+	}
+
+	for watcher := range pw.watchers {
+		for _, event := range events {
+			select {
+			case watcher <- event:
+				continue
+			default:
+			}
+			pw.drop(watcher)
+			break
+		}
+	}
+}
+
+// since returns the buffered events after the event lastEventID; the caller holds mu
+func (pw *PostWatch) since(lastEventID string) ([]*models.PostEvent, bool) {
+	if lastEventID == "" {
+		return nil, false
+	}
+	for i := len(pw.history) - 1; i >= 0; i-- {
+		if pw.history[i].ID == lastEventID {
+			return slices.Clone(pw.history[i+1:]), true
+		}
+	}
+	return nil, false
+}
+
+// drop ends the subscription of watcher; the caller holds mu
+func (pw *PostWatch) drop(watcher chan *models.PostEvent) {
+	if _, ok := pw.watchers[watcher]; ok {
+		delete(pw.watchers, watcher)
+		close(watcher)
+	}
+}
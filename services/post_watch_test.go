@@ -0,0 +1,92 @@
+package services
+
+import (
+	"testing"
+
+	"gofr-blog-service/models"
+)
+
+// TestPostWatch tests delivery to watchers, unsubscribing and dropping watchers that fall behind
+func TestPostWatch(t *testing.T) {
+	pw := NewPostWatch(2, 0)
+	fast := pw.Subscribe("")
+	slow := pw.Subscribe("")
+
+	created := &models.PostEvent{Type: models.PostCreated, PostID: 1}
+	pw.Publish(created)
+	if event := <-fast.Events; event != created {
+		t.Errorf("Expected the created event, got %+v", event)
+	}
+
+	pw.Publish(&models.PostEvent{Type: models.PostUpdated}, &models.PostEvent{Type: models.PostDeleted})
+	<-fast.Events
+	<-fast.Events
+
+	// slow now holds three events in a buffer of two
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	if received != 2 {
+		t.Errorf("Expected the slow watcher to be dropped after 2 events, got %d", received)
+	}
+
+	fast.Cancel()
+	if _, ok := <-fast.Events; ok {
+		t.Error("Expected the channel to be closed after cancel")
+	}
+	fast.Cancel()
+	pw.Publish(created)
+}
+
+// TestPostWatch_Resume tests replaying buffered events after a Last-Event-ID and reporting gaps
+func TestPostWatch_Resume(t *testing.T) {
+	pw := NewPostWatch(10, 3)
+	for _, id := range []string{"e1", "e2", "e3", "e4"} {
+		pw.Publish(&models.PostEvent{ID: id, Type: models.PostUpdated})
+	}
+
+	sub := pw.Subscribe("e2")
+	if sub.Gap || len(sub.Replay) != 2 || sub.Replay[0].ID != "e3" || sub.Replay[1].ID != "e4" {
+		t.Errorf("Expected replay of e3 and e4 without gap, got %+v (gap %v)", sub.Replay, sub.Gap)
+	}
+	sub.Cancel()
+
+	// e1 was evicted by the history of 3
+	sub = pw.Subscribe("e1")
+	if !sub.Gap || len(sub.Replay) != 0 {
+		t.Errorf("Expected a gap for an evicted event, got %+v (gap %v)", sub.Replay, sub.Gap)
+	}
+	sub.Cancel()
+
+	if recent, _ := pw.Since(""); len(recent) != 3 || recent[0].ID != "e2" {
+		t.Errorf("Expected the 3 latest events, got %+v", recent)
+	}
+}
+
+// TestPostEventFilter tests matching events by type, author, status and tags
+func TestPostEventFilter(t *testing.T) {
+	event := &models.PostEvent{Type: models.PostPublished,
+		Post: &models.Post{AuthorID: 7, Status: "published", Tags: []string{"go", "web"}}}
+
+	tests := []struct {
+		filter   PostEventFilter
+		expected bool
+	}{
+		{PostEventFilter{}, true},
+		{PostEventFilter{Types: []string{models.PostPublished}}, true},
+		{PostEventFilter{Types: []string{models.PostCreated}}, false},
+		{PostEventFilter{AuthorID: 7, Status: "published"}, true},
+		{PostEventFilter{AuthorID: 8}, false},
+		{PostEventFilter{Status: "draft"}, false},
+		{PostEventFilter{Tags: []string{"rust", "web"}}, true},
+		{PostEventFilter{Tags: []string{"rust"}}, false},
+		{PostEventFilter{AuthorID: 7, Tags: []string{"go"}}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Matches(event); got != tt.expected {
+			t.Errorf("%+v: expected %v, got %v", tt.filter, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"time"
+)
+
+// RetryBackoff returns the exponential delay before the next attempt after attempts failures, capped at limit
+func RetryBackoff(base time.Duration, attempts int, limit time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
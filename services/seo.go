@@ -0,0 +1,123 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// excerptLength is the maximum length of a generated description
+const excerptLength = 160
+
+var (
+	markdownLinkPattern   = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownSyntaxPattern = regexp.MustCompile("[#*_`>~]+")
+)
+
+// SEOService builds search engine and social metadata for posts
+type SEOService struct {
+	postService *PostService
+	site        SiteConfig
+}
+
+// NewSEOService creates a new SEO service instance
+func NewSEOService(postService *PostService, site SiteConfig) *SEOService {
+	return &SEOService{
+		postService: postService,
+		site:        site,
+	}
+}
+
+// GetPostSEO returns the resolved SEO metadata for a post
+func (ss *SEOService) GetPostSEO(ctx *gofr.Context, id int) (*models.SEOMetadata, error) {
+	post, err := ss.postService.GetPost(ctx, id, nil)
+	if err != nil {
+		return nil, errors.Join(ErrSEOFailed, err)
+	}
+
+	return BuildSEOMetadata(post, ss.site), nil
+}
+
+// BuildSEOMetadata resolves the SEO metadata of a post, falling back to its title and excerpt
+func BuildSEOMetadata(post *models.Post, site SiteConfig) *models.SEOMetadata {
+	title := post.MetaTitle
+	if title == "" {
+		title = post.Title
+	}
+
+	description := post.MetaDescription
+	if description == "" {
+		description = Excerpt(post.Content, excerptLength)
+	}
+
+	canonicalURL := post.CanonicalURL
+	if canonicalURL == "" {
+		canonicalURL = site.PostURL(post)
+	}
+
+	robots := "index, follow"
+	if post.NoIndex {
+		robots = "noindex, nofollow"
+	}
+
+	openGraph := map[string]string{
+		"og:type":                "article",
+		"og:title":               title,
+		"og:description":         description,
+		"og:url":                 canonicalURL,
+		"og:site_name":           site.Name,
+		"article:published_time": post.CreatedAt.Format(time.RFC3339),
+		"article:modified_time":  post.UpdatedAt.Format(time.RFC3339),
+	}
+
+	structuredData := map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         title,
+		"description":      description,
+		"url":              canonicalURL,
+		"mainEntityOfPage": map[string]any{"@type": "WebPage", "@id": canonicalURL},
+		"datePublished":    post.CreatedAt.Format(time.RFC3339),
+		"dateModified":     post.UpdatedAt.Format(time.RFC3339),
+		"author":           map[string]any{"@type": "Person", "identifier": strconv.Itoa(post.AuthorID)},
+		"publisher":        map[string]any{"@type": "Organization", "name": site.Name},
+	}
+
+	if post.OGImage != "" {
+		openGraph["og:image"] = post.OGImage
+		structuredData["image"] = post.OGImage
+	}
+
+	return &models.SEOMetadata{
+		Title:          title,
+		Description:    description,
+		CanonicalURL:   canonicalURL,
+		Robots:         robots,
+		OpenGraph:      openGraph,
+		StructuredData: structuredData,
+	}
+}
+
+// Excerpt returns a plain-text excerpt of Markdown content, cut at a word boundary
+func Excerpt(content string, maxLength int) string {
+	text := markdownLinkPattern.ReplaceAllString(content, "$1")
+	text = markdownSyntaxPattern.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	cut := string(runes[:maxLength])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, ".,;:") + "…"
+}
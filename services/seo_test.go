@@ -0,0 +1,77 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+// TestBuildSEOMetadata_Fallbacks tests that missing SEO fields fall back to title and excerpt
+func TestBuildSEOMetadata_Fallbacks(t *testing.T) {
+	post := &models.Post{
+		ID:        1,
+		Title:     "Introduction to GoFr",
+		Content:   "## Getting started\n\nGoFr is an **opinionated** framework. See [the docs](https://gofr.dev).",
+		Slug:      "introduction-to-gofr",
+		AuthorID:  7,
+		CreatedAt: time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 16, 10, 30, 0, 0, time.UTC),
+	}
+
+	seo := BuildSEOMetadata(post, SiteConfig{BaseURL: "https://blog.example.com/", Name: "Example"})
+
+	if seo.Title != post.Title {
+		t.Errorf("Expected title %q, got %q", post.Title, seo.Title)
+	}
+	if seo.Description != "Getting started GoFr is an opinionated framework. See the docs." {
+		t.Errorf("Unexpected description %q", seo.Description)
+	}
+	if seo.CanonicalURL != "https://blog.example.com/posts/introduction-to-gofr" {
+		t.Errorf("Unexpected canonical URL %q", seo.CanonicalURL)
+	}
+	if seo.StructuredData["@type"] != "BlogPosting" {
+		t.Errorf("Expected BlogPosting structured data, got %v", seo.StructuredData["@type"])
+	}
+	if _, ok := seo.OpenGraph["og:image"]; ok {
+		t.Errorf("Expected no og:image without an image")
+	}
+}
+
+// TestBuildSEOMetadata_Overrides tests that explicit SEO fields win over fallbacks
+func TestBuildSEOMetadata_Overrides(t *testing.T) {
+	post := &models.Post{
+		Title:           "Title",
+		Content:         "Some content here",
+		MetaTitle:       "Meta title",
+		MetaDescription: "Meta description",
+		CanonicalURL:    "https://example.com/canonical",
+		OGImage:         "https://example.com/image.png",
+		NoIndex:         true,
+	}
+
+	seo := BuildSEOMetadata(post, SiteConfig{BaseURL: "https://blog.example.com"})
+
+	if seo.Title != "Meta title" || seo.Description != "Meta description" {
+		t.Errorf("Expected meta overrides, got %q / %q", seo.Title, seo.Description)
+	}
+	if seo.CanonicalURL != post.CanonicalURL {
+		t.Errorf("Expected canonical URL %q, got %q", post.CanonicalURL, seo.CanonicalURL)
+	}
+	if seo.Robots != "noindex, nofollow" {
+		t.Errorf("Expected noindex robots, got %q", seo.Robots)
+	}
+	if seo.OpenGraph["og:image"] != post.OGImage {
+		t.Errorf("Expected og:image %q, got %q", post.OGImage, seo.OpenGraph["og:image"])
+	}
+}
+
+// TestExcerpt tests that long content is cut at a word boundary
+func TestExcerpt(t *testing.T) {
+	excerpt := Excerpt(strings.Repeat("word ", 100), 22)
+
+	if excerpt != "word word word word…" {
+		t.Errorf("Unexpected excerpt %q", excerpt)
+	}
+}
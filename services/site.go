@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gofr-blog-service/models"
+)
+
+// DefaultPermalinkPattern is the permalink pattern used when none is configured
+const DefaultPermalinkPattern = "/posts/:slug"
+
+// SiteConfig holds the public site settings used when rendering links and metadata
+type SiteConfig struct {
+	BaseURL string
+	Name    string
+
+	// PermalinkPattern builds post paths from the :slug, :id, :year, :month and :day tokens
+	PermalinkPattern string
+
+	// Locale is the language posts are written in, used as the hreflang of their own URL
+	Locale string
+}
+
+// hreflangLink is a language version of a post page
+type hreflangLink struct {
+	HrefLang string
+	URL      string
+}
+
+// PostURL returns the public URL of a post built from the permalink pattern
+func (sc SiteConfig) PostURL(post *models.Post) string {
+	return sc.URL(sc.postPath(post, post.Slug))
+}
+
+// LocalizedPostURL returns the public URL of a translation of a post: the permalink built
+// with the translated slug under a path prefix naming the locale, such as /de/posts/:slug
+func (sc SiteConfig) LocalizedPostURL(post *models.Post, alternate models.PostAlternate) string {
+	return sc.URL("/" + strings.ToLower(alternate.Locale) + sc.postPath(post, alternate.Slug))
+}
+
+// hreflangLinks returns the language versions of a post, its own URL first, or nil when the
+// post has no translations or the site locale is not set
+func (sc SiteConfig) hreflangLinks(post *models.Post) []hreflangLink {
+	if len(post.Alternates) == 0 || sc.Locale == "" {
+		return nil
+	}
+
+	links := []hreflangLink{{HrefLang: sc.Locale, URL: sc.PostURL(post)}}
+	for _, alternate := range post.Alternates {
+		links = append(links, hreflangLink{HrefLang: alternate.Locale, URL: sc.LocalizedPostURL(post, alternate)})
+	}
+	return links
+}
+
+// postPath returns the path of a post built from the permalink pattern with slug
+func (sc SiteConfig) postPath(post *models.Post, slug string) string {
+	pattern := sc.PermalinkPattern
+	if pattern == "" {
+		pattern = DefaultPermalinkPattern
+	}
+
+	date := publishedAt(post)
+	path := strings.NewReplacer(
+		":slug", slug,
+		":id", strconv.Itoa(post.ID),
+		":year", strconv.Itoa(date.Year()),
+		":month", fmt.Sprintf("%02d", int(date.Month())),
+		":day", fmt.Sprintf("%02d", date.Day()),
+	).Replace(pattern)
+
+	return "/" + strings.TrimLeft(path, "/")
+}
+
+// URL returns the absolute public URL of a site path
+func (sc SiteConfig) URL(path string) string {
+	return strings.TrimRight(sc.BaseURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// SitemapURLLimit is the maximum number of URLs a single sitemap may list
+const SitemapURLLimit = 50000
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// xhtmlNamespace qualifies the hreflang alternate links of sitemap URLs
+const xhtmlNamespace = "http://www.w3.org/1999/xhtml"
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	XHTMLNS string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod"`
+	Alternates []sitemapAlternate `xml:"xhtml:link"`
+}
+
+// sitemapAlternate is an hreflang link to a language version of a sitemap URL
+type sitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// SitemapService builds XML sitemaps of the indexable published posts
+type SitemapService struct {
+	postStore    *store.PostStore
+	translations *TranslationService
+	site         SiteConfig
+	chunkSize    int
+}
+
+// NewSitemapService creates a new sitemap service instance
+func NewSitemapService(postStore *store.PostStore, site SiteConfig) *SitemapService {
+	return &SitemapService{
+		postStore: postStore,
+		site:      site,
+		chunkSize: SitemapURLLimit,
+	}
+}
+
+// SetTranslations lists the translations of posts as hreflang alternates of their URLs
+func (ss *SitemapService) SetTranslations(translations *TranslationService) {
+	ss.translations = translations
+}
+
+// Sitemap renders /sitemap.xml, switching to a sitemap index once the posts exceed one sitemap
+func (ss *SitemapService) Sitemap(ctx *gofr.Context) ([]byte, error) {
+	chunks, err := ss.postStore.GetSitemapChunks(ctx, ss.chunkSize)
+	if err != nil {
+		return nil, errors.Join(ErrSitemapFailed, err)
+	}
+
+	if len(chunks) > 1 {
+		return marshalSitemap(BuildSitemapIndex(chunks, ss.site))
+	}
+
+	return ss.ChildSitemap(ctx, 1)
+}
+
+// ChildSitemap renders the numbered child sitemap of a sitemap index, starting at 1
+func (ss *SitemapService) ChildSitemap(ctx *gofr.Context, index int) ([]byte, error) {
+	if index <= 0 {
+		return nil, ErrSitemapNotFound
+	}
+
+	posts, err := ss.postStore.GetSitemapPosts(ctx, ss.chunkSize, (index-1)*ss.chunkSize)
+	if err != nil {
+		return nil, errors.Join(ErrSitemapFailed, err)
+	}
+
+	if len(posts) == 0 && index > 1 {
+		return nil, ErrSitemapNotFound
+	}
+
+	if ss.translations != nil {
+		if err = ss.translations.AttachAlternates(ctx, posts); err != nil {
+			return nil, errors.Join(ErrSitemapFailed, err)
+		}
+	}
+
+	return marshalSitemap(BuildURLSet(posts, ss.site))
+}
+
+// BuildURLSet lists posts as sitemap URLs with their last modification date. Translated posts
+// link their language versions as hreflang alternates, with the post itself as x-default.
+func BuildURLSet(posts []models.Post, site SiteConfig) any {
+	urlSet := sitemapURLSet{XMLNS: sitemapNamespace, URLs: make([]sitemapURL, 0, len(posts))}
+	for i := range posts {
+		loc := site.PostURL(&posts[i])
+		entry := sitemapURL{Loc: loc, LastMod: posts[i].UpdatedAt.UTC().Format(time.RFC3339)}
+
+		if versions := site.hreflangLinks(&posts[i]); len(versions) > 0 {
+			urlSet.XHTMLNS = xhtmlNamespace
+			for _, version := range versions {
+				entry.Alternates = append(entry.Alternates,
+					sitemapAlternate{Rel: "alternate", HrefLang: version.HrefLang, Href: version.URL})
+			}
+			entry.Alternates = append(entry.Alternates,
+				sitemapAlternate{Rel: "alternate", HrefLang: "x-default", Href: loc})
+		}
+
+		urlSet.URLs = append(urlSet.URLs, entry)
+	}
+	return urlSet
+}
+
+// BuildSitemapIndex lists the numbered child sitemaps of a sitemap index
+func BuildSitemapIndex(chunks []models.SitemapChunk, site SiteConfig) any {
+	index := sitemapIndex{XMLNS: sitemapNamespace, Sitemaps: make([]sitemapURL, 0, len(chunks))}
+	for _, chunk := range chunks {
+		index.Sitemaps = append(index.Sitemaps, sitemapURL{
+			Loc:     site.URL("/sitemap-" + strconv.Itoa(chunk.Index+1) + ".xml"),
+			LastMod: chunk.LastModified.UTC().Format(time.RFC3339),
+		})
+	}
+	return index
+}
+
+func marshalSitemap(v any) ([]byte, error) {
+	body, err := marshalXML(v)
+	if err != nil {
+		return nil, errors.Join(ErrSitemapFailed, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+// TestSiteConfig_PostURL tests permalink pattern expansion
+func TestSiteConfig_PostURL(t *testing.T) {
+	published := time.Date(2025, 3, 7, 12, 0, 0, 0, time.UTC)
+	post := &models.Post{ID: 42, Slug: "hello-world", PublishedAt: &published}
+
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"", "https://blog.example.com/posts/hello-world"},
+		{"/:year/:month/:day/:slug/", "https://blog.example.com/2025/03/07/hello-world/"},
+		{"p/:id-:slug", "https://blog.example.com/p/42-hello-world"},
+	}
+
+	for _, tt := range tests {
+		site := SiteConfig{BaseURL: "https://blog.example.com/", PermalinkPattern: tt.pattern}
+		if got := site.PostURL(post); got != tt.expected {
+			t.Errorf("Pattern %q: expected %q, got %q", tt.pattern, tt.expected, got)
+		}
+	}
+}
+
+// TestBuildSitemapIndex tests that chunks become numbered child sitemaps
+func TestBuildSitemapIndex(t *testing.T) {
+	chunks := []models.SitemapChunk{
+		{Index: 0, LastModified: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Index: 1, LastModified: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	body, err := marshalSitemap(BuildSitemapIndex(chunks, SiteConfig{BaseURL: "https://blog.example.com"}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sitemap := string(body)
+	if !strings.Contains(sitemap, "<sitemapindex xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\">") {
+		t.Errorf("Expected a sitemap index, got %s", sitemap)
+	}
+	if !strings.Contains(sitemap, "<loc>https://blog.example.com/sitemap-2.xml</loc>") {
+		t.Errorf("Expected numbered child sitemaps, got %s", sitemap)
+	}
+	if !strings.Contains(sitemap, "<lastmod>2025-02-01T00:00:00Z</lastmod>") {
+		t.Errorf("Expected chunk lastmod, got %s", sitemap)
+	}
+}
+
+// TestSiteConfig_LocalizedPostURL tests that translations live under a locale prefix with their own slug
+func TestSiteConfig_LocalizedPostURL(t *testing.T) {
+	published := time.Date(2025, 3, 7, 12, 0, 0, 0, time.UTC)
+	post := &models.Post{ID: 42, Slug: "hello-world", PublishedAt: &published}
+	site := SiteConfig{BaseURL: "https://blog.example.com/", PermalinkPattern: "/:year/:slug/"}
+
+	got := site.LocalizedPostURL(post, models.PostAlternate{Locale: "de-AT", Slug: "hallo-welt"})
+	if expected := "https://blog.example.com/de-at/2025/hallo-welt/"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestBuildURLSet_Alternates tests that translated posts list hreflang alternates and an x-default
+func TestBuildURLSet_Alternates(t *testing.T) {
+	posts := []models.Post{
+		{ID: 1, Slug: "hello", Alternates: []models.PostAlternate{{Locale: "de", Slug: "hallo"}}},
+		{ID: 2, Slug: "untranslated"},
+	}
+	site := SiteConfig{BaseURL: "https://blog.example.com", Locale: "en"}
+
+	body, err := marshalSitemap(BuildURLSet(posts, site))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sitemap := string(body)
+	for _, expected := range []string{
+		`xmlns:xhtml="http://www.w3.org/1999/xhtml"`,
+		`<xhtml:link rel="alternate" hreflang="en" href="https://blog.example.com/posts/hello"></xhtml:link>`,
+		`<xhtml:link rel="alternate" hreflang="de" href="https://blog.example.com/de/posts/hallo"></xhtml:link>`,
+		`<xhtml:link rel="alternate" hreflang="x-default" href="https://blog.example.com/posts/hello"></xhtml:link>`,
+	} {
+		if !strings.Contains(sitemap, expected) {
+			t.Errorf("Expected %s in %s", expected, sitemap)
+		}
+	}
+	if strings.Count(sitemap, "<xhtml:link") != 3 {
+		t.Errorf("Expected alternates on the translated post only, got %s", sitemap)
+	}
+}
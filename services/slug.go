@@ -0,0 +1,38 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxSlugLength is the longest slug accepted by post validation
+const maxSlugLength = 200
+
+// Slugify derives a URL slug from a title: lowercase letters and digits joined by single hyphens
+func Slugify(title string) string {
+	var slug strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if hyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			hyphen = false
+			slug.WriteRune(r)
+		case r != '\'' && r != '’':
+			hyphen = true
+		}
+	}
+
+	result := slug.String()
+	for len(result) > maxSlugLength {
+		cut := strings.LastIndexByte(result[:maxSlugLength+1], '-')
+		if cut <= 0 {
+			result = strings.ToValidUTF8(result[:maxSlugLength], "")
+			break
+		}
+		result = result[:cut]
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSlugify tests deriving slugs from titles
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Go 1.22 -- What's new?  ", "go-1-22-whats-new"},
+		{"Ünïcode Títles", "ünïcode-títles"},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.title); got != tt.expected {
+			t.Errorf("Slugify(%q): expected %q, got %q", tt.title, tt.expected, got)
+		}
+	}
+
+	long := Slugify(strings.Repeat("word ", 60))
+	if len(long) > maxSlugLength || strings.HasSuffix(long, "-") || !strings.HasSuffix(long, "word") {
+		t.Errorf("Expected a long slug cut at a word boundary, got %q", long)
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+const (
+	// maxTagLength is the longest tag accepted, after normalization
+	maxTagLength = 50
+
+	// maxPostTags is the most tags a post can have
+	maxPostTags = 20
+)
+
+// ErrInvalidTag is returned for tags that are empty or too long once normalized, and for too many tags
+var ErrInvalidTag = errors.New("invalid tag")
+
+// TagService manages the tags of posts. Like a translation, setting the tags of a post bumps
+// its update time and drops it from the cache, so feed and list validators follow the tags.
+// It also emits post.updated with tags as the changed field, so watchers filtering by tag
+// see posts enter and leave the tag.
+type TagService struct {
+	tagStore    *store.TagStore
+	postService *PostService
+}
+
+// NewTagService creates a new tag service instance
+func NewTagService(tagStore *store.TagStore, postService *PostService) *TagService {
+	return &TagService{
+		tagStore:    tagStore,
+		postService: postService,
+	}
+}
+
+// Set replaces the tags of the post with postID, normalizing them with NormalizeTags
+func (ts *TagService) Set(ctx *gofr.Context, postID int, tags []string) (*models.PostTags, error) {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.RunInTx(ctx, store.TxOptions{}, func(uow *store.UnitOfWork) error {
+		_, err := ts.replace(ctx, uow, postID, tags)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrTagFailed, err)
+	}
+
+	ts.postService.afterCommit(ctx, postID)
+	return &models.PostTags{PostID: postID, Tags: tags}, nil
+}
+
+// replace replaces the tags of the post with postID inside uow with tags, which are normalized,
+// bumping the post's update time and emitting its post.updated. It returns the tagged post.
+func (ts *TagService) replace(ctx *gofr.Context, uow *store.UnitOfWork, postID int, tags []string) (*models.Post, error) {
+	post, err := uow.Posts.TouchPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err = ts.setTags(ctx, uow, postID, tags); err != nil {
+		return nil, err
+	}
+
+	post.Tags = tags
+	err = ts.postService.enqueueEvents(ctx, uow, func(*PostEvents) []*models.PostEvent {
+		return []*models.PostEvent{NewPostEvent(models.PostUpdated, post, []string{"tags"})}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// New returns the tags, normalized, that no post has yet
+func (ts *TagService) New(ctx *gofr.Context, tags []string) ([]string, error) {
+	used, err := ts.tagStore.Used(ctx, tags)
+	if err != nil {
+		return nil, errors.Join(ErrTagFailed, err)
+	}
+
+	fresh := []string{}
+	for _, tag := range tags {
+		if !used[tag] {
+			fresh = append(fresh, tag)
+		}
+	}
+	return fresh, nil
+}
+
+// setTags replaces the tags of the post with postID inside uow with tags, which are normalized
+func (ts *TagService) setTags(ctx *gofr.Context, uow *store.UnitOfWork, postID int, tags []string) error {
+	return uow.Tags.SetPostTags(ctx, postID, tags)
+}
+
+// List retrieves the tags of the post with postID
+func (ts *TagService) List(ctx *gofr.Context, postID int) (*models.PostTags, error) {
+	if _, err := ts.postService.GetPost(ctx, postID, []string{"id"}); err != nil {
+		return nil, err
+	}
+
+	tags, err := ts.tagStore.ForPosts(ctx, []int{postID})
+	if err != nil {
+		return nil, errors.Join(ErrTagFailed, err)
+	}
+
+	result := &models.PostTags{PostID: postID, Tags: tags[postID]}
+	if result.Tags == nil {
+		result.Tags = []string{}
+	}
+	return result, nil
+}
+
+// ForPosts retrieves the tags of the posts with postIDs in one query, keyed by post id
+func (ts *TagService) ForPosts(ctx *gofr.Context, postIDs []int) (map[int][]string, error) {
+	tags, err := ts.tagStore.ForPosts(ctx, postIDs)
+	if err != nil {
+		return nil, errors.Join(ErrTagFailed, err)
+	}
+	return tags, nil
+}
+
+// Attach sets the tags of posts
+func (ts *TagService) Attach(ctx *gofr.Context, posts []models.Post) error {
+	ids := make([]int, 0, len(posts))
+	for i := range posts {
+		ids = append(ids, posts[i].ID)
+	}
+
+	tags, err := ts.ForPosts(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].Tags = tags[posts[i].ID]
+	}
+	return nil
+}
+
+// NormalizeTag turns a tag into its stored form, lowercase letters and digits joined by
+// single hyphens, so "Go Lang" and "go-lang" are the same tag
+func NormalizeTag(tag string) string {
+	return Slugify(tag)
+}
+
+// NormalizeTags normalizes tags and returns them sorted without duplicates
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := NormalizeTag(tag)
+		if name == "" || len(name) > maxTagLength {
+			return nil, errors.Join(ErrInvalidTag, errors.New("tags must have 1 to "+
+				strconv.Itoa(maxTagLength)+" letters, digits or hyphens: "+strconv.Quote(tag)))
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+
+	if len(normalized) > maxPostTags {
+		return nil, errors.Join(ErrInvalidTag, errors.New("a post can have at most "+strconv.Itoa(maxPostTags)+" tags"))
+	}
+
+	sort.Strings(normalized)
+	return normalized, nil
+}
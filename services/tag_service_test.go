@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestNormalizeTags tests that tags are slugified, deduplicated and sorted
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{"Go Lang", "databases", "go-lang", "  API design "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"api-design", "databases", "go-lang"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+}
+
+// TestNormalizeTags_Invalid tests that empty and overlong tags and too many tags are rejected
+func TestNormalizeTags_Invalid(t *testing.T) {
+	tooMany := make([]string, maxPostTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag-" + strconv.Itoa(i)
+	}
+
+	tests := []struct {
+		name string
+		tags []string
+	}{
+		{"empty", []string{"go", "  "}},
+		{"punctuation only", []string{"!!!"}},
+		{"too long", []string{strings.Repeat("a", maxTagLength+1)}},
+		{"too many", tooMany},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NormalizeTags(tt.tags); !errors.Is(err, ErrInvalidTag) {
+				t.Errorf("Expected ErrInvalidTag, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+
+	"gofr-blog-service/i18n"
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// DefaultLocale is the locale of posts when no site locales are configured
+const DefaultLocale = "en"
+
+var (
+	// ErrInvalidLocale is returned for translations into locales the site does not publish in,
+	// including its default locale, which the post itself is written in
+	ErrInvalidLocale = errors.New("locale is not a translation locale of the site")
+
+	// ErrTranslationSlugTaken is returned when another post's translation holds a slug in the same locale
+	ErrTranslationSlugTaken = errors.New("slug is already used by another translation in this locale")
+)
+
+// TranslationService manages post translations and localizes posts along locale fallback
+// chains. Writing a translation bumps its post's update time and drops it from the cache, so
+// list validators and cached pages follow the translation.
+type TranslationService struct {
+	translationStore *store.TranslationStore
+	postService      *PostService
+	locales          []string
+}
+
+// NewTranslationService creates a translation service for the site's locales, normalized
+// language tags of which the first is the default locale posts are written in. With no
+// locales the site publishes in DefaultLocale only.
+func NewTranslationService(translationStore *store.TranslationStore, postService *PostService,
+	locales []string) *TranslationService {
+	if len(locales) == 0 {
+		locales = []string{DefaultLocale}
+	}
+	return &TranslationService{
+		translationStore: translationStore,
+		postService:      postService,
+		locales:          locales,
+	}
+}
+
+// DefaultLocale returns the locale posts are written in
+func (ts *TranslationService) DefaultLocale() string {
+	return ts.locales[0]
+}
+
+// Chain returns the site locales to try for the requested language tags, most preferred
+// first and ending with the default locale
+func (ts *TranslationService) Chain(requested []string) []string {
+	return i18n.Chain(requested, ts.locales, ts.DefaultLocale())
+}
+
+// Localize replaces the title, content, slug and SEO fields of posts with their translation
+// in the first locale of chain that has one, and sets the locale each post is rendered in.
+// Posts with no translation along the chain keep their default locale fields.
+func (ts *TranslationService) Localize(ctx *gofr.Context, chain []string, posts ...*models.Post) error {
+	for _, post := range posts {
+		post.Locale = ts.DefaultLocale()
+	}
+
+	// The chain ends with the default locale, which the posts are already in
+	locales := chain
+	if len(locales) > 0 {
+		locales = locales[:len(locales)-1]
+	}
+	if len(locales) == 0 || len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	translations, err := ts.translationStore.ForPosts(ctx, ids, locales)
+	if err != nil {
+		return errors.Join(ErrTranslateFailed, err)
+	}
+
+	byPost := make(map[int]map[string]*models.PostTranslation, len(posts))
+	for i := range translations {
+		translation := &translations[i]
+		if byPost[translation.PostID] == nil {
+			byPost[translation.PostID] = make(map[string]*models.PostTranslation)
+		}
+		byPost[translation.PostID][translation.Locale] = translation
+	}
+
+	for _, post := range posts {
+		for _, locale := range locales {
+			if translation, ok := byPost[post.ID][locale]; ok {
+				applyTranslation(post, translation)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Upsert creates or replaces the translation of the post with postID in locale
+func (ts *TranslationService) Upsert(ctx *gofr.Context, postID int, locale string,
+	req models.TranslationRequest) (*models.PostTranslation, error) {
+	locale, err := ts.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var translation *models.PostTranslation
+	err = store.RunInTx(ctx, store.TxOptions{}, func(uow *store.UnitOfWork) error {
+		if _, err := uow.Posts.TouchPost(ctx, postID); err != nil {
+			return err
+		}
+
+		holder, err := uow.Translations.GetBySlug(ctx, locale, req.Slug)
+		switch {
+		case err == nil && holder.PostID != postID:
+			return ErrTranslationSlugTaken
+		case err != nil && !errors.Is(err, store.ErrNotFound):
+			return err
+		}
+
+		translation, err = uow.Translations.Upsert(ctx, postID, locale, req)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Join(ErrTranslateFailed, err)
+	}
+
+	ts.postService.afterCommit(ctx, postID)
+	return translation, nil
+}
+
+// List retrieves the translations of the post with postID
+func (ts *TranslationService) List(ctx *gofr.Context, postID int) ([]models.PostTranslation, error) {
+	if _, err := ts.postService.GetPost(ctx, postID, []string{"id"}); err != nil {
+		return nil, err
+	}
+
+	translations, err := ts.translationStore.List(ctx, postID)
+	if err != nil {
+		return nil, errors.Join(ErrTranslateFailed, err)
+	}
+	return translations, nil
+}
+
+// Delete removes the translation of the post with postID in locale
+func (ts *TranslationService) Delete(ctx *gofr.Context, postID int, locale string) error {
+	locale, err := ts.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	err = store.RunInTx(ctx, store.TxOptions{}, func(uow *store.UnitOfWork) error {
+		if _, err := uow.Posts.TouchPost(ctx, postID); err != nil {
+			return err
+		}
+		return uow.Translations.Delete(ctx, postID, locale)
+	})
+	if err != nil {
+		return errors.Join(ErrTranslateFailed, err)
+	}
+
+	ts.postService.afterCommit(ctx, postID)
+	return nil
+}
+
+// Locales reports which site locales the post with postID is available and missing in
+func (ts *TranslationService) Locales(ctx *gofr.Context, postID int) (*models.PostLocales, error) {
+	translations, err := ts.List(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	translated := make([]string, 0, len(translations))
+	for i := range translations {
+		translated = append(translated, translations[i].Locale)
+	}
+	return PostLocales(postID, ts.locales, translated), nil
+}
+
+// AttachAlternates sets the translations of posts in the site's locales as their hreflang
+// alternates
+func (ts *TranslationService) AttachAlternates(ctx *gofr.Context, posts []models.Post) error {
+	ids := make([]int, 0, len(posts))
+	for i := range posts {
+		ids = append(ids, posts[i].ID)
+	}
+
+	alternates, err := ts.translationStore.Alternates(ctx, ids)
+	if err != nil {
+		return errors.Join(ErrTranslateFailed, err)
+	}
+
+	for i := range posts {
+		posts[i].Alternates = nil
+		for _, alternate := range alternates[posts[i].ID] {
+			if ts.isTranslationLocale(alternate.Locale) {
+				posts[i].Alternates = append(posts[i].Alternates, alternate)
+			}
+		}
+	}
+	return nil
+}
+
+// PostLocales builds the locale report of a post translated into translated, listing the site
+// locales in their configured order; locales is the site's, the first being the default
+func PostLocales(postID int, locales, translated []string) *models.PostLocales {
+	has := make(map[string]bool, len(translated))
+	for _, locale := range translated {
+		has[locale] = true
+	}
+
+	report := &models.PostLocales{
+		PostID:    postID,
+		Default:   locales[0],
+		Available: []string{locales[0]},
+		Missing:   []string{},
+	}
+	for _, locale := range locales[1:] {
+		if has[locale] {
+			report.Available = append(report.Available, locale)
+		} else {
+			report.Missing = append(report.Missing, locale)
+		}
+	}
+	return report
+}
+
+// translationLocale normalizes locale and checks that posts can be translated into it
+func (ts *TranslationService) translationLocale(locale string) (string, error) {
+	normalized := i18n.Normalize(locale)
+	if !ts.isTranslationLocale(normalized) {
+		return "", errors.Join(ErrInvalidLocale, errors.New(locale))
+	}
+	return normalized, nil
+}
+
+// isTranslationLocale reports whether locale is a site locale other than the default
+func (ts *TranslationService) isTranslationLocale(locale string) bool {
+	for _, candidate := range ts.locales[1:] {
+		if candidate == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTranslation overlays the translated fields of translation on post
+func applyTranslation(post *models.Post, translation *models.PostTranslation) {
+	post.Locale = translation.Locale
+	post.Title = translation.Title
+	post.Content = translation.Content
+	post.Slug = translation.Slug
+	post.MetaTitle = translation.MetaTitle
+	post.MetaDescription = translation.MetaDescription
+	post.CanonicalURL = translation.CanonicalURL
+	post.OGImage = translation.OGImage
+}
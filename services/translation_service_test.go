@@ -0,0 +1,40 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"gofr-blog-service/models"
+)
+
+// TestPostLocales tests reporting available and missing locales in configured order
+func TestPostLocales(t *testing.T) {
+	report := PostLocales(7, []string{"en", "de", "ja", "fr"}, []string{"ja", "es"})
+
+	expected := &models.PostLocales{
+		PostID:    7,
+		Default:   "en",
+		Available: []string{"en", "ja"},
+		Missing:   []string{"de", "fr"},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, report)
+	}
+}
+
+// TestApplyTranslation tests that translations replace the localized fields and keep the rest
+func TestApplyTranslation(t *testing.T) {
+	post := &models.Post{ID: 3, Title: "Hello", Slug: "hello", AuthorID: 9, Status: "published",
+		MetaTitle: "Hello | Blog", CanonicalURL: "https://blog.example.com/posts/hello"}
+	applyTranslation(post, &models.PostTranslation{Locale: "de", Title: "Hallo", Content: "Inhalt", Slug: "hallo"})
+
+	if post.Locale != "de" || post.Title != "Hallo" || post.Slug != "hallo" || post.Content != "Inhalt" {
+		t.Errorf("Expected the German fields, got %+v", post)
+	}
+	if post.MetaTitle != "" || post.CanonicalURL != "" {
+		t.Errorf("Expected the default locale SEO fields to be dropped, got %+v", post)
+	}
+	if post.ID != 3 || post.AuthorID != 9 || post.Status != "published" {
+		t.Errorf("Expected shared fields to be kept, got %+v", post)
+	}
+}
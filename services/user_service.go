@@ -0,0 +1,199 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// APIKeyPrefix starts every API key, so leaked keys are easy to recognize
+const APIKeyPrefix = "gbk_"
+
+// apiKeyPrefixLength is the number of leading characters of a key stored to tell keys apart
+const apiKeyPrefixLength = len(APIKeyPrefix) + 8
+
+// AdminPrincipalID identifies callers presenting the ADMIN_API_KEY
+const AdminPrincipalID = "admin"
+
+var (
+	// ErrUserExists is returned when creating a user with an email address already in use
+	ErrUserExists = errors.New("a user with this email already exists")
+
+	// ErrInvalidAPIKey is returned for API keys that are unknown, revoked or of a disabled user
+	ErrInvalidAPIKey = errors.New("invalid API key")
+)
+
+// UserService manages users and their API keys, and authenticates the keys presented with
+// requests. The admin key authenticates as an admin without a user, so the first users and
+// keys can be created.
+type UserService struct {
+	userStore *store.UserStore
+	adminKey  string
+}
+
+// NewUserService creates a user service; an empty adminKey authenticates no one
+func NewUserService(userStore *store.UserStore, adminKey string) *UserService {
+	return &UserService{
+		userStore: userStore,
+		adminKey:  adminKey,
+	}
+}
+
+// CreateUser creates a user with a unique email address
+func (us *UserService) CreateUser(ctx *gofr.Context, req models.CreateUserRequest) (*models.User, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err := ValidateUser(req); err != nil {
+		return nil, err
+	}
+
+	_, err := us.userStore.GetUserByEmail(ctx, req.Email)
+	switch {
+	case err == nil:
+		return nil, ErrUserExists
+	case !errors.Is(err, store.ErrNotFound):
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+
+	user, err := us.userStore.CreateUser(ctx, req)
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+
+	ctx.Logger.Infof("User created successfully with ID: %d", user.ID)
+	return user, nil
+}
+
+// ListUsers retrieves every user
+func (us *UserService) ListUsers(ctx *gofr.Context) ([]models.User, error) {
+	users, err := us.userStore.ListUsers(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+	return users, nil
+}
+
+// SetUserDisabled disables or re-enables the user with id; a disabled user's keys stop
+// authenticating without being revoked
+func (us *UserService) SetUserDisabled(ctx *gofr.Context, id int, disabled bool) (*models.User, error) {
+	user, err := us.userStore.SetUserDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+	return user, nil
+}
+
+// CreateAPIKey creates an API key for the user with userID. The key is only returned by this
+// call; just its hash is stored.
+func (us *UserService) CreateAPIKey(ctx *gofr.Context, userID int, name string) (*models.CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, errors.Join(ErrValidationFailed, errors.New("key name must be 1 to 100 characters"))
+	}
+
+	user, err := us.userStore.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+	if user.Disabled {
+		return nil, errors.Join(ErrValidationFailed, errors.New("user "+strconv.Itoa(userID)+" is disabled"))
+	}
+
+	secret := NewAPIKey()
+	key, err := us.userStore.CreateAPIKey(ctx, userID, name, secret[:apiKeyPrefixLength], HashAPIKey(secret))
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+
+	ctx.Logger.Infof("API key %d created for user %d", key.ID, userID)
+	return &models.CreatedAPIKey{APIKey: *key, Key: secret}, nil
+}
+
+// ListAPIKeys retrieves the API keys of the user with userID, or of every user when it is 0
+func (us *UserService) ListAPIKeys(ctx *gofr.Context, userID int) ([]models.APIKey, error) {
+	keys, err := us.userStore.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes the API key with id for good
+func (us *UserService) RevokeAPIKey(ctx *gofr.Context, id int) (*models.APIKey, error) {
+	key, err := us.userStore.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+
+	ctx.Logger.Infof("API key %d revoked", id)
+	return key, nil
+}
+
+// Authenticate returns the principal a presented key authenticates: an admin for the admin
+// key, or the user of an active API key. It returns ErrInvalidAPIKey for any other key.
+func (us *UserService) Authenticate(ctx *gofr.Context, presented string) (*models.Principal, error) {
+	if us.adminKey != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(us.adminKey)) == 1 {
+		return &models.Principal{ID: AdminPrincipalID, Name: "admin key", Role: models.RoleAdmin}, nil
+	}
+	if !strings.HasPrefix(presented, APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, user, err := us.userStore.GetActiveAPIKey(ctx, HashAPIKey(presented))
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return nil, ErrInvalidAPIKey
+	case err != nil:
+		return nil, errors.Join(ErrUserFailed, err)
+	}
+
+	if err = us.userStore.TouchAPIKey(ctx, key.ID); err != nil {
+		ctx.Logger.Errorf("Failed to record the use of API key %d: %v", key.ID, err)
+	}
+
+	return &models.Principal{
+		ID:     "key:" + strconv.Itoa(key.ID),
+		UserID: user.ID,
+		Name:   user.Name,
+		Role:   user.Role,
+	}, nil
+}
+
+// ValidateUser checks the fields of a new user
+func ValidateUser(req models.CreateUserRequest) error {
+	if req.Name == "" || len(req.Name) > 100 {
+		return errors.Join(ErrValidationFailed, errors.New("name must be 1 to 100 characters"))
+	}
+	if address, err := mail.ParseAddress(req.Email); err != nil || address.Address != req.Email || len(req.Email) > 254 {
+		return errors.Join(ErrValidationFailed, errors.New("email must be a valid email address"))
+	}
+	if req.Role != models.RoleAdmin && req.Role != models.RoleEditor {
+		return errors.Join(ErrValidationFailed, errors.New("role must be admin or editor"))
+	}
+	return nil
+}
+
+// NewAPIKey returns a random API key: APIKeyPrefix followed by 256 random bits
+func NewAPIKey() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+}
+
+// HashAPIKey returns the hex SHA-256 of key, by which keys are stored and looked up. Keys
+// carry 256 random bits, so a fast unsalted hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
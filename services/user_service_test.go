@@ -0,0 +1,49 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"gofr-blog-service/models"
+)
+
+// TestValidateUser tests the checks on the fields of new users
+func TestValidateUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   models.CreateUserRequest
+		valid bool
+	}{
+		{"valid", models.CreateUserRequest{Name: "Ada", Email: "ada@example.com", Role: models.RoleAdmin}, true},
+		{"editor", models.CreateUserRequest{Name: "Bo", Email: "bo@example.com", Role: models.RoleEditor}, true},
+		{"no name", models.CreateUserRequest{Email: "ada@example.com", Role: models.RoleAdmin}, false},
+		{"bad email", models.CreateUserRequest{Name: "Ada", Email: "ada", Role: models.RoleAdmin}, false},
+		{"display name email", models.CreateUserRequest{Name: "Ada", Email: "Ada <ada@example.com>", Role: models.RoleAdmin}, false},
+		{"unknown role", models.CreateUserRequest{Name: "Ada", Email: "ada@example.com", Role: "owner"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateUser(tt.req); (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, got error %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+// TestNewAPIKey tests that keys are prefixed, distinct and hashed deterministically
+func TestNewAPIKey(t *testing.T) {
+	first, second := NewAPIKey(), NewAPIKey()
+	if !strings.HasPrefix(first, APIKeyPrefix) || len(first) != len(APIKeyPrefix)+43 {
+		t.Errorf("Unexpected key format %q", first)
+	}
+	if first == second {
+		t.Error("Expected distinct keys")
+	}
+	if HashAPIKey(first) != HashAPIKey(first) || HashAPIKey(first) == HashAPIKey(second) {
+		t.Error("Expected hashes to identify keys")
+	}
+	if len(HashAPIKey(first)) != 64 {
+		t.Errorf("Expected a 64 character hash, got %d", len(HashAPIKey(first)))
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"sync/atomic"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// maxWebhookBackoff caps the delay between delivery attempts of one webhook
+const maxWebhookBackoff = time.Hour
+
+// WebhookDispatcherConfig tunes the webhook dispatcher. Lease is how long a batch of
+// deliveries is claimed for; deliveries not sent within it are left to a later run.
+type WebhookDispatcherConfig struct {
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	Lease       time.Duration
+}
+
+// WebhookDispatcher delivers pending webhook deliveries.
+// It is registered as a GoFr cron job; overlapping runs in one process are skipped, and
+// deliveries are claimed with a lease so concurrent instances do not send the same one.
+// Requests are sent outside any transaction, and each outcome is recorded on its own.
+type WebhookDispatcher struct {
+	webhookStore *store.WebhookStore
+	sender       *WebhookSender
+	config       WebhookDispatcherConfig
+	running      atomic.Bool
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher instance
+func NewWebhookDispatcher(webhookStore *store.WebhookStore, sender *WebhookSender,
+	config WebhookDispatcherConfig) *WebhookDispatcher {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 20
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 8
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 10 * time.Second
+	}
+	if config.Lease <= 0 {
+		config.Lease = 5 * time.Minute
+	}
+	// The lease must fit at least one send that runs into the timeout
+	if minLease := 2 * sender.client.Timeout; config.Lease < minLease {
+		config.Lease = minLease
+	}
+
+	return &WebhookDispatcher{
+		webhookStore: webhookStore,
+		sender:       sender,
+		config:       config,
+	}
+}
+
+// Run sends one batch of due webhook deliveries
+func (wd *WebhookDispatcher) Run(ctx *gofr.Context) {
+	if !wd.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer wd.running.Store(false)
+
+	claimedAt := time.Now()
+	due, err := wd.webhookStore.ClaimDueDeliveries(ctx, wd.config.BatchSize, wd.config.Lease)
+	if err != nil {
+		ctx.Logger.Errorf("Webhook dispatcher failed to claim deliveries: %v", err)
+		return
+	}
+
+	// A send may take the full timeout; none starts that could outlast the lease
+	deadline := claimedAt.Add(wd.config.Lease - wd.sender.client.Timeout)
+	for i := range due {
+		if time.Now().After(deadline) {
+			ctx.Logger.Warnf("Webhook dispatcher lease ran out; %d deliveries wait for a later run", len(due)-i)
+			return
+		}
+		if err = wd.deliver(ctx, &due[i]); err != nil {
+			// The delivery stays claimed and is retried once the lease ends
+			ctx.Logger.Errorf("Failed to record webhook delivery %d: %v", due[i].ID, err)
+		}
+	}
+}
+
+// deliver sends one delivery and records the outcome, dead-lettering it after the last attempt
+func (wd *WebhookDispatcher) deliver(ctx *gofr.Context, d *models.DueWebhookDelivery) error {
+	statusCode, sendErr := wd.sender.Send(ctx, d.URL, d.Secret, d.ID, d.EventType, d.Payload)
+	if sendErr == nil {
+		return wd.webhookStore.RecordAttempt(ctx, d.ID, models.DeliverySucceeded, statusCode, "", time.Now())
+	}
+
+	attempts := d.Attempts + 1
+	if attempts >= wd.config.MaxAttempts {
+		ctx.Logger.Errorf("Webhook delivery %d to subscription %d dead-lettered after %d attempts: %v",
+			d.ID, d.SubscriptionID, attempts, sendErr)
+		return wd.webhookStore.RecordAttempt(ctx, d.ID, models.DeliveryFailed, statusCode, sendErr.Error(), time.Now())
+	}
+
+	ctx.Logger.Warnf("Webhook delivery %d attempt %d failed: %v", d.ID, attempts, sendErr)
+	nextAttempt := time.Now().Add(RetryBackoff(wd.config.BaseBackoff, attempts, maxWebhookBackoff))
+	return wd.webhookStore.RecordAttempt(ctx, d.ID, models.DeliveryPending, statusCode, sendErr.Error(), nextAttempt)
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Webhook request headers
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+var errWebhookStatus = errors.New("webhook endpoint returned a non-2xx status")
+
+// ErrWebhookURLRejected is returned for webhook URLs the service must not call: anything but
+// absolute http(s) URLs, and hosts resolving to loopback, link-local, private, shared,
+// unspecified or multicast addresses
+var ErrWebhookURLRejected = errors.New("webhook URL is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, private in practice though not in net.IP
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// WebhookSender POSTs signed event payloads to webhook endpoints
+type WebhookSender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+// NewWebhookSender creates a webhook sender whose requests time out after timeout. Unless
+// allowPrivate is set, it refuses to connect to internal addresses. The address is checked
+// as each connection is made, so DNS changes and redirects cannot reach them either.
+func NewWebhookSender(timeout time.Duration, allowPrivate bool) *WebhookSender {
+	wsd := &WebhookSender{allowPrivate: allowPrivate}
+	dialer := &net.Dialer{Timeout: timeout, Control: wsd.controlDial}
+	wsd.client = &http.Client{
+		Timeout: timeout,
+		// No proxy: connections must go to the checked address itself
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: timeout,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+	return wsd
+}
+
+// CheckURL checks that rawURL is an absolute http(s) URL whose host only resolves to addresses
+// the sender may connect to
+func (wsd *WebhookSender) CheckURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return errors.Join(ErrWebhookURLRejected, errors.New("url must be an absolute http(s) URL"))
+	}
+	if wsd.allowPrivate {
+		return nil
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil {
+		return errors.Join(ErrWebhookURLRejected, err)
+	}
+	for _, address := range addresses {
+		if BlockedWebhookIP(address.IP) {
+			return errors.Join(ErrWebhookURLRejected,
+				errors.New(parsed.Hostname()+" resolves to internal address "+address.IP.String()))
+		}
+	}
+	return nil
+}
+
+// BlockedWebhookIP reports whether ip is an internal address webhooks must not be sent to
+func BlockedWebhookIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// controlDial refuses connections to internal addresses unless they are allowed
+func (wsd *WebhookSender) controlDial(_, address string, _ syscall.RawConn) error {
+	if wsd.allowPrivate {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || BlockedWebhookIP(ip) {
+		return errors.Join(ErrWebhookURLRejected, errors.New("refusing to connect to internal address "+host))
+	}
+	return nil
+}
+
+// Send POSTs a payload signed with secret and returns the response status code.
+// Any non-2xx status is returned as an error along with the code.
+func (wsd *WebhookSender) Send(ctx context.Context, url, secret string, deliveryID int64,
+	eventType string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gofr-blog-service-webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, eventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(deliveryID, 10))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, timestamp, payload))
+
+	resp, err := wsd.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.Join(errWebhookStatus, errors.New(resp.Status))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the signature header value for a payload sent at timestamp.
+// The signature is the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the subscription secret.
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook reports whether signature is a valid signature of payload sent at timestamp
+func VerifyWebhook(secret, timestamp string, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, payload)), []byte(signature))
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestWebhookSender_Send tests that deliveries are POSTed with event headers and a verifiable signature
+func TestWebhookSender_Send(t *testing.T) {
+	secret := "0123456789abcdef"
+	payload := []byte(`{"type":"post.created"}`)
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(time.Second, true)
+	code, err := sender.Send(context.Background(), server.URL, secret, 42, "post.created", payload)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", code)
+	}
+
+	if received.Header.Get(WebhookEventHeader) != "post.created" {
+		t.Errorf("Expected event header post.created, got %q", received.Header.Get(WebhookEventHeader))
+	}
+	if received.Header.Get(WebhookDeliveryHeader) != "42" {
+		t.Errorf("Expected delivery header 42, got %q", received.Header.Get(WebhookDeliveryHeader))
+	}
+
+	timestamp := received.Header.Get(WebhookTimestampHeader)
+	if !VerifyWebhook(secret, timestamp, body, received.Header.Get(WebhookSignatureHeader)) {
+		t.Errorf("Expected signature to verify for body %s", body)
+	}
+	if VerifyWebhook("another-secret-value", timestamp, body, received.Header.Get(WebhookSignatureHeader)) {
+		t.Error("Expected signature not to verify with a different secret")
+	}
+}
+
+// TestWebhookSender_SendNon2xx tests that a non-2xx response is returned as an error with its status code
+func TestWebhookSender_SendNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(time.Second, true)
+	code, err := sender.Send(context.Background(), server.URL, "0123456789abcdef", 1, "post.deleted", []byte(`{}`))
+	if !errors.Is(err, errWebhookStatus) {
+		t.Errorf("Expected errWebhookStatus, got %v", err)
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+}
+
+// TestWebhookSender_BlocksInternalAddresses tests that URLs and connections to internal addresses are refused
+func TestWebhookSender_BlocksInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(time.Second, false)
+	if _, err := sender.Send(context.Background(), server.URL, "0123456789abcdef", 1, "post.created", []byte(`{}`)); !errors.Is(err, ErrWebhookURLRejected) {
+		t.Errorf("Expected the connection to a loopback server to be refused, got %v", err)
+	}
+
+	for _, rawURL := range []string{server.URL, "http://localhost/hook", "http://169.254.169.254/latest", "ftp://example.com/", "/hook"} {
+		if err := sender.CheckURL(context.Background(), rawURL); !errors.Is(err, ErrWebhookURLRejected) {
+			t.Errorf("Expected %s to be rejected, got %v", rawURL, err)
+		}
+	}
+	if err := sender.CheckURL(context.Background(), "https://93.184.216.34/hook"); err != nil {
+		t.Errorf("Expected a public address to be allowed, got %v", err)
+	}
+}
+
+// TestBlockedWebhookIP tests which addresses count as internal
+func TestBlockedWebhookIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"224.0.0.1", true},
+		{"::1", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"93.184.216.34", false},
+		{"2606:2800:220:1::1", false},
+	}
+
+	for _, tt := range tests {
+		if got := BlockedWebhookIP(net.ParseIP(tt.ip)); got != tt.expected {
+			t.Errorf("BlockedWebhookIP(%s): expected %v, got %v", tt.ip, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/store"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// WebhookService handles business logic for webhook subscriptions and their delivery logs
+type WebhookService struct {
+	webhookStore *store.WebhookStore
+	sender       *WebhookSender
+}
+
+// NewWebhookService creates a new webhook service instance. Subscription URLs must be ones
+// sender may deliver to.
+func NewWebhookService(webhookStore *store.WebhookStore, sender *WebhookSender) *WebhookService {
+	return &WebhookService{
+		webhookStore: webhookStore,
+		sender:       sender,
+	}
+}
+
+// CreateSubscription creates a webhook subscription, generating a secret when none is given.
+// The secret is only returned by this call.
+func (ws *WebhookService) CreateSubscription(ctx *gofr.Context, req models.CreateWebhookRequest) (*models.WebhookSubscription, error) {
+	if err := ws.sender.CheckURL(ctx, req.URL); err != nil {
+		return nil, errors.Join(ErrValidationFailed, err)
+	}
+
+	sub := &models.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Active:     req.Active == nil || *req.Active,
+	}
+	if sub.Secret == "" {
+		sub.Secret = newWebhookSecret()
+	}
+
+	created, err := ws.webhookStore.CreateSubscription(ctx, sub)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	ctx.Logger.Infof("Webhook subscription created successfully with ID: %d", created.ID)
+	return created, nil
+}
+
+// GetSubscription retrieves a webhook subscription by ID without its secret
+func (ws *WebhookService) GetSubscription(ctx *gofr.Context, id int) (*models.WebhookSubscription, error) {
+	sub, err := ws.webhookStore.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	sub.Secret = ""
+	return sub, nil
+}
+
+// ListSubscriptions retrieves every webhook subscription without secrets
+func (ws *WebhookService) ListSubscriptions(ctx *gofr.Context) ([]models.WebhookSubscription, error) {
+	subs, err := ws.webhookStore.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+// UpdateSubscription updates a webhook subscription
+func (ws *WebhookService) UpdateSubscription(ctx *gofr.Context, id int, req models.UpdateWebhookRequest) (*models.WebhookSubscription, error) {
+	if req.URL != "" {
+		if err := ws.sender.CheckURL(ctx, req.URL); err != nil {
+			return nil, errors.Join(ErrValidationFailed, err)
+		}
+	}
+
+	sub, err := ws.webhookStore.UpdateSubscription(ctx, id, req)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	ctx.Logger.Infof("Webhook subscription updated successfully: %d", id)
+	sub.Secret = ""
+	return sub, nil
+}
+
+// DeleteSubscription removes a webhook subscription and its delivery log
+func (ws *WebhookService) DeleteSubscription(ctx *gofr.Context, id int) error {
+	if err := ws.webhookStore.DeleteSubscription(ctx, id); err != nil {
+		return errors.Join(ErrWebhookFailed, err)
+	}
+
+	ctx.Logger.Infof("Webhook subscription deleted successfully: %d", id)
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log of a subscription with pagination
+func (ws *WebhookService) ListDeliveries(ctx *gofr.Context, subscriptionID int, status string,
+	page, pageSize int) (*models.WebhookDeliveryListResponse, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	if _, err := ws.webhookStore.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	totalCount, err := ws.webhookStore.CountDeliveries(ctx, subscriptionID, status)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	deliveries, err := ws.webhookStore.ListDeliveries(ctx, subscriptionID, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	return &models.WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (totalCount + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// ReplayDelivery queues a logged delivery to be sent again as a new delivery
+func (ws *WebhookService) ReplayDelivery(ctx *gofr.Context, subscriptionID int, deliveryID int64) (*models.WebhookDelivery, error) {
+	delivery, err := ws.webhookStore.ReplayDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, errors.Join(ErrWebhookFailed, err)
+	}
+
+	ctx.Logger.Infof("Webhook delivery %d replayed as %d", deliveryID, delivery.ID)
+	return delivery, nil
+}
+
+// IsWebhookEventType reports whether eventType can be subscribed to; "*" subscribes to every event
+func IsWebhookEventType(eventType string) bool {
+	switch eventType {
+	case "*", models.PostCreated, models.PostUpdated, models.PostPublished, models.PostDeleted:
+		return true
+	}
+	return false
+}
+
+// newWebhookSecret returns a random 256-bit hex secret
+func newWebhookSecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return hex.EncodeToString(secret)
+}
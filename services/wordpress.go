@@ -0,0 +1,173 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"gofr-blog-service/markdown"
+	"gofr-blog-service/models"
+	"gofr-blog-service/wxr"
+)
+
+// Reasons an exported item is skipped or conflicts
+const (
+	ImportSkipPostType  = "post_type"
+	ImportSkipStatus    = "status"
+	ImportSkipMissingID = "missing_id"
+	ImportSlugTaken     = "slug_taken"
+	ImportDuplicateSlug = "duplicate_slug"
+	ImportUnknownAuthor = "unknown_author"
+	ImportInvalidPost   = "invalid"
+)
+
+// wordPressPostType is the post type of WordPress blog posts
+const wordPressPostType = "post"
+
+// wordPressDefaultCategory is the slug of the category WordPress files uncategorized posts under
+const wordPressDefaultCategory = "uncategorized"
+
+// wordPressStatuses maps the WordPress statuses that are imported to post statuses.
+// Trashed posts, auto-drafts and revisions are not imported.
+var wordPressStatuses = map[string]string{
+	"publish": "published",
+	"future":  "draft",
+	"draft":   "draft",
+	"pending": "draft",
+	"private": "draft",
+}
+
+// wordPressPost is an exported item mapped to a post
+type wordPressPost struct {
+	item        *wxr.Item
+	req         models.CreatePostRequest
+	createdAt   time.Time
+	publishedAt *time.Time
+	tags        []string
+}
+
+// wordPressPlan maps the items of a WXR export to posts
+type wordPressPlan struct {
+	export  *wxr.Export
+	options models.ImportOptions
+
+	// authors maps author logins to author ids; slugs maps each slug to the first item claiming it
+	authors map[string]int
+	slugs   map[string]int
+}
+
+// newWordPressPlan prepares the mapping of export. Slugs are claimed in export order, so every
+// batch of a resumed import settles duplicates the same way.
+func newWordPressPlan(export *wxr.Export, options models.ImportOptions) *wordPressPlan {
+	plan := &wordPressPlan{
+		export:  export,
+		options: options,
+		authors: make(map[string]int, len(export.Authors)),
+		slugs:   make(map[string]int),
+	}
+
+	for _, author := range export.Authors {
+		plan.authors[author.Login] = author.ID
+	}
+	for login, id := range options.AuthorMap {
+		plan.authors[login] = id
+	}
+
+	for i := range export.Items {
+		item := &export.Items[i]
+		if plan.skipReason(item) != "" {
+			continue
+		}
+		slug := wordPressSlug(item)
+		if _, taken := plan.slugs[slug]; !taken {
+			plan.slugs[slug] = item.PostID
+		}
+	}
+	return plan
+}
+
+// skipReason returns why item is not imported, or "" when it is a post to import
+func (p *wordPressPlan) skipReason(item *wxr.Item) string {
+	switch {
+	case item.PostType != wordPressPostType:
+		return ImportSkipPostType
+	case wordPressStatuses[item.Status] == "":
+		return ImportSkipStatus
+	case item.PostID <= 0:
+		return ImportSkipMissingID
+	}
+	return ""
+}
+
+// post maps a post item, returning the conflict that keeps it from being imported, if any.
+// Slugs taken by existing posts are checked by the caller.
+func (p *wordPressPlan) post(item *wxr.Item) (*wordPressPost, string) {
+	slug := wordPressSlug(item)
+	if p.slugs[slug] != item.PostID {
+		return nil, ImportDuplicateSlug
+	}
+
+	authorID, ok := p.authors[item.Creator]
+	if !ok || authorID <= 0 {
+		authorID = p.options.DefaultAuthorID
+	}
+	if authorID <= 0 {
+		return nil, ImportUnknownAuthor
+	}
+
+	tags, err := NormalizeTags(wordPressTags(item))
+	if err != nil {
+		return nil, ImportInvalidPost + ": " + strings.ReplaceAll(err.Error(), "\n", ": ")
+	}
+
+	post := &wordPressPost{
+		item: item,
+		req: models.CreatePostRequest{
+			Title:    strings.TrimSpace(item.Title),
+			Content:  markdown.FromHTML(item.Content),
+			Slug:     slug,
+			AuthorID: authorID,
+			Status:   wordPressStatuses[item.Status],
+		},
+		createdAt: time.Now().UTC(),
+		tags:      tags,
+	}
+	if item.Excerpt != "" {
+		post.req.MetaDescription = Excerpt(markdown.FromHTML(item.Excerpt), excerptLength)
+	}
+
+	if date, ok := item.Date(); ok {
+		post.createdAt = date
+		if post.req.Status == "published" {
+			post.publishedAt = &date
+		}
+	}
+	return post, ""
+}
+
+// wordPressTags returns the names of an item's categories and tags, which both become tags.
+// WordPress files posts without a category under Uncategorized, which is left out.
+func wordPressTags(item *wxr.Item) []string {
+	var names []string
+	for _, term := range item.Terms {
+		switch {
+		case term.Domain == wxr.DomainCategory && term.Slug == wordPressDefaultCategory:
+		case term.Domain == wxr.DomainCategory || term.Domain == wxr.DomainTag:
+			names = append(names, term.Name)
+		}
+	}
+	return names
+}
+
+// wordPressSlug returns the slug of an item: its post name, which WordPress stores
+// percent-encoded, or one derived from its title for drafts that never got one
+func wordPressSlug(item *wxr.Item) string {
+	slug := strings.TrimSpace(item.PostName)
+	if unescaped, err := url.PathUnescape(slug); err == nil {
+		slug = unescaped
+	}
+	if slug == "" {
+		slug = Slugify(item.Title)
+	}
+	return slug
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"gofr-blog-service/models"
+	"gofr-blog-service/wxr"
+)
+
+// TestWordPressPlan tests that items map to posts with their slugs, dates, authors and tags, and
+// that duplicate slugs go to the first item claiming them
+func TestWordPressPlan(t *testing.T) {
+	export := &wxr.Export{
+		Authors: []wxr.Author{{ID: 2, Login: "admin"}},
+		Items: []wxr.Item{
+			{PostID: 1, Title: "Hello", PostName: "hello-w%c3%b6rld", Status: "publish", PostType: "post",
+				Creator: "admin", PostDateGMT: "2019-03-04 05:06:07", Content: "<p>Hi <b>there</b></p>",
+				Terms: []wxr.Term{
+					{Domain: wxr.DomainCategory, Slug: "uncategorized", Name: "Uncategorized"},
+					{Domain: wxr.DomainCategory, Slug: "news", Name: "News"},
+					{Domain: wxr.DomainTag, Slug: "go-lang", Name: "Go Lang"},
+					{Domain: wxr.DomainTag, Slug: "news", Name: "news"},
+					{Domain: "post_format", Slug: "post-format-aside", Name: "Aside"},
+				}},
+			{PostID: 2, Title: "Hello again", PostName: "hello-w%c3%b6rld", Status: "draft", PostType: "post",
+				Creator: "admin"},
+			{PostID: 3, Title: "About", PostName: "about", Status: "publish", PostType: "page"},
+			{PostID: 4, Title: "Old", PostName: "old", Status: "trash", PostType: "post"},
+			{PostID: 5, Title: "Guest Post", Status: "pending", PostType: "post", Creator: "guest"},
+			{PostID: 6, Title: "Mapped", PostName: "mapped", Status: "publish", PostType: "post", Creator: "jane"},
+		},
+	}
+	plan := newWordPressPlan(export, models.ImportOptions{AuthorMap: map[string]int{"jane": 9}})
+
+	post, conflict := plan.post(&export.Items[0])
+	published := time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+	switch {
+	case conflict != "":
+		t.Fatalf("expected item 1 to map, got conflict %q", conflict)
+	case post.req.Slug != "hello-wörld" || post.req.AuthorID != 2 || post.req.Status != "published":
+		t.Errorf("unexpected mapping of item 1: %+v", post.req)
+	case post.req.Content != "Hi **there**":
+		t.Errorf("expected Markdown content, got %q", post.req.Content)
+	case !post.createdAt.Equal(published) || post.publishedAt == nil || !post.publishedAt.Equal(published):
+		t.Errorf("expected the post dates to be kept, got %v and %v", post.createdAt, post.publishedAt)
+	case !slices.Equal(post.tags, []string{"go-lang", "news"}):
+		t.Errorf("expected the categories and tags as tags, got %v", post.tags)
+	}
+
+	if _, conflict = plan.post(&export.Items[1]); conflict != ImportDuplicateSlug {
+		t.Errorf("expected item 2 to conflict with %q, got %q", ImportDuplicateSlug, conflict)
+	}
+	if reason := plan.skipReason(&export.Items[2]); reason != ImportSkipPostType {
+		t.Errorf("expected the page to be skipped for %q, got %q", ImportSkipPostType, reason)
+	}
+	if reason := plan.skipReason(&export.Items[3]); reason != ImportSkipStatus {
+		t.Errorf("expected the trashed post to be skipped for %q, got %q", ImportSkipStatus, reason)
+	}
+	if _, conflict = plan.post(&export.Items[4]); conflict != ImportUnknownAuthor {
+		t.Errorf("expected item 5 to conflict with %q, got %q", ImportUnknownAuthor, conflict)
+	}
+	if post, _ = plan.post(&export.Items[5]); post == nil || post.req.AuthorID != 9 {
+		t.Errorf("expected item 6 to map to the mapped author 9, got %+v", post)
+	}
+}
@@ -0,0 +1,185 @@
+// Package sqltest provides a database/sql database for tests of code that runs SQL. It records
+// every statement, including those that begin and end transactions, and answers each one from
+// a function of the test, so tests can check the statements run without a database server.
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Transaction statements recorded alongside the statements run
+const (
+	Begin    = "BEGIN"
+	Commit   = "COMMIT"
+	Rollback = "ROLLBACK"
+)
+
+// Result answers a statement: the columns and rows of a query, or the error it fails with
+type Result struct {
+	Columns []string
+	Rows    [][]driver.Value
+	Err     error
+}
+
+// Statement is a statement run through a DB with its arguments
+type Statement struct {
+	Query string
+	Args  []driver.Value
+}
+
+// DB is a database whose statements are recorded and answered by the function it is opened with
+type DB struct {
+	*sql.DB
+
+	answer func(query string, args []driver.Value) Result
+
+	mu         sync.Mutex
+	statements []Statement
+}
+
+// Open opens a database answering statements with answer; a nil answer gives every query no
+// rows and every other statement no effect
+func Open(answer func(query string, args []driver.Value) Result) *DB {
+	if answer == nil {
+		answer = func(string, []driver.Value) Result { return Result{} }
+	}
+
+	db := &DB{answer: answer}
+	db.DB = sql.OpenDB(connector{db: db})
+	// One connection keeps the statements of concurrent callers in the order they ran
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+// Statements returns the statements run so far, in order
+func (db *DB) Statements() []Statement {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return append([]Statement(nil), db.statements...)
+}
+
+// Queries returns the text of the statements run so far, in order
+func (db *DB) Queries() []string {
+	statements := db.Statements()
+	queries := make([]string, len(statements))
+	for i, statement := range statements {
+		queries[i] = statement.Query
+	}
+	return queries
+}
+
+// run records a statement and answers it
+func (db *DB) run(query string, args []driver.Value) Result {
+	db.mu.Lock()
+	db.statements = append(db.statements, Statement{Query: query, Args: args})
+	db.mu.Unlock()
+
+	return db.answer(query, args)
+}
+
+// connector opens connections to a DB
+type connector struct {
+	db *DB
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{db: c.db}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return sqlDriver{}
+}
+
+// sqlDriver exists for driver.Connector; connections are only opened through the connector
+type sqlDriver struct{}
+
+func (sqlDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("sqltest: open databases with sqltest.Open")
+}
+
+// conn is a connection to a DB. It answers statements directly, so they are never prepared.
+type conn struct {
+	db *DB
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("sqltest: statements are not prepared")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	if result := c.db.run(Begin, nil); result.Err != nil {
+		return nil, result.Err
+	}
+	return tx{db: c.db}, nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result := c.db.run(query, values(args))
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return driver.RowsAffected(len(result.Rows)), nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result := c.db.run(query, values(args))
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return &rows{columns: result.Columns, rows: result.Rows}, nil
+}
+
+// tx is a transaction of a DB
+type tx struct {
+	db *DB
+}
+
+func (t tx) Commit() error {
+	return t.db.run(Commit, nil).Err
+}
+
+func (t tx) Rollback() error {
+	return t.db.run(Rollback, nil).Err
+}
+
+// rows iterates the rows of a Result
+type rows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// values returns the values of named arguments
+func values(args []driver.NamedValue) []driver.Value {
+	vals := make([]driver.Value, len(args))
+	for i, arg := range args {
+		vals[i] = arg.Value
+	}
+	return vals
+}
@@ -0,0 +1,166 @@
+// Package staticsite writes posts as the Markdown content of static site generators.
+package staticsite
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr-blog-service/models"
+)
+
+// Static site generators whose content layout and front matter are supported
+const (
+	FormatHugo   = "hugo"
+	FormatJekyll = "jekyll"
+)
+
+// jekyllDateLayout is the date format of Jekyll front matter
+const jekyllDateLayout = "2006-01-02 15:04:05 -0700"
+
+// ErrUnknownFormat is returned for formats other than hugo and jekyll
+var ErrUnknownFormat = errors.New("unknown export format, use hugo or jekyll")
+
+// mediaPattern matches the targets of Markdown images and of HTML src attributes
+var mediaPattern = regexp.MustCompile(`(!\[[^\]]*\]\(\s*|\bsrc\s*=\s*["'])([^\s)"']+)`)
+
+// ValidFormat reports whether format is a supported static site generator
+func ValidFormat(format string) bool {
+	return format == FormatHugo || format == FormatJekyll
+}
+
+// Archive writes posts into a zip archive laid out as the source tree of a static site
+type Archive struct {
+	zip     *zip.Writer
+	format  string
+	baseURL string
+}
+
+// NewArchive creates an archive of format writing to w. Media references under baseURL, the
+// public URL of the blog, are rewritten to site-relative paths.
+func NewArchive(w io.Writer, format, baseURL string) (*Archive, error) {
+	if !ValidFormat(format) {
+		return nil, ErrUnknownFormat
+	}
+	return &Archive{
+		zip:     zip.NewWriter(w),
+		format:  format,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Add writes post to the archive
+func (a *Archive) Add(post *models.Post) error {
+	file, err := a.zip.CreateHeader(&zip.FileHeader{
+		Name:     Path(a.format, post),
+		Method:   zip.Deflate,
+		Modified: post.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(file, Document(a.format, post, a.baseURL))
+	return err
+}
+
+// Close finishes the archive; it does not close the underlying writer
+func (a *Archive) Close() error {
+	return a.zip.Close()
+}
+
+// Path returns the path of a post in the source tree of format: content/posts/<slug>.md for
+// Hugo, and _posts/<date>-<slug>.md or, for drafts, _drafts/<slug>.md for Jekyll
+func Path(format string, post *models.Post) string {
+	name := strings.NewReplacer("/", "-", `\`, "-").Replace(post.Slug)
+	if name == "" {
+		name = strconv.Itoa(post.ID)
+	}
+
+	if format != FormatJekyll {
+		return "content/posts/" + name + ".md"
+	}
+	if isDraft(post) {
+		return "_drafts/" + name + ".md"
+	}
+	return "_posts/" + postDate(post).Format("2006-01-02") + "-" + name + ".md"
+}
+
+// Document returns post as a Markdown document with the front matter of format: its title,
+// slug, date, author and draft flag, and its meta description and tags when it has them
+func Document(format string, post *models.Post, baseURL string) string {
+	var out strings.Builder
+	out.WriteString("---\n")
+	if format == FormatJekyll {
+		out.WriteString("layout: post\n")
+	}
+	out.WriteString("title: " + quote(post.Title) + "\n")
+	out.WriteString("slug: " + quote(post.Slug) + "\n")
+
+	if format == FormatJekyll {
+		out.WriteString("date: " + postDate(post).Format(jekyllDateLayout) + "\n")
+	} else {
+		out.WriteString("date: " + postDate(post).Format(time.RFC3339) + "\n")
+		out.WriteString("lastmod: " + post.UpdatedAt.UTC().Format(time.RFC3339) + "\n")
+	}
+
+	out.WriteString("author: " + strconv.Itoa(post.AuthorID) + "\n")
+	out.WriteString("draft: " + strconv.FormatBool(isDraft(post)) + "\n")
+	if post.MetaDescription != "" {
+		out.WriteString("description: " + quote(post.MetaDescription) + "\n")
+	}
+	if len(post.Tags) > 0 {
+		tags := make([]string, len(post.Tags))
+		for i, tag := range post.Tags {
+			tags[i] = quote(tag)
+		}
+		out.WriteString("tags: [" + strings.Join(tags, ", ") + "]\n")
+	}
+	out.WriteString("---\n\n")
+
+	out.WriteString(strings.TrimSpace(RewriteMedia(post.Content, baseURL)))
+	out.WriteString("\n")
+	return out.String()
+}
+
+// RewriteMedia rewrites the images and src attributes of content that point under baseURL to
+// paths relative to the site root, so they resolve wherever the static site is served
+func RewriteMedia(content, baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if baseURL == "" {
+		return content
+	}
+
+	return mediaPattern.ReplaceAllStringFunc(content, func(match string) string {
+		groups := mediaPattern.FindStringSubmatch(match)
+		path, ok := strings.CutPrefix(groups[2], baseURL)
+		if !ok || (path != "" && !strings.HasPrefix(path, "/")) {
+			return match
+		}
+		if path == "" {
+			path = "/"
+		}
+		return groups[1] + path
+	})
+}
+
+// isDraft reports whether post is not published
+func isDraft(post *models.Post) bool {
+	return post.Status != "published"
+}
+
+// postDate returns the date of a post: when it was published, or else when it was created
+func postDate(post *models.Post) time.Time {
+	if post.PublishedAt != nil {
+		return post.PublishedAt.UTC()
+	}
+	return post.CreatedAt.UTC()
+}
+
+// quote returns s as a double-quoted YAML scalar, whose escapes match Go's
+func quote(s string) string {
+	return strconv.Quote(s)
+}
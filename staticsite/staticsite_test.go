@@ -0,0 +1,114 @@
+package staticsite
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gofr-blog-service/markdown"
+	"gofr-blog-service/models"
+)
+
+// TestPath tests the content paths of each format
+func TestPath(t *testing.T) {
+	published := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	tests := []struct {
+		format   string
+		post     models.Post
+		expected string
+	}{
+		{FormatHugo, models.Post{Slug: "hello", Status: "published"}, "content/posts/hello.md"},
+		{FormatHugo, models.Post{ID: 7, Status: "draft"}, "content/posts/7.md"},
+		{FormatJekyll, models.Post{Slug: "hello", Status: "published", PublishedAt: &published}, "_posts/2024-05-06-hello.md"},
+		{FormatJekyll, models.Post{Slug: "a/b", Status: "draft"}, "_drafts/a-b.md"},
+	}
+
+	for _, tt := range tests {
+		if got := Path(tt.format, &tt.post); got != tt.expected {
+			t.Errorf("Path(%s, %q): expected %q, got %q", tt.format, tt.post.Slug, tt.expected, got)
+		}
+	}
+}
+
+// TestDocument tests that front matter round-trips through SplitFrontMatter
+func TestDocument(t *testing.T) {
+	published := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	post := &models.Post{
+		ID: 1, Title: `Say "hi": a guide`, Slug: "say-hi", AuthorID: 3, Status: "published",
+		Content: "Body", PublishedAt: &published, UpdatedAt: published, MetaDescription: "How to say hi",
+		Tags: []string{"etiquette", "greetings"},
+	}
+
+	for format, date := range map[string]string{FormatHugo: "2024-05-06T07:08:09Z", FormatJekyll: "2024-05-06 07:08:09 +0000"} {
+		fields, body := markdown.SplitFrontMatter(Document(format, post, ""))
+		expected := map[string]string{
+			"title": post.Title, "slug": "say-hi", "date": date, "author": "3", "draft": "false",
+			"description": "How to say hi", "tags": `["etiquette", "greetings"]`,
+		}
+		for key, value := range expected {
+			if fields[key] != value {
+				t.Errorf("%s: expected %s %q, got %q", format, key, value, fields[key])
+			}
+		}
+		if body != "Body\n" {
+			t.Errorf("%s: expected the content as body, got %q", format, body)
+		}
+	}
+}
+
+// TestRewriteMedia tests that only media under the base URL become site-relative
+func TestRewriteMedia(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"![cat](https://blog.example.com/uploads/cat.png)", "![cat](/uploads/cat.png)"},
+		{`<img src="https://blog.example.com/uploads/a.jpg">`, `<img src="/uploads/a.jpg">`},
+		{"![cdn](https://cdn.example.com/a.png)", "![cdn](https://cdn.example.com/a.png)"},
+		{"![other](https://blog.example.com.evil/a.png)", "![other](https://blog.example.com.evil/a.png)"},
+		{"[a link](https://blog.example.com/posts/x)", "[a link](https://blog.example.com/posts/x)"},
+	}
+
+	for _, tt := range tests {
+		if got := RewriteMedia(tt.content, "https://blog.example.com/"); got != tt.expected {
+			t.Errorf("RewriteMedia(%q): expected %q, got %q", tt.content, tt.expected, got)
+		}
+	}
+}
+
+// TestArchive tests that added posts are written as zip entries
+func TestArchive(t *testing.T) {
+	if _, err := NewArchive(io.Discard, "gatsby", ""); err != ErrUnknownFormat {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	archive, err := NewArchive(&buf, FormatHugo, "")
+	if err != nil {
+		t.Fatalf("NewArchive: %v", err)
+	}
+	for _, slug := range []string{"one", "two"} {
+		if err = archive.Add(&models.Post{Slug: slug, Title: slug, Content: "Text of " + slug}); err != nil {
+			t.Fatalf("Add(%s): %v", slug, err)
+		}
+	}
+	if err = archive.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("reading archive: %v", err)
+	}
+	if len(reader.File) != 2 || reader.File[1].Name != "content/posts/two.md" {
+		t.Fatalf("unexpected entries: %v", reader.File)
+	}
+	file, _ := reader.File[1].Open()
+	data, _ := io.ReadAll(file)
+	if !strings.HasSuffix(string(data), "Text of two\n") {
+		t.Errorf("unexpected entry content %q", data)
+	}
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// AuditStore handles database operations for the append-only audit log
+type AuditStore struct {
+	tx Executor
+}
+
+// NewAuditStore creates a new audit store instance
+func NewAuditStore() *AuditStore {
+	return &AuditStore{}
+}
+
+// Append writes entries to the audit log
+func (as *AuditStore) Append(ctx *gofr.Context, entries ...models.AuditEntry) error {
+	for i := range entries {
+		e := &entries[i]
+		_, err := executorFor(ctx, as.tx).Exec(InsertAuditEntryQuery, e.Action, e.EntityType, e.EntityID,
+			e.Actor, e.ClaimedActor, e.RequestID, e.IP, []byte(e.Changes))
+		if err != nil {
+			return errors.Join(errDatabaseOperation, err)
+		}
+	}
+	return nil
+}
+
+// Restore appends restored entries to the audit log, keeping their times
+func (as *AuditStore) Restore(ctx *gofr.Context, entries ...models.AuditEntry) error {
+	for i := range entries {
+		e := &entries[i]
+		_, err := executorFor(ctx, as.tx).Exec(RestoreAuditEntryQuery, e.Action, e.EntityType, e.EntityID,
+			e.Actor, e.ClaimedActor, e.RequestID, e.IP, []byte(e.Changes), e.CreatedAt)
+		if err != nil {
+			return errors.Join(errDatabaseOperation, err)
+		}
+	}
+	return nil
+}
+
+// List retrieves audit entries matching filter, newest first
+func (as *AuditStore) List(ctx *gofr.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	args := append(auditFilterArgs(filter), filter.Limit, filter.Offset)
+	rows, err := executorFor(ctx, as.tx).Query(ListAuditEntriesQuery, args...)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanAuditEntries(rows)
+}
+
+// Page retrieves up to limit audit entries after the one with afterID, oldest first
+func (as *AuditStore) Page(ctx *gofr.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	rows, err := executorFor(ctx, as.tx).Query(ListAuditPageQuery, afterID, limit)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanAuditEntries(rows)
+}
+
+// scanAuditEntries scans audit entry rows and closes them
+func scanAuditEntries(rows *sql.Rows) ([]models.AuditEntry, error) {
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var e models.AuditEntry
+		scanErr := rows.Scan(&e.ID, &e.Action, &e.EntityType, &e.EntityID, &e.Actor, &e.ClaimedActor,
+			&e.RequestID, &e.IP, &e.Changes, &e.CreatedAt)
+		if scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return entries, nil
+}
+
+// Count counts audit entries matching filter
+func (as *AuditStore) Count(ctx *gofr.Context, filter models.AuditFilter) (int, error) {
+	var count int
+	err := executorFor(ctx, as.tx).QueryRow(CountAuditEntriesQuery, auditFilterArgs(filter)...).Scan(&count)
+	if err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+	return count, nil
+}
+
+// Purge deletes entries created before cutoff and returns how many were removed
+func (as *AuditStore) Purge(ctx *gofr.Context, cutoff time.Time) (int64, error) {
+	result, err := executorFor(ctx, as.tx).Exec(PurgeAuditEntriesQuery, cutoff)
+	if err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+	return purged, nil
+}
+
+// auditFilterArgs returns the positional arguments of auditFilterClause
+func auditFilterArgs(filter models.AuditFilter) []any {
+	return []any{filter.Action, filter.EntityType, filter.EntityID, filter.Actor, filter.RequestID,
+		filter.From, filter.To}
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"database/sql"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Executor is the subset of GoFr's SQL datasource shared by connections and transactions
+type Executor interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// executorFor returns tx when a store is bound to a transaction and the context connection otherwise
+func executorFor(ctx *gofr.Context, tx Executor) Executor {
+	if tx != nil {
+		return tx
+	}
+	return ctx.SQL
+}
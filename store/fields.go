@@ -0,0 +1,61 @@
+package store
+
+import (
+	"gofr-blog-service/models"
+)
+
+// selectColumns returns the requested columns in canonical order, always including id.
+// Unknown names are dropped so only whitelisted columns ever reach the SQL projection.
+func selectColumns(fields []string) []string {
+	requested := make(map[string]bool, len(fields)+1)
+	requested["id"] = true
+	for _, field := range fields {
+		requested[field] = true
+	}
+
+	columns := make([]string, 0, len(requested))
+	for _, column := range models.PostFields {
+		if requested[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// scanTargets returns the scan destinations on post for the given columns
+func scanTargets(post *models.Post, columns []string) []any {
+	targets := make([]any, 0, len(columns))
+	for _, column := range columns {
+		switch column {
+		case "id":
+			targets = append(targets, &post.ID)
+		case "title":
+			targets = append(targets, &post.Title)
+		case "content":
+			targets = append(targets, &post.Content)
+		case "slug":
+			targets = append(targets, &post.Slug)
+		case "author_id":
+			targets = append(targets, &post.AuthorID)
+		case "status":
+			targets = append(targets, &post.Status)
+		case "created_at":
+			targets = append(targets, &post.CreatedAt)
+		case "updated_at":
+			targets = append(targets, &post.UpdatedAt)
+		case "published_at":
+			targets = append(targets, &post.PublishedAt)
+		case "meta_title":
+			targets = append(targets, &post.MetaTitle)
+		case "meta_description":
+			targets = append(targets, &post.MetaDescription)
+		case "canonical_url":
+			targets = append(targets, &post.CanonicalURL)
+		case "og_image":
+			targets = append(targets, &post.OGImage)
+		case "noindex":
+			targets = append(targets, &post.NoIndex)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"gofr-blog-service/models"
+)
+
+// TestSelectColumns tests that projections are whitelisted, ordered and always include id
+func TestSelectColumns(t *testing.T) {
+	columns := selectColumns([]string{"status", "title", "content; DROP TABLE posts", "title"})
+
+	expected := []string{"id", "title", "status"}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Expected columns %v, got %v", expected, columns)
+	}
+}
+
+// TestScanTargets tests that every selected column gets a scan destination
+func TestScanTargets(t *testing.T) {
+	var post models.Post
+	targets := scanTargets(&post, selectColumns(models.PostFields))
+
+	if len(targets) != len(models.PostFields) {
+		t.Errorf("Expected %d scan targets, got %d", len(models.PostFields), len(targets))
+	}
+}
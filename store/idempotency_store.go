@@ -0,0 +1,91 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// IdempotencyStore handles database operations for idempotency keys
+type IdempotencyStore struct {
+	tx Executor
+}
+
+// NewIdempotencyStore creates a new idempotency store instance
+func NewIdempotencyStore() *IdempotencyStore {
+	return &IdempotencyStore{}
+}
+
+// Claim records a new in-progress request for a caller's key and reports whether it was claimed.
+// When the key is already held, the existing record is returned instead; it is nil when the
+// holder disappeared between the two statements.
+func (is *IdempotencyStore) Claim(ctx *gofr.Context, caller, key, requestHash string,
+	ttl, lockTimeout time.Duration) (bool, *models.IdempotencyRecord, error) {
+	var claimedBy string
+	err := executorFor(ctx, is.tx).QueryRow(ClaimIdempotencyKeyQuery, caller, key, requestHash,
+		int64(ttl.Seconds()), int64(lockTimeout.Seconds())).Scan(&claimedBy)
+	if err == nil {
+		return true, nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	var record models.IdempotencyRecord
+	var response []byte
+	err = executorFor(ctx, is.tx).QueryRow(GetIdempotencyKeyQuery, caller, key).Scan(&record.Caller, &record.Key,
+		&record.RequestHash, &record.Status, &response, &record.CreatedAt, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil, nil
+		}
+		return false, nil, errors.Join(errDatabaseOperation, err)
+	}
+	record.Response = response
+
+	return false, &record, nil
+}
+
+// Complete stores the response of a key claimed for the request with requestHash. It returns
+// ErrNotFound when the claim was lost, such as to a retry that took over an expired lock.
+func (is *IdempotencyStore) Complete(ctx *gofr.Context, caller, key, requestHash string, response []byte) error {
+	result, err := executorFor(ctx, is.tx).Exec(CompleteIdempotencyKeyQuery, caller, key, requestHash, response)
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Release drops an in-progress key so its request can be retried
+func (is *IdempotencyStore) Release(ctx *gofr.Context, caller, key string) error {
+	if _, err := executorFor(ctx, is.tx).Exec(ReleaseIdempotencyKeyQuery, caller, key); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// Purge deletes expired keys and returns how many were removed
+func (is *IdempotencyStore) Purge(ctx *gofr.Context) (int64, error) {
+	result, err := executorFor(ctx, is.tx).Exec(PurgeIdempotencyKeysQuery)
+	if err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ImportStore handles database operations for import jobs and their items
+type ImportStore struct {
+	tx Executor
+}
+
+// NewImportStore creates a new import store instance
+func NewImportStore() *ImportStore {
+	return &ImportStore{}
+}
+
+// CreateJob queues the import of payload
+func (is *ImportStore) CreateJob(ctx *gofr.Context, source, checksum string, payload []byte,
+	options models.ImportOptions, total int) (*models.ImportJob, error) {
+	encodedOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	row := executorFor(ctx, is.tx).QueryRow(CreateImportJobQuery, source, checksum, payload, encodedOptions, total)
+	return scanImportJob(row)
+}
+
+// GetJob retrieves the import job with id
+func (is *ImportStore) GetJob(ctx *gofr.Context, id int) (*models.ImportJob, error) {
+	return scanImportJob(executorFor(ctx, is.tx).QueryRow(GetImportJobQuery, id))
+}
+
+// GetUnfinishedJob retrieves the latest unfinished import of the payload with checksum, or
+// ErrNotFound when there is none
+func (is *ImportStore) GetUnfinishedJob(ctx *gofr.Context, source, checksum string) (*models.ImportJob, error) {
+	return scanImportJob(executorFor(ctx, is.tx).QueryRow(GetUnfinishedImportJobQuery, source, checksum))
+}
+
+// LockJob locks the import job with id until the transaction ends and returns it with its payload
+func (is *ImportStore) LockJob(ctx *gofr.Context, id int) (*models.ImportJob, []byte, error) {
+	var payload []byte
+	job, err := scanImportJob(executorFor(ctx, is.tx).QueryRow(LockImportJobQuery, id), &payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, payload, nil
+}
+
+// NextJobID returns the id of the oldest unfinished import job that no other transaction holds,
+// locking it, or ErrNotFound when there is none
+func (is *ImportStore) NextJobID(ctx *gofr.Context) (int, error) {
+	var id int
+	if err := executorFor(ctx, is.tx).QueryRow(GetNextImportJobIDQuery).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+	return id, nil
+}
+
+// UpdateProgress sets the status of an import job and adds the outcome of a batch to its counters
+func (is *ImportStore) UpdateProgress(ctx *gofr.Context, id int, status string, imported, skipped, failed int,
+	lastError string) (*models.ImportJob, error) {
+	row := executorFor(ctx, is.tx).QueryRow(UpdateImportJobProgressQuery, id, status,
+		imported+skipped+failed, imported, skipped, failed, lastError)
+	return scanImportJob(row)
+}
+
+// ItemIDs returns the source ids of the items the import job with id has processed
+func (is *ImportStore) ItemIDs(ctx *gofr.Context, id int) (map[int]bool, error) {
+	rows, err := executorFor(ctx, is.tx).Query(GetImportItemIDsQuery, id)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int]bool)
+	for rows.Next() {
+		var sourceID int
+		if err = rows.Scan(&sourceID); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		ids[sourceID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return ids, nil
+}
+
+// RecordItem records the outcome of an item of the import job with id
+func (is *ImportStore) RecordItem(ctx *gofr.Context, id int, item models.ImportItem) error {
+	_, err := executorFor(ctx, is.tx).Exec(InsertImportItemQuery, id, item.SourceID, item.PostID, item.Status, item.Reason)
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// scanImportJob scans an import job row followed by extra columns
+func scanImportJob(row *sql.Row, extra ...any) (*models.ImportJob, error) {
+	var job models.ImportJob
+	var options []byte
+	targets := append([]any{&job.ID, &job.Source, &job.Checksum, &options, &job.Status, &job.Total, &job.Processed,
+		&job.Imported, &job.Skipped, &job.Failed, &job.LastError, &job.CreatedAt, &job.UpdatedAt, &job.CompletedAt},
+		extra...)
+
+	if err := row.Scan(targets...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	if err := json.Unmarshal(options, &job.Options); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &job, nil
+}
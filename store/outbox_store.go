@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// OutboxStore handles database operations for the transactional outbox
+type OutboxStore struct {
+	tx Executor
+}
+
+// NewOutboxStore creates a new outbox store instance
+func NewOutboxStore() *OutboxStore {
+	return &OutboxStore{}
+}
+
+// Enqueue appends messages to the outbox
+func (ob *OutboxStore) Enqueue(ctx *gofr.Context, messages ...models.OutboxMessage) error {
+	for i := range messages {
+		msg := &messages[i]
+		_, err := executorFor(ctx, ob.tx).Exec(InsertOutboxMessageQuery, msg.AggregateID, msg.EventType, msg.Topic, msg.Payload)
+		if err != nil {
+			return errors.Join(errDatabaseOperation, err)
+		}
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit due messages, at most one per aggregate, in delivery order for
+// lease, in one short statement. Claimed messages are not due again until the lease ends, so a
+// relay that dies while publishing them leaves them to be retried after it.
+func (ob *OutboxStore) ClaimDue(ctx *gofr.Context, limit int, lease time.Duration) ([]models.OutboxMessage, error) {
+	rows, err := executorFor(ctx, ob.tx).Query(ClaimDueOutboxMessagesQuery, limit, int64(lease.Seconds()))
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var messages []models.OutboxMessage
+	for rows.Next() {
+		var msg models.OutboxMessage
+		scanErr := rows.Scan(&msg.ID, &msg.AggregateID, &msg.EventType, &msg.Topic, &msg.Payload,
+			&msg.Attempts, &msg.CreatedAt)
+		if scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return messages, nil
+}
+
+// MarkSent records a successful delivery
+func (ob *OutboxStore) MarkSent(ctx *gofr.Context, id int64) error {
+	if _, err := executorFor(ctx, ob.tx).Exec(MarkOutboxMessageSentQuery, id); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// Reschedule records a failed delivery and schedules the next attempt
+func (ob *OutboxStore) Reschedule(ctx *gofr.Context, id int64, lastError string, nextAttempt time.Time) error {
+	if _, err := executorFor(ctx, ob.tx).Exec(RescheduleOutboxMessageQuery, id, lastError, nextAttempt); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// Fail dead-letters a message that ran out of delivery attempts
+func (ob *OutboxStore) Fail(ctx *gofr.Context, id int64, lastError string) error {
+	if _, err := executorFor(ctx, ob.tx).Exec(FailOutboxMessageQuery, id, lastError); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
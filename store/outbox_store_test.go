@@ -0,0 +1,63 @@
+package store
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gofr-blog-service/sqltest"
+)
+
+// TestOutboxStore_ClaimDue tests that due messages are claimed for a lease in one statement,
+// outside any transaction, and returned in delivery order
+func TestOutboxStore_ClaimDue(t *testing.T) {
+	created := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	db := sqltest.Open(func(query string, _ []driver.Value) sqltest.Result {
+		if query != ClaimDueOutboxMessagesQuery {
+			return sqltest.Result{}
+		}
+		return sqltest.Result{
+			Columns: []string{"id", "aggregate_id", "event_type", "topic", "payload", "attempts", "created_at"},
+			Rows: [][]driver.Value{
+				{int64(4), int64(10), "post.created", "posts", []byte(`{"id":"a"}`), int64(0), created},
+				{int64(7), int64(20), "post.updated", "posts", []byte(`{"id":"b"}`), int64(2), created},
+			},
+		}
+	})
+	ob := &OutboxStore{tx: db}
+
+	messages, err := ob.ClaimDue(nil, 50, 90*time.Second)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != 4 || messages[1].ID != 7 || messages[1].Attempts != 2 ||
+		string(messages[0].Payload) != `{"id":"a"}` {
+		t.Errorf("Unexpected claimed messages %+v", messages)
+	}
+
+	statements := db.Statements()
+	if len(statements) != 1 || statements[0].Query != ClaimDueOutboxMessagesQuery {
+		t.Fatalf("Expected the claim to run as one statement, got %v", db.Queries())
+	}
+	if !reflect.DeepEqual(statements[0].Args, []driver.Value{int64(50), int64(90)}) {
+		t.Errorf("Expected the limit and the lease in seconds, got %v", statements[0].Args)
+	}
+}
+
+// TestClaimDueOutboxMessagesQuery tests that the claim leases the head of each aggregate's
+// queue and skips rows other relays are claiming
+func TestClaimDueOutboxMessagesQuery(t *testing.T) {
+	for _, clause := range []string{
+		"UPDATE outbox SET next_attempt_at = NOW() + $2 * INTERVAL '1 second'",
+		"earlier.aggregate_id = o.aggregate_id AND earlier.id < o.id",
+		"FOR UPDATE SKIP LOCKED",
+		"RETURNING",
+		"ORDER BY id",
+	} {
+		if !strings.Contains(ClaimDueOutboxMessagesQuery, clause) {
+			t.Errorf("Expected the claim to contain %q", clause)
+		}
+	}
+}
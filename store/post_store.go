@@ -3,40 +3,70 @@ package store
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"gofr-blog-service/models"
 
+	"github.com/lib/pq"
 	"gofr.dev/pkg/gofr"
 )
 
+// ErrNotFound is returned when the requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
 // Error definitions
 var (
 	errDatabaseOperation = errors.New("database operation failed")
-	errNotFound          = errors.New("record not found")
 	errNoFieldsToUpdate  = errors.New("no fields to update")
 	errInvalidID         = errors.New("invalid ID")
 )
 
 // PostStore handles database operations for posts
-type PostStore struct{}
+type PostStore struct {
+	tx       Executor
+	replicas *ReplicaSet
+}
 
 // NewPostStore creates a new post store instance
 func NewPostStore() *PostStore {
 	return &PostStore{}
 }
 
+// db returns the executor for the store's operations
+func (ps *PostStore) db(ctx *gofr.Context) Executor {
+	return executorFor(ctx, ps.tx)
+}
+
+// reader returns the executor for the store's read queries: the bound transaction,
+// a healthy replica when replicas are set, or the context connection
+func (ps *PostStore) reader(ctx *gofr.Context) Executor {
+	if ps.tx != nil || ps.replicas == nil {
+		return ps.db(ctx)
+	}
+	return ps.replicas.Reader(ctx)
+}
+
+// SetReplicas routes the reads of post pages, single posts, counts and versions to replicas
+func (ps *PostStore) SetReplicas(replicas *ReplicaSet) {
+	ps.replicas = replicas
+}
+
+// Primary returns a store whose reads always go to the primary, for reads that must see recent writes
+func (ps *PostStore) Primary() *PostStore {
+	return &PostStore{tx: ps.tx}
+}
+
 // CreatePost persists a new blog post in the database
 func (ps *PostStore) CreatePost(ctx *gofr.Context, post models.CreatePostRequest) (*models.Post, error) {
 	var createdPost models.Post
-	err := ctx.SQL.QueryRow(
+	err := ps.db(ctx).QueryRow(
 		CreatePostQuery,
 		post.Title, post.Content, post.Slug, post.AuthorID, post.Status,
-	).Scan(
-		&createdPost.ID, &createdPost.Title, &createdPost.Content, &createdPost.Slug,
-		&createdPost.AuthorID, &createdPost.Status, &createdPost.CreatedAt, &createdPost.UpdatedAt,
-	)
+		post.MetaTitle, post.MetaDescription, post.CanonicalURL, post.OGImage, post.NoIndex,
+	).Scan(scanTargets(&createdPost, models.PostFields)...)
 
 	if err != nil {
 		return nil, errors.Join(errDatabaseOperation, err)
@@ -45,21 +75,111 @@ func (ps *PostStore) CreatePost(ctx *gofr.Context, post models.CreatePostRequest
 	return &createdPost, nil
 }
 
-// GetPostByID retrieves a single post from the database by ID
-func (ps *PostStore) GetPostByID(ctx *gofr.Context, id int) (*models.Post, error) {
+// ImportPost persists an imported post with its original creation time, which also becomes its
+// update time, and publication time
+func (ps *PostStore) ImportPost(ctx *gofr.Context, post models.CreatePostRequest, createdAt time.Time,
+	publishedAt *time.Time) (*models.Post, error) {
+	var importedPost models.Post
+	err := ps.db(ctx).QueryRow(
+		ImportPostQuery,
+		post.Title, post.Content, post.Slug, post.AuthorID, post.Status,
+		post.MetaTitle, post.MetaDescription, post.CanonicalURL, post.OGImage, post.NoIndex,
+		createdAt, publishedAt,
+	).Scan(scanTargets(&importedPost, models.PostFields)...)
+
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &importedPost, nil
+}
+
+// RestorePost inserts a post from a backup with its original times. A positive id is kept;
+// otherwise the post is given a new one.
+func (ps *PostStore) RestorePost(ctx *gofr.Context, post *models.Post, id int) (*models.Post, error) {
+	var postID any
+	if id > 0 {
+		postID = id
+	}
+
+	var restoredPost models.Post
+	err := ps.db(ctx).QueryRow(
+		RestorePostQuery,
+		postID, post.Title, post.Content, post.Slug, post.AuthorID, post.Status,
+		post.MetaTitle, post.MetaDescription, post.CanonicalURL, post.OGImage, post.NoIndex,
+		post.CreatedAt, post.UpdatedAt, post.PublishedAt,
+	).Scan(scanTargets(&restoredPost, models.PostFields)...)
+
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &restoredPost, nil
+}
+
+// OverwritePost replaces the post with id with a post from a backup, times included
+func (ps *PostStore) OverwritePost(ctx *gofr.Context, id int, post *models.Post) (*models.Post, error) {
+	var overwrittenPost models.Post
+	err := ps.db(ctx).QueryRow(
+		OverwritePostQuery,
+		id, post.Title, post.Content, post.Slug, post.AuthorID, post.Status,
+		post.MetaTitle, post.MetaDescription, post.CanonicalURL, post.OGImage, post.NoIndex,
+		post.CreatedAt, post.UpdatedAt, post.PublishedAt,
+	).Scan(scanTargets(&overwrittenPost, models.PostFields)...)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &overwrittenPost, nil
+}
+
+// ResetIDSequence moves the post id sequence past the highest post id
+func (ps *PostStore) ResetIDSequence(ctx *gofr.Context) error {
+	if _, err := ps.db(ctx).Exec(ResetPostIDSequenceQuery); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// GetPostByID retrieves a single post from the database by ID.
+// A non-empty fields list limits the columns selected; nil selects every column.
+func (ps *PostStore) GetPostByID(ctx *gofr.Context, id int, fields []string) (*models.Post, error) {
 	if id <= 0 {
 		return nil, errInvalidID
 	}
 
+	columns := selectColumns(models.PostFields)
+	query := GetPostByIDQuery
+	if len(fields) > 0 {
+		columns = selectColumns(fields)
+		query = fmt.Sprintf(GetPostByIDFieldsQuery, strings.Join(columns, ", "))
+	}
+
+	var post models.Post
+	err := ps.reader(ctx).QueryRow(query, id).Scan(scanTargets(&post, columns)...)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &post, nil
+}
+
+// GetPostBySlug retrieves a single post from the database by slug
+func (ps *PostStore) GetPostBySlug(ctx *gofr.Context, slug string) (*models.Post, error) {
 	var post models.Post
-	err := ctx.SQL.QueryRow(GetPostByIDQuery, id).Scan(
-		&post.ID, &post.Title, &post.Content, &post.Slug, &post.AuthorID,
-		&post.Status, &post.CreatedAt, &post.UpdatedAt,
-	)
+	err := ps.reader(ctx).QueryRow(GetPostBySlugQuery, slug).Scan(scanTargets(&post, models.PostFields)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errNotFound
+			return nil, ErrNotFound
 		}
 		return nil, errors.Join(errDatabaseOperation, err)
 	}
@@ -67,9 +187,17 @@ func (ps *PostStore) GetPostByID(ctx *gofr.Context, id int) (*models.Post, error
 	return &post, nil
 }
 
-// GetPosts retrieves posts from the database with pagination
-func (ps *PostStore) GetPosts(ctx *gofr.Context, limit, offset int) ([]models.Post, error) {
-	rows, err := ctx.SQL.Query(GetPostsQuery, limit, offset)
+// GetPosts retrieves posts from the database with pagination.
+// A non-empty fields list limits the columns selected; nil selects every column.
+func (ps *PostStore) GetPosts(ctx *gofr.Context, limit, offset int, fields []string) ([]models.Post, error) {
+	columns := selectColumns(models.PostFields)
+	query := GetPostsQuery
+	if len(fields) > 0 {
+		columns = selectColumns(fields)
+		query = fmt.Sprintf(GetPostsFieldsQuery, strings.Join(columns, ", "))
+	}
+
+	rows, err := ps.reader(ctx).Query(query, limit, offset)
 	if err != nil {
 		return nil, errors.Join(errDatabaseOperation, err)
 	}
@@ -78,10 +206,7 @@ func (ps *PostStore) GetPosts(ctx *gofr.Context, limit, offset int) ([]models.Po
 	var posts []models.Post
 	for rows.Next() {
 		var post models.Post
-		scanErr := rows.Scan(
-			&post.ID, &post.Title, &post.Content, &post.Slug, &post.AuthorID,
-			&post.Status, &post.CreatedAt, &post.UpdatedAt,
-		)
+		scanErr := rows.Scan(scanTargets(&post, columns)...)
 		if scanErr != nil {
 			return nil, errors.Join(errDatabaseOperation, scanErr)
 		}
@@ -95,16 +220,212 @@ func (ps *PostStore) GetPosts(ctx *gofr.Context, limit, offset int) ([]models.Po
 	return posts, nil
 }
 
+// GetPostsPage retrieves up to limit posts matching filter that come after cursor in
+// newest-first order; a nil cursor starts from the newest post
+func (ps *PostStore) GetPostsPage(ctx *gofr.Context, filter models.PostFilter, after *models.PostCursor,
+	limit int) ([]models.Post, error) {
+	var afterTime, afterID any
+	if after != nil {
+		afterTime, afterID = after.CreatedAt, after.ID
+	}
+
+	rows, err := ps.reader(ctx).Query(GetPostsPageQuery, filter.Status, filter.AuthorID, afterTime, afterID, limit,
+		filter.Tag)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanPosts(rows)
+}
+
+// CountPosts counts the posts matching filter
+func (ps *PostStore) CountPosts(ctx *gofr.Context, filter models.PostFilter) (int, error) {
+	var count int
+	err := ps.reader(ctx).QueryRow(CountFilteredPostsQuery, filter.Status, filter.AuthorID, filter.Tag).Scan(&count)
+	if err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+	return count, nil
+}
+
+// GetPublishedPostsByAuthors retrieves the newest published posts of each author in one query,
+// at most perAuthor each
+func (ps *PostStore) GetPublishedPostsByAuthors(ctx *gofr.Context, authorIDs []int, perAuthor int) ([]models.Post, error) {
+	ids := make([]int64, len(authorIDs))
+	for i, id := range authorIDs {
+		ids[i] = int64(id)
+	}
+
+	rows, err := ps.reader(ctx).Query(GetPublishedPostsByAuthorsQuery, pq.Array(ids), perAuthor)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanPosts(rows)
+}
+
+// scanPosts reads every column of the posts in rows and closes them
+func scanPosts(rows *sql.Rows) ([]models.Post, error) {
+	defer rows.Close()
+
+	var posts []models.Post
+	for rows.Next() {
+		var post models.Post
+		if err := rows.Scan(scanTargets(&post, models.PostFields)...); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return posts, nil
+}
+
+// GetPublishedPosts retrieves published posts matching a feed filter, newest first
+func (ps *PostStore) GetPublishedPosts(ctx *gofr.Context, filter models.FeedFilter) ([]models.Post, error) {
+	rows, err := ps.db(ctx).Query(GetPublishedPostsQuery, filter.AuthorID, filter.Limit, filter.Offset, filter.Tag)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var posts []models.Post
+	for rows.Next() {
+		var post models.Post
+		if scanErr := rows.Scan(scanTargets(&post, models.PostFields)...); scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		posts = append(posts, post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return posts, nil
+}
+
+// GetPostsVersion returns the newest update or delete time and count of all posts, the version of
+// post lists
+func (ps *PostStore) GetPostsVersion(ctx *gofr.Context) (*models.FeedVersion, error) {
+	var version models.FeedVersion
+	err := ps.reader(ctx).QueryRow(GetPostsVersionQuery).Scan(&version.LastModified, &version.Count)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &version, nil
+}
+
+// GetPublishedPostsVersion returns the newest update or delete time and count of published posts
+// matching a feed filter
+func (ps *PostStore) GetPublishedPostsVersion(ctx *gofr.Context, filter models.FeedFilter) (*models.FeedVersion, error) {
+	var version models.FeedVersion
+	err := ps.db(ctx).QueryRow(GetPublishedPostsVersionQuery, filter.AuthorID, filter.Tag).Scan(&version.LastModified, &version.Count)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &version, nil
+}
+
+// GetSitemapPosts retrieves the link fields of the indexable published posts in one sitemap chunk
+func (ps *PostStore) GetSitemapPosts(ctx *gofr.Context, limit, offset int) ([]models.Post, error) {
+	rows, err := ps.db(ctx).Query(GetSitemapPostsQuery, limit, offset)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	columns := []string{"id", "slug", "created_at", "updated_at", "published_at"}
+
+	var posts []models.Post
+	for rows.Next() {
+		var post models.Post
+		if scanErr := rows.Scan(scanTargets(&post, columns)...); scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		posts = append(posts, post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return posts, nil
+}
+
+// GetSitemapChunks splits the indexable published posts into chunks of chunkSize
+func (ps *PostStore) GetSitemapChunks(ctx *gofr.Context, chunkSize int) ([]models.SitemapChunk, error) {
+	rows, err := ps.db(ctx).Query(GetSitemapChunksQuery, chunkSize)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var chunks []models.SitemapChunk
+	for rows.Next() {
+		var chunk models.SitemapChunk
+		if scanErr := rows.Scan(&chunk.Index, &chunk.LastModified); scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return chunks, nil
+}
+
 // GetTotalPostCount returns the total number of posts in the database
 func (ps *PostStore) GetTotalPostCount(ctx *gofr.Context) (int, error) {
 	var totalCount int
-	err := ctx.SQL.QueryRow(GetTotalPostCountQuery).Scan(&totalCount)
+	err := ps.reader(ctx).QueryRow(GetTotalPostCountQuery).Scan(&totalCount)
 	if err != nil {
 		return 0, errors.Join(errDatabaseOperation, err)
 	}
 	return totalCount, nil
 }
 
+// GetPostForUpdate retrieves a post by ID and locks its row; it must run inside a transaction
+func (ps *PostStore) GetPostForUpdate(ctx *gofr.Context, id int) (*models.Post, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+
+	var post models.Post
+	err := ps.db(ctx).QueryRow(GetPostForUpdateQuery, id).Scan(scanTargets(&post, models.PostFields)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &post, nil
+}
+
+// ReplacePost overwrites every writable field of a post with req, so empty values clear fields
+func (ps *PostStore) ReplacePost(ctx *gofr.Context, id int, req models.CreatePostRequest) (*models.Post, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+
+	var post models.Post
+	err := ps.db(ctx).QueryRow(ReplacePostQuery, id,
+		req.Title, req.Content, req.Slug, req.AuthorID, req.Status,
+		req.MetaTitle, req.MetaDescription, req.CanonicalURL, req.OGImage, req.NoIndex,
+	).Scan(scanTargets(&post, models.PostFields)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &post, nil
+}
+
 // UpdatePost updates an existing post in the database
 func (ps *PostStore) UpdatePost(ctx *gofr.Context, id int, req models.UpdatePostRequest) (*models.Post, error) {
 	if id <= 0 {
@@ -118,14 +439,11 @@ func (ps *PostStore) UpdatePost(ctx *gofr.Context, id int, req models.UpdatePost
 	}
 
 	var post models.Post
-	err := ctx.SQL.QueryRow(query, args...).Scan(
-		&post.ID, &post.Title, &post.Content, &post.Slug, &post.AuthorID,
-		&post.Status, &post.CreatedAt, &post.UpdatedAt,
-	)
+	err := ps.db(ctx).QueryRow(query, args...).Scan(scanTargets(&post, models.PostFields)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errNotFound
+			return nil, ErrNotFound
 		}
 		return nil, errors.Join(errDatabaseOperation, err)
 	}
@@ -139,7 +457,7 @@ func (ps *PostStore) DeletePost(ctx *gofr.Context, id int) error {
 		return errInvalidID
 	}
 
-	result, err := ctx.SQL.Exec(DeletePostQuery, id)
+	result, err := ps.db(ctx).Exec(DeletePostQuery, id)
 	if err != nil {
 		return errors.Join(errDatabaseOperation, err)
 	}
@@ -150,12 +468,31 @@ func (ps *PostStore) DeletePost(ctx *gofr.Context, id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return errNotFound
+		return ErrNotFound
 	}
 
 	return nil
 }
 
+// TouchPost bumps the update time of a post and locks its row, so the post's validators change
+// with content stored outside of it; it must run inside a transaction
+func (ps *PostStore) TouchPost(ctx *gofr.Context, id int) (*models.Post, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+
+	var post models.Post
+	err := ps.db(ctx).QueryRow(TouchPostQuery, id).Scan(scanTargets(&post, models.PostFields)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return &post, nil
+}
+
 // Helper method to build dynamic update queries
 func (ps *PostStore) buildUpdateQuery(id int, req models.UpdatePostRequest) (query string, args []any) {
 	setParts := []string{}
@@ -178,10 +515,37 @@ func (ps *PostStore) buildUpdateQuery(id int, req models.UpdatePostRequest) (que
 		argIndex++
 	}
 	if req.Status != "" {
-		setParts = append(setParts, "status = $"+strconv.Itoa(argIndex))
+		setParts = append(setParts, "status = $"+strconv.Itoa(argIndex),
+			"published_at = CASE WHEN $"+strconv.Itoa(argIndex)+
+				" = 'published' THEN COALESCE(published_at, NOW()) ELSE published_at END")
 		args = append(args, req.Status)
 		argIndex++
 	}
+	if req.MetaTitle != "" {
+		setParts = append(setParts, "meta_title = $"+strconv.Itoa(argIndex))
+		args = append(args, req.MetaTitle)
+		argIndex++
+	}
+	if req.MetaDescription != "" {
+		setParts = append(setParts, "meta_description = $"+strconv.Itoa(argIndex))
+		args = append(args, req.MetaDescription)
+		argIndex++
+	}
+	if req.CanonicalURL != "" {
+		setParts = append(setParts, "canonical_url = $"+strconv.Itoa(argIndex))
+		args = append(args, req.CanonicalURL)
+		argIndex++
+	}
+	if req.OGImage != "" {
+		setParts = append(setParts, "og_image = $"+strconv.Itoa(argIndex))
+		args = append(args, req.OGImage)
+		argIndex++
+	}
+	if req.NoIndex != nil {
+		setParts = append(setParts, "noindex = $"+strconv.Itoa(argIndex))
+		args = append(args, *req.NoIndex)
+		argIndex++
+	}
 
 	setParts = append(setParts, "updated_at = NOW()")
 	args = append(args, id)
@@ -189,7 +553,7 @@ func (ps *PostStore) buildUpdateQuery(id int, req models.UpdatePostRequest) (que
 	// Using = instead of := since query is already declared in the return
 	query = "UPDATE posts SET " + strings.Join(setParts, ", ") +
 		" WHERE id = $" + strconv.Itoa(argIndex) +
-		" RETURNING id, title, content, slug, author_id, status, created_at, updated_at"
+		" RETURNING " + postColumns
 
 	return query, args
 }
@@ -22,8 +22,8 @@ func (m *MockPostStore) CreatePost(ctx *gofr.Context, post models.CreatePostRequ
 }
 
 // GetPostByID mocks the GetPostByID method
-func (m *MockPostStore) GetPostByID(ctx *gofr.Context, id int) (*models.Post, error) {
-	args := m.Called(ctx, id)
+func (m *MockPostStore) GetPostByID(ctx *gofr.Context, id int, fields []string) (*models.Post, error) {
+	args := m.Called(ctx, id, fields)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -31,8 +31,8 @@ func (m *MockPostStore) GetPostByID(ctx *gofr.Context, id int) (*models.Post, er
 }
 
 // GetPosts mocks the GetPosts method
-func (m *MockPostStore) GetPosts(ctx *gofr.Context, limit, offset int) ([]models.Post, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockPostStore) GetPosts(ctx *gofr.Context, limit, offset int, fields []string) ([]models.Post, error) {
+	args := m.Called(ctx, limit, offset, fields)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
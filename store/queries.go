@@ -1,35 +1,670 @@
 package store
 
+// postColumns is the full column list returned for a post, in models.PostFields order
+const postColumns = `id, title, content, slug, author_id, status, created_at, updated_at, published_at,
+		meta_title, meta_description, canonical_url, og_image, noindex`
+
 // SQL queries for post store operations
 const (
 	// CreatePostQuery inserts a new post into the database
 	CreatePostQuery = `
-		INSERT INTO posts (title, content, slug, author_id, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
-		RETURNING id, title, content, slug, author_id, status, created_at, updated_at
+		INSERT INTO posts (title, content, slug, author_id, status,
+			meta_title, meta_description, canonical_url, og_image, noindex, created_at, updated_at, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW(),
+			CASE WHEN $5 = 'published' THEN NOW() END)
+		RETURNING ` + postColumns
+
+	// ImportPostQuery inserts an imported post with its original creation and publication times
+	ImportPostQuery = `
+		INSERT INTO posts (title, content, slug, author_id, status,
+			meta_title, meta_description, canonical_url, og_image, noindex, created_at, updated_at, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11, $12)
+		RETURNING ` + postColumns + `
+	`
+
+	// RestorePostQuery inserts a restored post with its original times, keeping its id when one is given
+	RestorePostQuery = `
+		INSERT INTO posts (id, title, content, slug, author_id, status,
+			meta_title, meta_description, canonical_url, og_image, noindex, created_at, updated_at, published_at)
+		VALUES (COALESCE($1, nextval(pg_get_serial_sequence('posts', 'id'))), $2, $3, $4, $5, $6,
+			$7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING ` + postColumns + `
+	`
+
+	// OverwritePostQuery replaces every field of a post, times included, with those of a restored post
+	OverwritePostQuery = `
+		UPDATE posts
+		SET title = $2, content = $3, slug = $4, author_id = $5, status = $6,
+			meta_title = $7, meta_description = $8, canonical_url = $9, og_image = $10, noindex = $11,
+			created_at = $12, updated_at = $13, published_at = $14
+		WHERE id = $1
+		RETURNING ` + postColumns + `
+	`
+
+	// ResetPostIDSequenceQuery moves the post id sequence past the highest id, after ids were inserted explicitly
+	ResetPostIDSequenceQuery = `
+		SELECT setval(pg_get_serial_sequence('posts', 'id'), GREATEST((SELECT MAX(id) FROM posts), 1))
 	`
 
 	// GetPostByIDQuery retrieves a post by its ID
 	GetPostByIDQuery = `
-		SELECT id, title, content, slug, author_id, status, created_at, updated_at
+		SELECT ` + postColumns + `
+		FROM posts WHERE id = $1
+	`
+
+	// GetPostBySlugQuery retrieves a post by its slug
+	GetPostBySlugQuery = `
+		SELECT ` + postColumns + `
+		FROM posts WHERE slug = $1
+	`
+
+	// GetPostForUpdateQuery retrieves a post by its ID and locks it until the transaction ends
+	GetPostForUpdateQuery = `
+		SELECT ` + postColumns + `
 		FROM posts WHERE id = $1
+		FOR UPDATE
+	`
+
+	// ReplacePostQuery overwrites every writable field of a post, including empty values
+	ReplacePostQuery = `
+		UPDATE posts
+		SET title = $2, content = $3, slug = $4, author_id = $5, status = $6,
+			meta_title = $7, meta_description = $8, canonical_url = $9, og_image = $10, noindex = $11,
+			published_at = CASE WHEN $6 = 'published' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + postColumns + `
 	`
 
 	// GetPostsQuery retrieves posts with pagination
 	GetPostsQuery = `
-		SELECT id, title, content, slug, author_id, status, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts 
 		ORDER BY created_at DESC 
 		LIMIT $1 OFFSET $2
 	`
 
+	// GetPostsPageQuery retrieves a filtered page of posts after a (created_at, id) cursor, newest first
+	GetPostsPageQuery = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE ($1 = '' OR status = $1) AND ($2 = 0 OR author_id = $2)
+			AND ($3::timestamptz IS NULL OR (created_at, id) < ($3::timestamptz, $4))
+			AND ($6 = '' OR EXISTS (SELECT 1 FROM post_tags t WHERE t.post_id = posts.id AND t.tag = $6))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+
+	// CountFilteredPostsQuery counts the posts matching a filter
+	CountFilteredPostsQuery = `
+		SELECT COUNT(*) FROM posts
+		WHERE ($1 = '' OR status = $1) AND ($2 = 0 OR author_id = $2)
+			AND ($3 = '' OR EXISTS (SELECT 1 FROM post_tags t WHERE t.post_id = posts.id AND t.tag = $3))
+	`
+
+	// GetPublishedPostsByAuthorsQuery retrieves the newest published posts of each author, at most $2 per author
+	GetPublishedPostsByAuthorsQuery = `
+		SELECT ` + postColumns + `
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY author_id ORDER BY published_at DESC, id DESC) AS author_rank
+			FROM posts
+			WHERE status = 'published' AND author_id = ANY($1)
+		) ranked
+		WHERE author_rank <= $2
+		ORDER BY author_id, published_at DESC, id DESC
+	`
+
+	// GetPostByIDFieldsQuery retrieves the selected columns of a post by its ID
+	GetPostByIDFieldsQuery = `SELECT %s FROM posts WHERE id = $1`
+
+	// GetPostsFieldsQuery retrieves the selected columns of posts with pagination
+	GetPostsFieldsQuery = `SELECT %s FROM posts ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	// GetPublishedPostsQuery retrieves published posts, optionally by author and tag, newest first
+	GetPublishedPostsQuery = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE status = 'published' AND ($1 = 0 OR author_id = $1)
+			AND ($4 = '' OR EXISTS (SELECT 1 FROM post_tags t WHERE t.post_id = posts.id AND t.tag = $4))
+		ORDER BY published_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	// GetPostsVersionQuery returns the newest update or delete time and count of all posts
+	GetPostsVersionQuery = `
+		SELECT GREATEST(COALESCE(MAX(updated_at), TIMESTAMP WITH TIME ZONE 'epoch'),
+			(SELECT deleted_at FROM post_list_state)), COUNT(*)
+		FROM posts
+	`
+
+	// GetPublishedPostsVersionQuery returns the newest update or delete time and count of published
+	// posts, optionally by author and tag. Any delete counts, since deleted posts cannot be matched.
+	GetPublishedPostsVersionQuery = `
+		SELECT GREATEST(COALESCE(MAX(updated_at), TIMESTAMP WITH TIME ZONE 'epoch'),
+			(SELECT deleted_at FROM post_list_state)), COUNT(*)
+		FROM posts
+		WHERE status = 'published' AND ($1 = 0 OR author_id = $1)
+			AND ($2 = '' OR EXISTS (SELECT 1 FROM post_tags t WHERE t.post_id = posts.id AND t.tag = $2))
+	`
+
+	// GetSitemapPostsQuery retrieves the indexable published posts of one sitemap chunk
+	GetSitemapPostsQuery = `
+		SELECT id, slug, created_at, updated_at, published_at
+		FROM posts
+		WHERE status = 'published' AND NOT noindex
+		ORDER BY id
+		LIMIT $1 OFFSET $2
+	`
+
+	// GetSitemapChunksQuery splits the indexable published posts into chunks with their newest update time
+	GetSitemapChunksQuery = `
+		SELECT chunk, MAX(updated_at)
+		FROM (
+			SELECT (ROW_NUMBER() OVER (ORDER BY id) - 1) / $1 AS chunk, updated_at
+			FROM posts
+			WHERE status = 'published' AND NOT noindex
+		) numbered
+		GROUP BY chunk
+		ORDER BY chunk
+	`
+
 	// GetTotalPostCountQuery counts the total number of posts
 	GetTotalPostCountQuery = `SELECT COUNT(*) FROM posts`
 
 	// DeletePostQuery deletes a post by ID
 	DeletePostQuery = `DELETE FROM posts WHERE id = $1`
 
+	// TouchPostQuery bumps the update time of a post whose translations changed
+	TouchPostQuery = `UPDATE posts SET updated_at = NOW() WHERE id = $1 RETURNING ` + postColumns
+
 	// UpdatePostBaseQuery is the base for dynamic update queries
 	UpdatePostBaseQuery = `UPDATE posts SET %s, updated_at = NOW() WHERE id = $%d 
-		RETURNING id, title, content, slug, author_id, status, created_at, updated_at`
+		RETURNING ` + postColumns
+)
+
+// SQL queries for outbox store operations
+const (
+	// InsertOutboxMessageQuery appends an event to the outbox
+	InsertOutboxMessageQuery = `
+		INSERT INTO outbox (aggregate_id, event_type, topic, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	// ClaimDueOutboxMessagesQuery claims the oldest pending message of each aggregate that is due
+	// by pushing its next attempt back by a lease ($2 seconds), so no other relay picks it up while
+	// it is published; rows being claimed concurrently are skipped. Only the head of an aggregate's
+	// queue is claimed, and it blocks the rest until it is sent, so events of one post are
+	// delivered in order.
+	ClaimDueOutboxMessagesQuery = `
+		WITH claimed AS (
+			UPDATE outbox SET next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+			WHERE id IN (
+				SELECT o.id FROM outbox o
+				WHERE o.sent_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= NOW()
+					AND NOT EXISTS (
+						SELECT 1 FROM outbox earlier
+						WHERE earlier.aggregate_id = o.aggregate_id AND earlier.id < o.id
+							AND earlier.sent_at IS NULL AND earlier.failed_at IS NULL
+					)
+				ORDER BY o.id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, aggregate_id, event_type, topic, payload, attempts, created_at
+		)
+		SELECT id, aggregate_id, event_type, topic, payload, attempts, created_at
+		FROM claimed
+		ORDER BY id
+	`
+
+	// MarkOutboxMessageSentQuery records a successful delivery
+	MarkOutboxMessageSentQuery = `UPDATE outbox SET sent_at = NOW(), attempts = attempts + 1 WHERE id = $1`
+
+	// RescheduleOutboxMessageQuery records a failed delivery and schedules the next attempt
+	RescheduleOutboxMessageQuery = `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+
+	// FailOutboxMessageQuery dead-letters a message that ran out of attempts
+	FailOutboxMessageQuery = `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $2, failed_at = NOW()
+		WHERE id = $1
+	`
+)
+
+// webhookSubscriptionColumns is the column list returned for a webhook subscription
+const webhookSubscriptionColumns = `id, url, secret, event_types, active, created_at, updated_at`
+
+// webhookDeliveryColumns is the column list returned for a webhook delivery
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+		last_status_code, last_error, next_attempt_at, created_at, delivered_at`
+
+// SQL queries for webhook store operations
+const (
+	// CreateWebhookSubscriptionQuery inserts a new webhook subscription
+	CreateWebhookSubscriptionQuery = `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookSubscriptionColumns
+
+	// GetWebhookSubscriptionQuery retrieves a webhook subscription by its ID
+	GetWebhookSubscriptionQuery = `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	// ListWebhookSubscriptionsQuery retrieves every webhook subscription
+	ListWebhookSubscriptionsQuery = `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY id`
+
+	// RestoreWebhookSubscriptionQuery inserts a restored webhook subscription, keeping its times,
+	// unless a subscription to the same URL exists
+	RestoreWebhookSubscriptionQuery = `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, active, created_at, updated_at)
+		SELECT $1::TEXT, $2::TEXT, $3::TEXT, $4::BOOLEAN, $5::TIMESTAMPTZ, $6::TIMESTAMPTZ
+		WHERE NOT EXISTS (SELECT 1 FROM webhook_subscriptions WHERE url = $1::TEXT)
+		RETURNING ` + webhookSubscriptionColumns
+
+	// UpdateWebhookSubscriptionQuery updates a webhook subscription, keeping fields passed as NULL
+	UpdateWebhookSubscriptionQuery = `
+		UPDATE webhook_subscriptions SET
+			url = COALESCE($2, url),
+			secret = COALESCE($3, secret),
+			event_types = COALESCE($4, event_types),
+			active = COALESCE($5, active)
+		WHERE id = $1
+		RETURNING ` + webhookSubscriptionColumns
+
+	// DeleteWebhookSubscriptionQuery deletes a webhook subscription and its delivery log
+	DeleteWebhookSubscriptionQuery = `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	// EnqueueWebhookDeliveriesQuery fans an event out to every active subscription listening for its type
+	EnqueueWebhookDeliveriesQuery = `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3
+		FROM webhook_subscriptions
+		WHERE active AND ($2 = ANY(string_to_array(event_types, ',')) OR '*' = ANY(string_to_array(event_types, ',')))
+	`
+
+	// ClaimDueWebhookDeliveriesQuery claims pending deliveries whose next attempt is due by
+	// pushing that attempt back by a lease ($2 seconds), so no other dispatcher picks them up
+	// while they are sent; rows being claimed concurrently are skipped
+	ClaimDueWebhookDeliveriesQuery = `
+		WITH claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= NOW()
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + webhookDeliveryColumns + `
+		)
+		SELECT c.id, c.subscription_id, c.event_id, c.event_type, c.payload, c.status, c.attempts,
+			c.last_status_code, c.last_error, c.next_attempt_at, c.created_at, c.delivered_at, s.url, s.secret
+		FROM claimed c
+		JOIN webhook_subscriptions s ON s.id = c.subscription_id
+		ORDER BY c.id
+	`
+
+	// RecordWebhookAttemptQuery records the outcome of a delivery attempt
+	RecordWebhookAttemptQuery = `
+		UPDATE webhook_deliveries SET
+			status = $2,
+			attempts = attempts + 1,
+			last_status_code = $3,
+			last_error = $4,
+			next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = 'succeeded' THEN NOW() ELSE delivered_at END
+		WHERE id = $1
+	`
+
+	// ListWebhookDeliveriesQuery retrieves the delivery log of a subscription, newest first
+	ListWebhookDeliveriesQuery = `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	// CountWebhookDeliveriesQuery counts the delivery log of a subscription
+	CountWebhookDeliveriesQuery = `
+		SELECT COUNT(*) FROM webhook_deliveries WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+	`
+
+	// ReplayWebhookDeliveryQuery queues a fresh copy of a logged delivery
+	ReplayWebhookDeliveryQuery = `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT subscription_id, event_id, event_type, payload
+		FROM webhook_deliveries
+		WHERE id = $1 AND subscription_id = $2
+		RETURNING ` + webhookDeliveryColumns
+
+	// auditColumns lists audit_log columns in scan order
+	auditColumns = "id, action, entity_type, entity_id, actor, claimed_actor, request_id, ip, changes, created_at"
+
+	// InsertAuditEntryQuery appends an entry to the audit log
+	InsertAuditEntryQuery = `
+		INSERT INTO audit_log (action, entity_type, entity_id, actor, claimed_actor, request_id, ip, changes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	// auditFilterClause matches the optional AuditFilter fields; empty values match everything
+	auditFilterClause = `
+		WHERE ($1 = '' OR action = $1)
+		  AND ($2 = '' OR entity_type = $2)
+		  AND ($3 = 0 OR entity_id = $3)
+		  AND ($4 = '' OR actor = $4)
+		  AND ($5 = '' OR request_id = $5)
+		  AND ($6::timestamptz IS NULL OR created_at >= $6)
+		  AND ($7::timestamptz IS NULL OR created_at < $7)
+	`
+
+	// ListAuditEntriesQuery retrieves filtered audit entries, newest first
+	ListAuditEntriesQuery = `
+		SELECT ` + auditColumns + `
+		FROM audit_log` + auditFilterClause + `
+		ORDER BY id DESC
+		LIMIT $8 OFFSET $9
+	`
+
+	// ListAuditPageQuery retrieves the audit entries after an id, oldest first
+	ListAuditPageQuery = `SELECT ` + auditColumns + ` FROM audit_log WHERE id > $1 ORDER BY id LIMIT $2`
+
+	// RestoreAuditEntryQuery appends a restored entry to the audit log, keeping its time
+	RestoreAuditEntryQuery = `
+		INSERT INTO audit_log (action, entity_type, entity_id, actor, claimed_actor, request_id, ip, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	// CountAuditEntriesQuery counts filtered audit entries
+	CountAuditEntriesQuery = `SELECT COUNT(*) FROM audit_log` + auditFilterClause
+
+	// PurgeAuditEntriesQuery deletes audit entries older than the retention cutoff
+	PurgeAuditEntriesQuery = `DELETE FROM audit_log WHERE created_at < $1`
+
+	// ClaimIdempotencyKeyQuery claims a key for a caller. An existing key is only taken over once it
+	// has expired or its original request has been in progress longer than the lock timeout ($5 seconds).
+	ClaimIdempotencyKeyQuery = `
+		INSERT INTO idempotency_keys (caller, idempotency_key, request_hash, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4 * INTERVAL '1 second')
+		ON CONFLICT (caller, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status = 'in_progress',
+			response = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()
+		   OR (idempotency_keys.status = 'in_progress'
+		       AND idempotency_keys.created_at < NOW() - $5 * INTERVAL '1 second')
+		RETURNING caller
+	`
+
+	// GetIdempotencyKeyQuery retrieves the recorded request of a caller's key
+	GetIdempotencyKeyQuery = `
+		SELECT caller, idempotency_key, request_hash, status, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE caller = $1 AND idempotency_key = $2
+	`
+
+	// CompleteIdempotencyKeyQuery stores the response of a key still claimed for a request
+	CompleteIdempotencyKeyQuery = `
+		UPDATE idempotency_keys SET status = 'completed', response = $4
+		WHERE caller = $1 AND idempotency_key = $2 AND request_hash = $3 AND status = 'in_progress'
+	`
+
+	// ReleaseIdempotencyKeyQuery drops a claimed key so the request can be retried
+	ReleaseIdempotencyKeyQuery = `
+		DELETE FROM idempotency_keys WHERE caller = $1 AND idempotency_key = $2 AND status = 'in_progress'
+	`
+
+	// PurgeIdempotencyKeysQuery deletes expired keys
+	PurgeIdempotencyKeysQuery = `DELETE FROM idempotency_keys WHERE expires_at < NOW()`
+)
+
+// importJobColumns is the column list returned for an import job, without its payload
+const importJobColumns = `id, source, checksum, options, status, total, processed, imported, skipped, failed,
+		last_error, created_at, updated_at, completed_at`
+
+// SQL queries for import job store operations
+const (
+	// CreateImportJobQuery queues an import of a payload
+	CreateImportJobQuery = `
+		INSERT INTO import_jobs (source, checksum, payload, options, total)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + importJobColumns + `
+	`
+
+	// GetImportJobQuery retrieves an import job by its ID
+	GetImportJobQuery = `SELECT ` + importJobColumns + ` FROM import_jobs WHERE id = $1`
+
+	// GetUnfinishedImportJobQuery retrieves the latest unfinished import of a payload
+	GetUnfinishedImportJobQuery = `
+		SELECT ` + importJobColumns + `
+		FROM import_jobs
+		WHERE source = $1 AND checksum = $2 AND status IN ('pending', 'running')
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	// LockImportJobQuery locks an import job and reads its payload
+	LockImportJobQuery = `
+		SELECT ` + importJobColumns + `, payload
+		FROM import_jobs WHERE id = $1
+		FOR UPDATE
+	`
+
+	// GetNextImportJobIDQuery retrieves the oldest unfinished import job no other instance is running
+	GetNextImportJobIDQuery = `
+		SELECT id FROM import_jobs
+		WHERE status IN ('pending', 'running')
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	// UpdateImportJobProgressQuery adds the outcome of a batch to an import job
+	UpdateImportJobProgressQuery = `
+		UPDATE import_jobs SET
+			status = $2,
+			processed = processed + $3,
+			imported = imported + $4,
+			skipped = skipped + $5,
+			failed = failed + $6,
+			last_error = $7,
+			updated_at = NOW(),
+			completed_at = CASE WHEN $2 IN ('completed', 'failed') THEN NOW() END
+		WHERE id = $1
+		RETURNING ` + importJobColumns + `
+	`
+
+	// GetImportItemIDsQuery retrieves the source ids of the items an import job has processed
+	GetImportItemIDsQuery = `SELECT source_id FROM import_items WHERE job_id = $1`
+
+	// InsertImportItemQuery records the outcome of an item of an import job
+	InsertImportItemQuery = `
+		INSERT INTO import_items (job_id, source_id, post_id, status, reason)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+)
+
+// translationColumns is the full column list returned for a post translation
+const translationColumns = `id, post_id, locale, title, content, slug,
+		meta_title, meta_description, canonical_url, og_image, created_at, updated_at`
+
+// SQL queries for translation store operations
+const (
+	// UpsertTranslationQuery creates or replaces the translation of a post in a locale
+	UpsertTranslationQuery = `
+		INSERT INTO post_translations (post_id, locale, title, content, slug,
+			meta_title, meta_description, canonical_url, og_image)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (post_id, locale) DO UPDATE SET
+			title = EXCLUDED.title,
+			content = EXCLUDED.content,
+			slug = EXCLUDED.slug,
+			meta_title = EXCLUDED.meta_title,
+			meta_description = EXCLUDED.meta_description,
+			canonical_url = EXCLUDED.canonical_url,
+			og_image = EXCLUDED.og_image,
+			updated_at = NOW()
+		RETURNING ` + translationColumns + `
+	`
+
+	// ListTranslationsQuery retrieves the translations of a post
+	ListTranslationsQuery = `SELECT ` + translationColumns + ` FROM post_translations WHERE post_id = $1 ORDER BY locale`
+
+	// GetTranslationBySlugQuery retrieves the translation holding a slug in a locale
+	GetTranslationBySlugQuery = `SELECT ` + translationColumns + ` FROM post_translations WHERE locale = $1 AND slug = $2`
+
+	// GetPostsTranslationsQuery retrieves the translations of posts in any of the given locales
+	GetPostsTranslationsQuery = `
+		SELECT ` + translationColumns + `
+		FROM post_translations
+		WHERE post_id = ANY($1) AND locale = ANY($2)
+	`
+
+	// GetPostsAlternatesQuery retrieves the locale and slug of every translation of posts
+	GetPostsAlternatesQuery = `
+		SELECT post_id, locale, slug
+		FROM post_translations
+		WHERE post_id = ANY($1)
+		ORDER BY post_id, locale
+	`
+
+	// ListAllTranslationsQuery retrieves every translation
+	ListAllTranslationsQuery = `SELECT ` + translationColumns + ` FROM post_translations ORDER BY id`
+
+	// RestoreTranslationQuery creates or replaces the translation of a post in a locale, keeping
+	// the times of the restored translation
+	RestoreTranslationQuery = `
+		INSERT INTO post_translations (post_id, locale, title, content, slug,
+			meta_title, meta_description, canonical_url, og_image, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (post_id, locale) DO UPDATE SET
+			title = EXCLUDED.title,
+			content = EXCLUDED.content,
+			slug = EXCLUDED.slug,
+			meta_title = EXCLUDED.meta_title,
+			meta_description = EXCLUDED.meta_description,
+			canonical_url = EXCLUDED.canonical_url,
+			og_image = EXCLUDED.og_image,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + translationColumns + `
+	`
+
+	// DeleteTranslationQuery deletes the translation of a post in a locale
+	DeleteTranslationQuery = `DELETE FROM post_translations WHERE post_id = $1 AND locale = $2`
+)
+
+// SQL queries for tag store operations
+const (
+	// DeletePostTagsQuery removes the tags of a post that are not in a list
+	DeletePostTagsQuery = `DELETE FROM post_tags WHERE post_id = $1 AND tag <> ALL($2)`
+
+	// InsertPostTagsQuery adds tags to a post, ignoring those it already has
+	InsertPostTagsQuery = `
+		INSERT INTO post_tags (post_id, tag)
+		SELECT $1, UNNEST($2::VARCHAR[])
+		ON CONFLICT DO NOTHING
+	`
+
+	// GetAllPostTagsQuery retrieves the tags of every post
+	GetAllPostTagsQuery = `SELECT post_id, tag FROM post_tags ORDER BY post_id, tag`
+
+	// GetPostsTagsQuery retrieves the tags of posts
+	GetPostsTagsQuery = `
+		SELECT post_id, tag
+		FROM post_tags
+		WHERE post_id = ANY($1)
+		ORDER BY post_id, tag
+	`
+
+	// GetUsedTagsQuery retrieves which of the given tags some post has
+	GetUsedTagsQuery = `SELECT DISTINCT tag FROM post_tags WHERE tag = ANY($1)`
+)
+
+// userColumns is the full column list returned for a user
+const userColumns = `id, name, email, role, disabled, created_at`
+
+// apiKeyColumns is the full column list returned for an API key
+const apiKeyColumns = `id, user_id, name, prefix, created_at, last_used_at, revoked_at`
+
+// SQL queries for user and API key store operations
+const (
+	// CreateUserQuery inserts a user
+	CreateUserQuery = `
+		INSERT INTO users (name, email, role)
+		VALUES ($1, $2, $3)
+		RETURNING ` + userColumns + `
+	`
+
+	// GetUserQuery retrieves a user by ID
+	GetUserQuery = `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	// GetUserByEmailQuery retrieves a user by email address
+	GetUserByEmailQuery = `SELECT ` + userColumns + ` FROM users WHERE email = $1`
+
+	// ListUsersQuery retrieves every user
+	ListUsersQuery = `SELECT ` + userColumns + ` FROM users ORDER BY id`
+
+	// RestoreUserQuery inserts a restored user, keeping its creation time
+	RestoreUserQuery = `
+		INSERT INTO users (name, email, role, disabled, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + userColumns + `
+	`
+
+	// SetUserDisabledQuery disables or enables a user
+	SetUserDisabledQuery = `UPDATE users SET disabled = $2 WHERE id = $1 RETURNING ` + userColumns
+
+	// CreateAPIKeyQuery inserts an API key of a user by the hash of its secret
+	CreateAPIKeyQuery = `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + apiKeyColumns + `
+	`
+
+	// ListAPIKeysQuery retrieves the API keys of a user, or of every user when the ID is 0
+	ListAPIKeysQuery = `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE $1 = 0 OR user_id = $1
+		ORDER BY id
+	`
+
+	// ListAPIKeyRecordsQuery retrieves every API key with the hash of its secret
+	ListAPIKeyRecordsQuery = `SELECT ` + apiKeyColumns + `, key_hash FROM api_keys ORDER BY id`
+
+	// RestoreAPIKeyQuery inserts a restored API key, keeping its times, unless a key with the same
+	// hash exists
+	RestoreAPIKeyQuery = `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, created_at, last_used_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (key_hash) DO NOTHING
+		RETURNING ` + apiKeyColumns + `
+	`
+
+	// RevokeAPIKeyQuery revokes an API key that is not revoked yet
+	RevokeAPIKeyQuery = `
+		UPDATE api_keys SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING ` + apiKeyColumns + `
+	`
+
+	// GetActiveAPIKeyQuery retrieves the unrevoked API key with a secret hash along with its
+	// enabled user
+	GetActiveAPIKeyQuery = `
+		SELECT k.id, k.user_id, k.name, k.prefix, k.created_at, k.last_used_at, k.revoked_at,
+			u.id, u.name, u.email, u.role, u.disabled, u.created_at
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND NOT u.disabled
+	`
+
+	// TouchAPIKeyQuery records the use of an API key, at most once a minute
+	TouchAPIKeyQuery = `
+		UPDATE api_keys SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
 )
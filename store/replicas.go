@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	// Registers the postgres driver for replica connections
+	_ "github.com/lib/pq"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+var errNoReplicas = errors.New("no replica connections configured")
+
+// replicaPingTimeout bounds each health check ping
+const replicaPingTimeout = 2 * time.Second
+
+// replica is a read-only connection and its last known health
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// ReplicaSet routes reads across replica connections, skipping those that failed their
+// last health check. Reads go to the primary when no replica is healthy.
+type ReplicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+// ReplicaConfig describes the connection pool of each replica
+type ReplicaConfig struct {
+	MaxOpenConns int
+	MaxIdleConns int
+}
+
+// OpenReplicas opens a postgres connection per data source name, keyed by a name used in logs.
+// Replicas that do not answer a first ping start unhealthy.
+func OpenReplicas(dsns map[string]string, cfg ReplicaConfig, logger logging.Logger) (*ReplicaSet, error) {
+	if len(dsns) == 0 {
+		return nil, errNoReplicas
+	}
+
+	rs := &ReplicaSet{}
+	for name, dsn := range dsns {
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			rs.Close()
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		if cfg.MaxOpenConns > 0 {
+			db.SetMaxOpenConns(cfg.MaxOpenConns)
+		}
+		if cfg.MaxIdleConns > 0 {
+			db.SetMaxIdleConns(cfg.MaxIdleConns)
+		}
+		r := &replica{name: name, db: db}
+		r.healthy.Store(true)
+		rs.replicas = append(rs.replicas, r)
+	}
+
+	rs.checkHealth(context.Background(), logger)
+	return rs, nil
+}
+
+// Reader returns the next healthy replica in round-robin order, or the primary of ctx
+func (rs *ReplicaSet) Reader(ctx *gofr.Context) Executor {
+	if rs == nil || len(rs.replicas) == 0 {
+		return ctx.SQL
+	}
+
+	start := rs.next.Add(1)
+	for i := range rs.replicas {
+		r := rs.replicas[(start+uint64(i))%uint64(len(rs.replicas))]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return ctx.SQL
+}
+
+// CheckHealth pings every replica and updates its health, logging changes.
+// It is meant to run as a cron job.
+func (rs *ReplicaSet) CheckHealth(ctx *gofr.Context) {
+	rs.checkHealth(ctx, ctx.Logger)
+}
+
+// checkHealth pings every replica and updates its health, logging changes
+func (rs *ReplicaSet) checkHealth(ctx context.Context, logger logging.Logger) {
+	for _, r := range rs.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if r.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			logger.Infof("Read replica %s is healthy", r.name)
+		} else {
+			logger.Errorf("Read replica %s is unhealthy, reading from the primary instead: %v", r.name, err)
+		}
+	}
+}
+
+// Close closes every replica connection
+func (rs *ReplicaSet) Close() {
+	for _, r := range rs.replicas {
+		_ = r.db.Close()
+	}
+}
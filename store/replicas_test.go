@@ -0,0 +1,54 @@
+package store
+
+import (
+	"database/sql"
+	"testing"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+)
+
+// TestReplicaSet_Reader tests round-robin routing over healthy replicas and the primary fallback
+func TestReplicaSet_Reader(t *testing.T) {
+	ctx := &gofr.Context{Container: &container.Container{}}
+
+	first := &replica{name: "replica-1", db: new(sql.DB)}
+	second := &replica{name: "replica-2", db: new(sql.DB)}
+	first.healthy.Store(true)
+	second.healthy.Store(true)
+	rs := &ReplicaSet{replicas: []*replica{first, second}}
+
+	seen := map[Executor]int{}
+	for i := 0; i < 4; i++ {
+		seen[rs.Reader(ctx)]++
+	}
+	if seen[first.db] != 2 || seen[second.db] != 2 {
+		t.Errorf("Expected reads spread evenly over both replicas, got %v", seen)
+	}
+
+	second.healthy.Store(false)
+	for i := 0; i < 3; i++ {
+		if reader := rs.Reader(ctx); reader != first.db {
+			t.Errorf("Expected the healthy replica, got %v", reader)
+		}
+	}
+
+	first.healthy.Store(false)
+	if reader := rs.Reader(ctx); reader != nil {
+		t.Errorf("Expected the primary without healthy replicas, got %v", reader)
+	}
+}
+
+// TestPostStore_Primary tests that a primary store never routes reads to replicas
+func TestPostStore_Primary(t *testing.T) {
+	replicas := &ReplicaSet{}
+	ps := NewPostStore()
+	ps.SetReplicas(replicas)
+
+	if primary := ps.Primary(); primary.replicas != nil {
+		t.Error("Expected the primary store to have no replicas")
+	}
+	if ps.replicas != replicas {
+		t.Error("Expected the original store to keep its replicas")
+	}
+}
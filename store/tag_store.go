@@ -0,0 +1,117 @@
+package store
+
+import (
+	"errors"
+
+	"gofr-blog-service/models"
+
+	"github.com/lib/pq"
+	"gofr.dev/pkg/gofr"
+)
+
+// TagStore handles database operations for post tags
+type TagStore struct {
+	tx Executor
+}
+
+// NewTagStore creates a new tag store instance
+func NewTagStore() *TagStore {
+	return &TagStore{}
+}
+
+// SetPostTags replaces the tags of the post with postID with tags
+func (ts *TagStore) SetPostTags(ctx *gofr.Context, postID int, tags []string) error {
+	db := executorFor(ctx, ts.tx)
+	if _, err := db.Exec(DeletePostTagsQuery, postID, pq.Array(tags)); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	if _, err := db.Exec(InsertPostTagsQuery, postID, pq.Array(tags)); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// All retrieves the tags of every tagged post, ordered by post id
+func (ts *TagStore) All(ctx *gofr.Context) ([]models.PostTags, error) {
+	rows, err := executorFor(ctx, ts.tx).Query(GetAllPostTagsQuery)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var all []models.PostTags
+	for rows.Next() {
+		var postID int
+		var tag string
+		if err = rows.Scan(&postID, &tag); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		if len(all) == 0 || all[len(all)-1].PostID != postID {
+			all = append(all, models.PostTags{PostID: postID})
+		}
+		all[len(all)-1].Tags = append(all[len(all)-1].Tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return all, nil
+}
+
+// Used returns which of tags some post has
+func (ts *TagStore) Used(ctx *gofr.Context, tags []string) (map[string]bool, error) {
+	used := make(map[string]bool)
+	if len(tags) == 0 {
+		return used, nil
+	}
+
+	rows, err := executorFor(ctx, ts.tx).Query(GetUsedTagsQuery, pq.Array(tags))
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tag string
+		if err = rows.Scan(&tag); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		used[tag] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return used, nil
+}
+
+// ForPosts returns the tags of the posts with postIDs in alphabetical order, keyed by post id
+func (ts *TagStore) ForPosts(ctx *gofr.Context, postIDs []int) (map[int][]string, error) {
+	tags := make(map[int][]string)
+	if len(postIDs) == 0 {
+		return tags, nil
+	}
+
+	rows, err := executorFor(ctx, ts.tx).Query(GetPostsTagsQuery, pq.Array(postIDs))
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID int
+		var tag string
+		if err = rows.Scan(&postID, &tag); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		tags[postID] = append(tags[postID], tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return tags, nil
+}
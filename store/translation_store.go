@@ -0,0 +1,159 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+
+	"gofr-blog-service/models"
+
+	"github.com/lib/pq"
+	"gofr.dev/pkg/gofr"
+)
+
+// TranslationStore handles database operations for post translations
+type TranslationStore struct {
+	tx Executor
+}
+
+// NewTranslationStore creates a new translation store instance
+func NewTranslationStore() *TranslationStore {
+	return &TranslationStore{}
+}
+
+// Upsert creates or replaces the translation of the post with postID in locale
+func (ts *TranslationStore) Upsert(ctx *gofr.Context, postID int, locale string,
+	req models.TranslationRequest) (*models.PostTranslation, error) {
+	row := executorFor(ctx, ts.tx).QueryRow(UpsertTranslationQuery, postID, locale,
+		req.Title, req.Content, req.Slug, req.MetaTitle, req.MetaDescription, req.CanonicalURL, req.OGImage)
+	return scanTranslation(row)
+}
+
+// List retrieves the translations of the post with postID, ordered by locale
+func (ts *TranslationStore) List(ctx *gofr.Context, postID int) ([]models.PostTranslation, error) {
+	rows, err := executorFor(ctx, ts.tx).Query(ListTranslationsQuery, postID)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanTranslations(rows)
+}
+
+// GetBySlug retrieves the translation holding slug in locale, or ErrNotFound when there is none
+func (ts *TranslationStore) GetBySlug(ctx *gofr.Context, locale, slug string) (*models.PostTranslation, error) {
+	return scanTranslation(executorFor(ctx, ts.tx).QueryRow(GetTranslationBySlugQuery, locale, slug))
+}
+
+// ForPosts retrieves the translations of the posts with postIDs in any of locales
+func (ts *TranslationStore) ForPosts(ctx *gofr.Context, postIDs []int, locales []string) ([]models.PostTranslation, error) {
+	if len(postIDs) == 0 || len(locales) == 0 {
+		return nil, nil
+	}
+
+	rows, err := executorFor(ctx, ts.tx).Query(GetPostsTranslationsQuery, pq.Array(postIDs), pq.Array(locales))
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanTranslations(rows)
+}
+
+// Alternates returns the locale and slug of every translation of the posts with postIDs,
+// keyed by post id
+func (ts *TranslationStore) Alternates(ctx *gofr.Context, postIDs []int) (map[int][]models.PostAlternate, error) {
+	alternates := make(map[int][]models.PostAlternate)
+	if len(postIDs) == 0 {
+		return alternates, nil
+	}
+
+	rows, err := executorFor(ctx, ts.tx).Query(GetPostsAlternatesQuery, pq.Array(postIDs))
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID int
+		var alternate models.PostAlternate
+		if err = rows.Scan(&postID, &alternate.Locale, &alternate.Slug); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		alternates[postID] = append(alternates[postID], alternate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return alternates, nil
+}
+
+// All retrieves every translation, ordered by id
+func (ts *TranslationStore) All(ctx *gofr.Context) ([]models.PostTranslation, error) {
+	rows, err := executorFor(ctx, ts.tx).Query(ListAllTranslationsQuery)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return scanTranslations(rows)
+}
+
+// Restore creates or replaces the translation of the post with postID in the locale of
+// translation, keeping its times
+func (ts *TranslationStore) Restore(ctx *gofr.Context, postID int, translation *models.PostTranslation) (*models.PostTranslation, error) {
+	row := executorFor(ctx, ts.tx).QueryRow(RestoreTranslationQuery, postID, translation.Locale,
+		translation.Title, translation.Content, translation.Slug, translation.MetaTitle, translation.MetaDescription,
+		translation.CanonicalURL, translation.OGImage, translation.CreatedAt, translation.UpdatedAt)
+	return scanTranslation(row)
+}
+
+// Delete removes the translation of the post with postID in locale
+func (ts *TranslationStore) Delete(ctx *gofr.Context, postID int, locale string) error {
+	result, err := executorFor(ctx, ts.tx).Exec(DeleteTranslationQuery, postID, locale)
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// translationTargets returns the scan destinations on translation for translationColumns
+func translationTargets(translation *models.PostTranslation) []any {
+	return []any{&translation.ID, &translation.PostID, &translation.Locale, &translation.Title,
+		&translation.Content, &translation.Slug, &translation.MetaTitle, &translation.MetaDescription,
+		&translation.CanonicalURL, &translation.OGImage, &translation.CreatedAt, &translation.UpdatedAt}
+}
+
+// scanTranslation scans a translation row
+func scanTranslation(row *sql.Row) (*models.PostTranslation, error) {
+	var translation models.PostTranslation
+	if err := row.Scan(translationTargets(&translation)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &translation, nil
+}
+
+// scanTranslations scans translation rows and closes them
+func scanTranslations(rows *sql.Rows) ([]models.PostTranslation, error) {
+	defer rows.Close()
+
+	translations := []models.PostTranslation{}
+	for rows.Next() {
+		var translation models.PostTranslation
+		if err := rows.Scan(translationTargets(&translation)...); err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		translations = append(translations, translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return translations, nil
+}
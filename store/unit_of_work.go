@@ -0,0 +1,189 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+)
+
+var errInvalidIsolation = errors.New("invalid transaction isolation level")
+
+// TxOptions configures the transaction of a unit of work.
+// The zero value uses the database's default isolation level.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+}
+
+// Tx is a transaction a unit of work runs in; GoFr's SQL transactions implement it
+type Tx interface {
+	Executor
+	Commit() error
+	Rollback() error
+}
+
+// UnitOfWork groups store operations into one transaction.
+// Its stores run every statement inside the shared transaction.
+type UnitOfWork struct {
+	tx Executor
+
+	// afterCommit holds the hooks to run once the transaction commits; savepoints
+	// remember how many were registered so rolling back to one drops the later hooks
+	afterCommit []func()
+	savepoints  map[string]int
+
+	Posts        *PostStore
+	Outbox       *OutboxStore
+	Webhooks     *WebhookStore
+	Audit        *AuditStore
+	Imports      *ImportStore
+	Translations *TranslationStore
+	Idempotency  *IdempotencyStore
+	Tags         *TagStore
+	Users        *UserStore
+}
+
+// newUnitOfWork binds a store of each kind to tx
+func newUnitOfWork(tx Executor) *UnitOfWork {
+	return &UnitOfWork{
+		tx:           tx,
+		savepoints:   make(map[string]int),
+		Posts:        &PostStore{tx: tx},
+		Outbox:       &OutboxStore{tx: tx},
+		Webhooks:     &WebhookStore{tx: tx},
+		Audit:        &AuditStore{tx: tx},
+		Imports:      &ImportStore{tx: tx},
+		Translations: &TranslationStore{tx: tx},
+		Idempotency:  &IdempotencyStore{tx: tx},
+		Tags:         &TagStore{tx: tx},
+		Users:        &UserStore{tx: tx},
+	}
+}
+
+// RunInTx runs fn in a unit of work over a transaction begun on the context's database
+func RunInTx(ctx *gofr.Context, opts TxOptions, fn func(uow *UnitOfWork) error) error {
+	tx, err := ctx.SQL.Begin()
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return RunTx(ctx, tx, opts, fn)
+}
+
+// RunTx runs fn in a unit of work over tx. The transaction is committed when fn returns nil and
+// rolled back when it returns an error or panics; a panic is re-raised after the rollback.
+// Hooks registered with AfterCommit run once the commit succeeded.
+func RunTx(ctx *gofr.Context, tx Tx, opts TxOptions, fn func(uow *UnitOfWork) error) (err error) {
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			ctx.Logger.Errorf("Failed to roll back transaction: %v", rollbackErr)
+		}
+	}()
+
+	// SET TRANSACTION must be the first statement of the transaction
+	if stmt := setTransactionStatement(opts); stmt != "" {
+		if _, err = tx.Exec(stmt); err != nil {
+			return errors.Join(errDatabaseOperation, err)
+		}
+	}
+
+	uow := newUnitOfWork(tx)
+	if err = fn(uow); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	committed = true
+
+	for _, hook := range uow.afterCommit {
+		hook()
+	}
+	return nil
+}
+
+// AfterCommit registers fn to run after the transaction commits. It is dropped when the
+// transaction rolls back, or when the unit of work rolls back to a savepoint taken before fn
+// was registered.
+func (uow *UnitOfWork) AfterCommit(fn func()) {
+	uow.afterCommit = append(uow.afterCommit, fn)
+}
+
+// Savepoint marks a point the unit of work can later roll back to
+func (uow *UnitOfWork) Savepoint(name string) error {
+	if err := uow.exec("SAVEPOINT " + name); err != nil {
+		return err
+	}
+	uow.savepoints[name] = len(uow.afterCommit)
+	return nil
+}
+
+// RollbackTo undoes every statement run since the savepoint, keeping the transaction open
+func (uow *UnitOfWork) RollbackTo(name string) error {
+	if err := uow.exec("ROLLBACK TO SAVEPOINT " + name); err != nil {
+		return err
+	}
+	if hooks, ok := uow.savepoints[name]; ok {
+		uow.afterCommit = uow.afterCommit[:hooks]
+	}
+	return nil
+}
+
+// Release discards a savepoint, keeping its statements
+func (uow *UnitOfWork) Release(name string) error {
+	return uow.exec("RELEASE SAVEPOINT " + name)
+}
+
+// exec runs a transaction control statement
+func (uow *UnitOfWork) exec(stmt string) error {
+	if _, err := uow.tx.Exec(stmt); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// isolationLevels maps the isolation levels supported by Postgres to their SQL names
+var isolationLevels = map[sql.IsolationLevel]string{
+	sql.LevelReadUncommitted: "READ UNCOMMITTED",
+	sql.LevelReadCommitted:   "READ COMMITTED",
+	sql.LevelRepeatableRead:  "REPEATABLE READ",
+	sql.LevelSerializable:    "SERIALIZABLE",
+}
+
+// setTransactionStatement returns the SET TRANSACTION statement for opts, or "" for the defaults
+func setTransactionStatement(opts TxOptions) string {
+	var modes []string
+	if name, ok := isolationLevels[opts.Isolation]; ok {
+		modes = append(modes, "ISOLATION LEVEL "+name)
+	}
+	if opts.ReadOnly {
+		modes = append(modes, "READ ONLY")
+	}
+
+	if len(modes) == 0 {
+		return ""
+	}
+	return "SET TRANSACTION " + strings.Join(modes, ", ")
+}
+
+// ParseIsolation parses an isolation level name such as "serializable" or "repeatable_read".
+// An empty name selects the database default.
+func ParseIsolation(name string) (sql.IsolationLevel, error) {
+	normalized := strings.ToUpper(strings.NewReplacer("_", " ", "-", " ").Replace(strings.TrimSpace(name)))
+	if normalized == "" || normalized == "DEFAULT" {
+		return sql.LevelDefault, nil
+	}
+
+	for level, levelName := range isolationLevels {
+		if levelName == normalized {
+			return level, nil
+		}
+	}
+	return sql.LevelDefault, errors.Join(errInvalidIsolation, errors.New(name))
+}
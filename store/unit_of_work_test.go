@@ -0,0 +1,99 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// TestSetTransactionStatement tests the statement issued for each combination of transaction options
+func TestSetTransactionStatement(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     TxOptions
+		expected string
+	}{
+		{"defaults", TxOptions{}, ""},
+		{"serializable", TxOptions{Isolation: sql.LevelSerializable}, "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"},
+		{"read only", TxOptions{ReadOnly: true}, "SET TRANSACTION READ ONLY"},
+		{"repeatable read only", TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true},
+			"SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY"},
+		{"unsupported level", TxOptions{Isolation: sql.LevelLinearizable}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setTransactionStatement(tt.opts); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseIsolation tests parsing of configured isolation level names
+func TestParseIsolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected sql.IsolationLevel
+	}{
+		{"", sql.LevelDefault},
+		{"default", sql.LevelDefault},
+		{"serializable", sql.LevelSerializable},
+		{"repeatable_read", sql.LevelRepeatableRead},
+		{"Read Committed", sql.LevelReadCommitted},
+	}
+
+	for _, tt := range tests {
+		level, err := ParseIsolation(tt.name)
+		if err != nil {
+			t.Errorf("Expected no error for %q, got %v", tt.name, err)
+		}
+		if level != tt.expected {
+			t.Errorf("Expected %v for %q, got %v", tt.expected, tt.name, level)
+		}
+	}
+
+	if _, err := ParseIsolation("snapshot"); !errors.Is(err, errInvalidIsolation) {
+		t.Errorf("Expected errInvalidIsolation, got %v", err)
+	}
+}
+
+// execRecorder is an Executor that records the statements it runs
+type execRecorder struct {
+	Executor
+	statements []string
+}
+
+func (e *execRecorder) Exec(query string, _ ...any) (sql.Result, error) {
+	e.statements = append(e.statements, query)
+	return nil, nil
+}
+
+// TestUnitOfWork_AfterCommitSavepoints tests that rolling back to a savepoint drops the hooks registered after it
+func TestUnitOfWork_AfterCommitSavepoints(t *testing.T) {
+	uow := newUnitOfWork(&execRecorder{})
+	var ran []string
+
+	uow.AfterCommit(func() { ran = append(ran, "first") })
+	if err := uow.Savepoint("op_1"); err != nil {
+		t.Fatalf("Savepoint: %v", err)
+	}
+	uow.AfterCommit(func() { ran = append(ran, "rolled back") })
+	if err := uow.RollbackTo("op_1"); err != nil {
+		t.Fatalf("RollbackTo: %v", err)
+	}
+	if err := uow.Savepoint("op_2"); err != nil {
+		t.Fatalf("Savepoint: %v", err)
+	}
+	uow.AfterCommit(func() { ran = append(ran, "released") })
+	if err := uow.Release("op_2"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	for _, hook := range uow.afterCommit {
+		hook()
+	}
+	if len(ran) != 2 || ran[0] != "first" || ran[1] != "released" {
+		t.Errorf("Expected hooks [first released], got %v", ran)
+	}
+}
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// UserStore handles database operations for users and their API keys
+type UserStore struct {
+	tx Executor
+}
+
+// NewUserStore creates a new user store instance
+func NewUserStore() *UserStore {
+	return &UserStore{}
+}
+
+// CreateUser persists a new user
+func (us *UserStore) CreateUser(ctx *gofr.Context, req models.CreateUserRequest) (*models.User, error) {
+	return scanUser(executorFor(ctx, us.tx).QueryRow(CreateUserQuery, req.Name, req.Email, req.Role))
+}
+
+// GetUser retrieves a user by ID
+func (us *UserStore) GetUser(ctx *gofr.Context, id int) (*models.User, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+	return scanUser(executorFor(ctx, us.tx).QueryRow(GetUserQuery, id))
+}
+
+// GetUserByEmail retrieves a user by email address
+func (us *UserStore) GetUserByEmail(ctx *gofr.Context, email string) (*models.User, error) {
+	return scanUser(executorFor(ctx, us.tx).QueryRow(GetUserByEmailQuery, email))
+}
+
+// ListUsers retrieves every user
+func (us *UserStore) ListUsers(ctx *gofr.Context) ([]models.User, error) {
+	rows, err := executorFor(ctx, us.tx).Query(ListUsersQuery)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, scanErr := scanUser(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		users = append(users, *user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return users, nil
+}
+
+// RestoreUser inserts a restored user, keeping its creation time
+func (us *UserStore) RestoreUser(ctx *gofr.Context, user *models.User) (*models.User, error) {
+	return scanUser(executorFor(ctx, us.tx).QueryRow(RestoreUserQuery,
+		user.Name, user.Email, user.Role, user.Disabled, user.CreatedAt))
+}
+
+// SetUserDisabled disables or enables the user with id; the keys of a disabled user stop
+// authenticating
+func (us *UserStore) SetUserDisabled(ctx *gofr.Context, id int, disabled bool) (*models.User, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+	return scanUser(executorFor(ctx, us.tx).QueryRow(SetUserDisabledQuery, id, disabled))
+}
+
+// CreateAPIKey persists an API key of the user with userID by the hash of its secret
+func (us *UserStore) CreateAPIKey(ctx *gofr.Context, userID int, name, prefix, keyHash string) (*models.APIKey, error) {
+	return scanAPIKey(executorFor(ctx, us.tx).QueryRow(CreateAPIKeyQuery, userID, name, prefix, keyHash))
+}
+
+// ListAPIKeys retrieves the API keys of the user with userID, or of every user when it is 0
+func (us *UserStore) ListAPIKeys(ctx *gofr.Context, userID int) ([]models.APIKey, error) {
+	rows, err := executorFor(ctx, us.tx).Query(ListAPIKeysQuery, userID)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, scanErr := scanAPIKey(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		keys = append(keys, *key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return keys, nil
+}
+
+// ListAPIKeyRecords retrieves every API key with the hash of its secret
+func (us *UserStore) ListAPIKeyRecords(ctx *gofr.Context) ([]models.APIKeyRecord, error) {
+	rows, err := executorFor(ctx, us.tx).Query(ListAPIKeyRecordsQuery)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	records := []models.APIKeyRecord{}
+	for rows.Next() {
+		var record models.APIKeyRecord
+		key := &record.APIKey
+		err = rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.CreatedAt, &key.LastUsedAt,
+			&key.RevokedAt, &record.KeyHash)
+		if err != nil {
+			return nil, errors.Join(errDatabaseOperation, err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return records, nil
+}
+
+// RestoreAPIKey inserts a restored API key of the user with userID, keeping its times. It
+// returns ErrNotFound when a key with the same hash exists, which is left alone.
+func (us *UserStore) RestoreAPIKey(ctx *gofr.Context, userID int, record *models.APIKeyRecord) (*models.APIKey, error) {
+	return scanAPIKey(executorFor(ctx, us.tx).QueryRow(RestoreAPIKeyQuery, userID, record.Name, record.Prefix,
+		record.KeyHash, record.CreatedAt, record.LastUsedAt, record.RevokedAt))
+}
+
+// RevokeAPIKey revokes the API key with id, or returns ErrNotFound when there is no such
+// unrevoked key
+func (us *UserStore) RevokeAPIKey(ctx *gofr.Context, id int) (*models.APIKey, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+	return scanAPIKey(executorFor(ctx, us.tx).QueryRow(RevokeAPIKeyQuery, id))
+}
+
+// GetActiveAPIKey retrieves the unrevoked API key whose secret hashes to keyHash, along with
+// its user, or returns ErrNotFound when the key is unknown, revoked or its user is disabled
+func (us *UserStore) GetActiveAPIKey(ctx *gofr.Context, keyHash string) (*models.APIKey, *models.User, error) {
+	var key models.APIKey
+	var user models.User
+	err := executorFor(ctx, us.tx).QueryRow(GetActiveAPIKeyQuery, keyHash).Scan(
+		&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt,
+		&user.ID, &user.Name, &user.Email, &user.Role, &user.Disabled, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &key, &user, nil
+}
+
+// TouchAPIKey records the use of the API key with id, at most once a minute
+func (us *UserStore) TouchAPIKey(ctx *gofr.Context, id int) error {
+	if _, err := executorFor(ctx, us.tx).Exec(TouchAPIKeyQuery, id); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// scanUser scans a user row
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Disabled, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &user, nil
+}
+
+// scanAPIKey scans an API key row
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &key, nil
+}
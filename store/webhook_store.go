@@ -0,0 +1,243 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"gofr-blog-service/models"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// WebhookStore handles database operations for webhook subscriptions and deliveries
+type WebhookStore struct {
+	tx Executor
+}
+
+// NewWebhookStore creates a new webhook store instance
+func NewWebhookStore() *WebhookStore {
+	return &WebhookStore{}
+}
+
+// db returns the executor for the store's operations
+func (ws *WebhookStore) db(ctx *gofr.Context) Executor {
+	return executorFor(ctx, ws.tx)
+}
+
+// CreateSubscription persists a new webhook subscription
+func (ws *WebhookStore) CreateSubscription(ctx *gofr.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	row := ws.db(ctx).QueryRow(CreateWebhookSubscriptionQuery,
+		sub.URL, sub.Secret, strings.Join(sub.EventTypes, ","), sub.Active)
+	return scanSubscription(row)
+}
+
+// GetSubscription retrieves a webhook subscription by ID
+func (ws *WebhookStore) GetSubscription(ctx *gofr.Context, id int) (*models.WebhookSubscription, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+	return scanSubscription(ws.db(ctx).QueryRow(GetWebhookSubscriptionQuery, id))
+}
+
+// ListSubscriptions retrieves every webhook subscription
+func (ws *WebhookStore) ListSubscriptions(ctx *gofr.Context) ([]models.WebhookSubscription, error) {
+	rows, err := ws.db(ctx).Query(ListWebhookSubscriptionsQuery)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	subs := []models.WebhookSubscription{}
+	for rows.Next() {
+		sub, scanErr := scanSubscription(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return subs, nil
+}
+
+// RestoreSubscription inserts a restored webhook subscription, keeping its times. It returns
+// ErrNotFound when a subscription to the same URL exists, which is left alone.
+func (ws *WebhookStore) RestoreSubscription(ctx *gofr.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	row := ws.db(ctx).QueryRow(RestoreWebhookSubscriptionQuery,
+		sub.URL, sub.Secret, strings.Join(sub.EventTypes, ","), sub.Active, sub.CreatedAt, sub.UpdatedAt)
+	return scanSubscription(row)
+}
+
+// UpdateSubscription updates the non-empty fields of a webhook subscription
+func (ws *WebhookStore) UpdateSubscription(ctx *gofr.Context, id int, req models.UpdateWebhookRequest) (*models.WebhookSubscription, error) {
+	if id <= 0 {
+		return nil, errInvalidID
+	}
+
+	var eventTypes *string
+	if len(req.EventTypes) > 0 {
+		joined := strings.Join(req.EventTypes, ",")
+		eventTypes = &joined
+	}
+
+	row := ws.db(ctx).QueryRow(UpdateWebhookSubscriptionQuery,
+		id, nullIfEmpty(req.URL), nullIfEmpty(req.Secret), eventTypes, req.Active)
+	return scanSubscription(row)
+}
+
+// DeleteSubscription removes a webhook subscription and its delivery log
+func (ws *WebhookStore) DeleteSubscription(ctx *gofr.Context, id int) error {
+	if id <= 0 {
+		return errInvalidID
+	}
+
+	result, err := ws.db(ctx).Exec(DeleteWebhookSubscriptionQuery, id)
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries queues an event for every active subscription listening for its type
+func (ws *WebhookStore) EnqueueDeliveries(ctx *gofr.Context, eventID, eventType string, payload []byte) error {
+	if _, err := ws.db(ctx).Exec(EnqueueWebhookDeliveriesQuery, eventID, eventType, payload); err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries claims up to limit pending deliveries whose next attempt is due for
+// lease, in one short statement. Claimed deliveries are not due again until the lease ends,
+// so a dispatcher that dies while sending them leaves them to be retried after it.
+func (ws *WebhookStore) ClaimDueDeliveries(ctx *gofr.Context, limit int, lease time.Duration) ([]models.DueWebhookDelivery, error) {
+	rows, err := ws.db(ctx).Query(ClaimDueWebhookDeliveriesQuery, limit, int64(lease.Seconds()))
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var due []models.DueWebhookDelivery
+	for rows.Next() {
+		var d models.DueWebhookDelivery
+		targets := append(deliveryScanTargets(&d.WebhookDelivery), &d.URL, &d.Secret)
+		if scanErr := rows.Scan(targets...); scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		due = append(due, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return due, nil
+}
+
+// RecordAttempt stores the outcome of a delivery attempt
+func (ws *WebhookStore) RecordAttempt(ctx *gofr.Context, id int64, status string, statusCode int,
+	lastError string, nextAttempt time.Time) error {
+	_, err := ws.db(ctx).Exec(RecordWebhookAttemptQuery, id, status, statusCode, lastError, nextAttempt)
+	if err != nil {
+		return errors.Join(errDatabaseOperation, err)
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, optionally by status
+func (ws *WebhookStore) ListDeliveries(ctx *gofr.Context, subscriptionID int, status string,
+	limit, offset int) ([]models.WebhookDelivery, error) {
+	rows, err := ws.db(ctx).Query(ListWebhookDeliveriesQuery, subscriptionID, status, limit, offset)
+	if err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if scanErr := rows.Scan(deliveryScanTargets(&d)...); scanErr != nil {
+			return nil, errors.Join(errDatabaseOperation, scanErr)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	return deliveries, nil
+}
+
+// CountDeliveries counts the delivery log of a subscription, optionally by status
+func (ws *WebhookStore) CountDeliveries(ctx *gofr.Context, subscriptionID int, status string) (int, error) {
+	var count int
+	if err := ws.db(ctx).QueryRow(CountWebhookDeliveriesQuery, subscriptionID, status).Scan(&count); err != nil {
+		return 0, errors.Join(errDatabaseOperation, err)
+	}
+	return count, nil
+}
+
+// ReplayDelivery queues a fresh copy of a logged delivery of a subscription
+func (ws *WebhookStore) ReplayDelivery(ctx *gofr.Context, subscriptionID int, deliveryID int64) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := ws.db(ctx).QueryRow(ReplayWebhookDeliveryQuery, deliveryID, subscriptionID).Scan(deliveryScanTargets(&d)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+	return &d, nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanSubscription scans a webhook subscription row
+func scanSubscription(row rowScanner) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	var eventTypes string
+	err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &eventTypes, &sub.Active, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Join(errDatabaseOperation, err)
+	}
+
+	sub.EventTypes = strings.Split(eventTypes, ",")
+	return &sub, nil
+}
+
+// deliveryScanTargets returns the scan destinations for webhookDeliveryColumns
+func deliveryScanTargets(d *models.WebhookDelivery) []any {
+	return []any{
+		&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.LastStatusCode, &d.LastError, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt,
+	}
+}
+
+// nullIfEmpty maps an empty string to SQL NULL
+func nullIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
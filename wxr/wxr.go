@@ -0,0 +1,143 @@
+// Package wxr reads WordPress eXtended RSS (WXR) exports.
+package wxr
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// Term domains of item categories
+const (
+	DomainCategory = "category"
+	DomainTag      = "post_tag"
+)
+
+// dateLayout is the layout of wp:post_date and wp:comment_date values
+const dateLayout = "2006-01-02 15:04:05"
+
+// ErrInvalidExport reports a document that is not a WXR export
+var ErrInvalidExport = errors.New("invalid WordPress export")
+
+// Export is the content of a WXR file
+type Export struct {
+	Authors []Author
+	Items   []Item
+}
+
+// Author is a wp:author of the export
+type Author struct {
+	ID          int    `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+// Item is an exported post, page, attachment or other post type
+type Item struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	PubDate     string    `xml:"pubDate"`
+	Creator     string    `xml:"creator"`
+	PostID      int       `xml:"post_id"`
+	PostDate    string    `xml:"post_date"`
+	PostDateGMT string    `xml:"post_date_gmt"`
+	PostName    string    `xml:"post_name"`
+	Status      string    `xml:"status"`
+	PostType    string    `xml:"post_type"`
+	Terms       []Term    `xml:"category"`
+	Comments    []Comment `xml:"comment"`
+
+	// Content and Excerpt hold the HTML of content:encoded and excerpt:encoded
+	Content string `xml:"-"`
+	Excerpt string `xml:"-"`
+}
+
+// Term is a category or tag of an item
+type Term struct {
+	Domain string `xml:"domain,attr"`
+	Slug   string `xml:"nicename,attr"`
+	Name   string `xml:",chardata"`
+}
+
+// Comment is a wp:comment of an item
+type Comment struct {
+	ID       int    `xml:"comment_id"`
+	Author   string `xml:"comment_author"`
+	Email    string `xml:"comment_author_email"`
+	DateGMT  string `xml:"comment_date_gmt"`
+	Content  string `xml:"comment_content"`
+	Approved string `xml:"comment_approved"`
+}
+
+// rawItem reads the content:encoded and excerpt:encoded elements of an item, which share a
+// local name and are told apart by namespace
+type rawItem struct {
+	Item
+	Encoded []encoded `xml:"encoded"`
+}
+
+type encoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type document struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Authors []Author  `xml:"author"`
+		Items   []rawItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// Parse reads a WXR export
+func Parse(r io.Reader) (*Export, error) {
+	var doc document
+	decoder := xml.NewDecoder(r)
+	// Exports declare UTF-8; tolerate other declared charsets by reading them as is
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, errors.Join(ErrInvalidExport, err)
+	}
+
+	export := &Export{Authors: doc.Channel.Authors, Items: make([]Item, 0, len(doc.Channel.Items))}
+	for _, raw := range doc.Channel.Items {
+		item := raw.Item
+		for _, e := range raw.Encoded {
+			if strings.Contains(e.XMLName.Space, "excerpt") {
+				item.Excerpt = e.Value
+			} else {
+				item.Content = e.Value
+			}
+		}
+		export.Items = append(export.Items, item)
+	}
+	return export, nil
+}
+
+// Date returns when the item was published in UTC, from wp:post_date_gmt, wp:post_date (in the
+// site's time zone, read as UTC) or pubDate, and whether any of them is set
+func (it *Item) Date() (time.Time, bool) {
+	for _, value := range []string{it.PostDateGMT, it.PostDate} {
+		if t, err := time.Parse(dateLayout, strings.TrimSpace(value)); err == nil && t.Year() > 1 {
+			return t.UTC(), true
+		}
+	}
+	if t, err := time.Parse(time.RFC1123Z, strings.TrimSpace(it.PubDate)); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// TermNames returns the names of the item's terms in domain
+func (it *Item) TermNames(domain string) []string {
+	var names []string
+	for _, term := range it.Terms {
+		if term.Domain == domain {
+			names = append(names, term.Name)
+		}
+	}
+	return names
+}
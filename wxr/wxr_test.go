@@ -0,0 +1,93 @@
+package wxr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleExport = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Example</title>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<wp:author>
+		<wp:author_id>2</wp:author_id>
+		<wp:author_login><![CDATA[jane]]></wp:author_login>
+		<wp:author_display_name><![CDATA[Jane Doe]]></wp:author_display_name>
+	</wp:author>
+	<item>
+		<title>Hello World</title>
+		<pubDate>Tue, 05 Mar 2019 10:00:00 +0000</pubDate>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[<p>Welcome to <strong>WordPress</strong>.</p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short]]></excerpt:encoded>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_date><![CDATA[2019-03-05 11:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2019-03-05 10:00:00]]></wp:post_date_gmt>
+		<wp:post_name><![CDATA[hello-world]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="news"><![CDATA[News]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+		<wp:comment>
+			<wp:comment_id>5</wp:comment_id>
+			<wp:comment_author><![CDATA[Bob]]></wp:comment_author>
+			<wp:comment_content><![CDATA[Nice post]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+		</wp:comment>
+	</item>
+	<item>
+		<title>Draft</title>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+// TestParse tests reading authors, items, terms and comments of an export
+func TestParse(t *testing.T) {
+	export, err := Parse(strings.NewReader(sampleExport))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(export.Authors) != 1 || export.Authors[0].ID != 2 || export.Authors[0].Login != "jane" {
+		t.Errorf("Unexpected authors %+v", export.Authors)
+	}
+	if len(export.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(export.Items))
+	}
+
+	item := export.Items[0]
+	if item.PostID != 11 || item.PostName != "hello-world" || item.Status != "publish" || item.PostType != "post" ||
+		item.Creator != "jane" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	if item.Content != "<p>Welcome to <strong>WordPress</strong>.</p>" || item.Excerpt != "Short" {
+		t.Errorf("Expected content and excerpt to be told apart, got %q and %q", item.Content, item.Excerpt)
+	}
+	if tags := item.TermNames(DomainTag); len(tags) != 1 || tags[0] != "Go" {
+		t.Errorf("Expected the Go tag, got %v", tags)
+	}
+	if len(item.Comments) != 1 || item.Comments[0].Content != "Nice post" {
+		t.Errorf("Unexpected comments %+v", item.Comments)
+	}
+	if date, ok := item.Date(); !ok || !date.Equal(time.Date(2019, 3, 5, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the GMT post date, got %v", date)
+	}
+	if _, ok := export.Items[1].Date(); ok {
+		t.Error("Expected no date for an unscheduled draft")
+	}
+
+	if _, err = Parse(strings.NewReader("<html></html>")); !errors.Is(err, ErrInvalidExport) {
+		t.Errorf("Expected ErrInvalidExport, got %v", err)
+	}
+}